@@ -0,0 +1,105 @@
+package qbr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// Cache stores the results of cached queries, for example in Redis.
+type Cache interface {
+	// Get returns the value stored for the key, and false if there is no such value.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value for the key for the given time to live.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Codec serializes the results stored in a Cache.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is a Codec using the encoding/json package, it is the default codec.
+type JSONCodec struct{}
+
+// Marshal returns the JSON encoding of v.
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal parses the JSON encoded data and stores the result in v.
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// Cached enables caching of the results of the query for the given time to live, in
+// the cache set by the WithCache option. The execution helpers (see TypedQuery.Find)
+// return the cached results instead of executing the query while they are stored.
+//
+// Only read queries are cached, the option is ignored for INSERT, UPDATE and DELETE
+// queries. Results are keyed by a hash of the placeholder, the normalized SQL and
+// the params of the statement. Cache errors do not fail the query, the query is
+// executed instead. The method returns the modified QueryBuilder instance for
+// method chaining.
+func (qb *Query) Cached(ttl time.Duration) *Query {
+	// set time to live
+	qb.cacheTTL = ttl
+
+	// return query
+	return qb
+}
+
+// isCached checks if the results of the query are cached, which they are not for locking reads.
+func (qb *Query) isCached() bool {
+	return qb.cacheTTL > 0 && qb.options.Cache != nil && qb.operation == domain.OperationRead && qb.lock == nil
+}
+
+// cacheKey returns the cache key of the statement with the given kind of results.
+func cacheKey(kind string, stmt *Statement) string {
+	// hash
+	h := sha256.New()
+
+	// write placeholder, kind and normalized sql
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", stmt.Placeholder, kind, strings.Join(strings.Fields(stmt.SQL), " "))
+
+	// write params
+	for _, param := range stmt.Params {
+		fmt.Fprintf(h, "%T\x00%v\x00", param, param)
+	}
+
+	// return key
+	return "qbr:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheGet reads the cached results of the statement with the given kind into v.
+//
+// Returns true if the results were read.
+func (qb *Query) cacheGet(ctx context.Context, kind string, stmt *Statement, v any) bool {
+	// get value
+	data, ok, err := qb.options.Cache.Get(ctx, cacheKey(kind, stmt))
+	if err != nil || !ok {
+		return false
+	}
+
+	// unmarshal value
+	return qb.options.Codec.Unmarshal(data, v) == nil
+}
+
+// cacheSet stores the results v of the statement with the given kind.
+func (qb *Query) cacheSet(ctx context.Context, kind string, stmt *Statement, v any) {
+	// marshal value
+	data, err := qb.options.Codec.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	// set value
+	_ = qb.options.Cache.Set(ctx, cacheKey(kind, stmt), data, qb.cacheTTL)
+}
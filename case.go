@@ -0,0 +1,60 @@
+package qbr
+
+import (
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// CaseExpression is a builder of CASE expressions.
+type CaseExpression struct {
+	expr *domain.Expression
+}
+
+// Case creates a new CASE expression builder, its branches are added by When and
+// its else result by Else:
+//
+//	qbr.Case().When(qbr.GtOrEq(score, 90), "A").When(qbr.GtOrEq(score, 80), "B").Else("C").As("grade")
+//
+// A CASE expression without branches fails the build of the query.
+func Case() *CaseExpression {
+	return &CaseExpression{
+		expr: &domain.Expression{Kind: domain.ExpressionCase},
+	}
+}
+
+// When adds a branch with the given condition and result to the expression. The result
+// can be a field, an expression or a literal, which is bound as a parameter.
+//
+// The branches are rendered in the order they are added, before the else result. The
+// method returns the modified builder for method chaining.
+func (c *CaseExpression) When(cond domain.Condition, result any) *CaseExpression {
+	// add branch before else result
+	c.expr.Operands = slices.Insert(c.expr.Operands, len(c.expr.Whens), toExpressions(result)...)
+	c.expr.Whens = append(c.expr.Whens, cond)
+
+	// return builder
+	return c
+}
+
+// Else sets the result of the expression if no condition of its branches is true. The
+// result can be a field, an expression or a literal, which is bound as a parameter.
+//
+// The method returns the modified builder for method chaining.
+func (c *CaseExpression) Else(result any) *CaseExpression {
+	// set else result
+	c.expr.Operands = append(c.expr.Operands[:len(c.expr.Whens)], toExpressions(result)...)
+
+	// return builder
+	return c
+}
+
+// Expr returns the built expression.
+func (c *CaseExpression) Expr() *domain.Expression {
+	return c.expr
+}
+
+// As returns a computed field of the expression with the given alias, see domain.Expression.As.
+func (c *CaseExpression) As(alias string) *domain.Field {
+	return c.expr.As(alias)
+}
@@ -0,0 +1,98 @@
+package qbr
+
+import "github.com/tyrenix/qbr/domain"
+
+// Clone returns a deep copy of the query builder.
+//
+// The conditions, selected fields, sort parameters, data and pagination of the
+// clone are independent of the original, so the clone can be used to derive
+// variants of a base query without affecting it.
+func (qb *Query) Clone() *Query {
+	// copy query
+	clone := *qb
+
+	// copy slices
+	clone.selects = append([]domain.Field(nil), qb.selects...)
+	clone.conditions = cloneConditions(qb.conditions)
+	clone.sort = append([]domain.Sort(nil), qb.sort...)
+	clone.data = append([]domain.Data(nil), qb.data...)
+	if qb.rows != nil {
+		clone.rows = make([][]domain.Data, len(qb.rows))
+		for i, row := range qb.rows {
+			clone.rows[i] = append([]domain.Data(nil), row...)
+		}
+	}
+	clone.mapColumns = append([]string(nil), qb.mapColumns...)
+	clone.omits = append([]string(nil), qb.omits...)
+	clone.indexHints = append([]domain.IndexHint(nil), qb.indexHints...)
+	clone.hints = append([]string(nil), qb.hints...)
+	clone.middlewares = append([]Middleware(nil), qb.middlewares...)
+	if qb.lock != nil {
+		lock := *qb.lock
+		lock.Of = append([]string(nil), qb.lock.Of...)
+		clone.lock = &lock
+	}
+	clone.ctes = append([]domain.CTE(nil), qb.ctes...)
+	clone.groupBy = append([]domain.Field(nil), qb.groupBy...)
+	clone.having = cloneConditions(qb.having)
+	clone.distinctOn = append([]domain.Field(nil), qb.distinctOn...)
+	clone.compounds = append([]domain.Compound(nil), qb.compounds...)
+	clone.after = append([]any(nil), qb.after...)
+	if qb.from != nil {
+		from := *qb.from
+		clone.from = &from
+	}
+	clone.using = append([]domain.Source(nil), qb.using...)
+	if qb.joins != nil {
+		clone.joins = make([]domain.Join, len(qb.joins))
+		for i, join := range qb.joins {
+			join.On = cloneConditions(join.On)
+			clone.joins[i] = join
+		}
+	}
+	if qb.conflict != nil {
+		conflict := *qb.conflict
+		conflict.Target = append([]domain.Field(nil), qb.conflict.Target...)
+		conflict.Where = cloneConditions(qb.conflict.Where)
+		conflict.Updates = append([]domain.Data(nil), qb.conflict.Updates...)
+		clone.conflict = &conflict
+	}
+	if qb.insertSelect != nil {
+		insertSelect := *qb.insertSelect
+		insertSelect.Columns = append([]domain.Field(nil), qb.insertSelect.Columns...)
+		clone.insertSelect = &insertSelect
+	}
+	if qb.version != nil {
+		version := *qb.version
+		clone.version = &version
+	}
+
+	// return clone
+	return &clone
+}
+
+// cloneConditions returns a deep copy of the given conditions, including the
+// sub conditions of logical conditions.
+func cloneConditions(conds []domain.Condition) []domain.Condition {
+	// check is nil
+	if conds == nil {
+		return nil
+	}
+
+	// copied conditions
+	result := make([]domain.Condition, len(conds))
+
+	// copy conditions
+	for i, cond := range conds {
+		// copy sub conditions
+		if sub, ok := cond.Value.([]domain.Condition); ok {
+			cond.Value = cloneConditions(sub)
+		}
+
+		// set condition
+		result[i] = cond
+	}
+
+	// return conditions
+	return result
+}
@@ -0,0 +1,61 @@
+package qbr_test
+
+import (
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestClone(t *testing.T) {
+	// base query of the request
+	status, age := qbr.FieldOf[user]("status"), qbr.FieldOf[user]("age")
+	base := func() *qbr.Query {
+		return qbr.NewRead().Model(user{}).Select(qbr.FieldOf[user]("id"), qbr.FieldOf[user]("name")).
+			Where(qbr.Eq(status, "active")).Sort(qbr.NewSortAsc(qbr.FieldOf[user]("id"))).Limit(10).Offset(20)
+	}
+
+	t.Run("clone changed", func(t *testing.T) {
+		// changing the clone does not change the original
+		qb := base()
+		clone := qb.Clone().Where(qbr.Gt(age, 18)).Select(age).Sort(qbr.NewSortDesc(age)).Limit(50).Offset(0)
+		qbrtest.Golden(t, clone)
+
+		// original is unchanged
+		want, err := base().Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := qb.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.SQL != want.SQL || len(got.Params) != len(want.Params) {
+			t.Fatalf("got %q %v, want %q %v", got.SQL, got.Params, want.SQL, want.Params)
+		}
+	})
+
+	t.Run("original changed", func(t *testing.T) {
+		// changing the original does not change the clone
+		qb := base()
+		clone := qb.Clone()
+		qb.Where(qbr.Gt(age, 18)).Select(age).Sort(qbr.NewSortDesc(age)).Limit(50)
+		qbrtest.Golden(t, clone)
+	})
+
+	t.Run("variants", func(t *testing.T) {
+		// variants of the base query do not contaminate each other
+		qb := base()
+		count := qb.Clone().Select(qbr.Count(qbr.NewAllField()).As("total")).Limit(0).Offset(0)
+		page := qb.Clone().Where(qbr.Gt(age, 30))
+		if got := len(count.GetConditions()); got != 1 {
+			t.Fatalf("got %d conditions of the count, want 1", got)
+		}
+		if got := len(page.GetConditions()); got != 2 {
+			t.Fatalf("got %d conditions of the page, want 2", got)
+		}
+		if got := qb.GetLimit(); got != 10 {
+			t.Fatalf("got limit %d of the base query, want 10", got)
+		}
+	})
+}
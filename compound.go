@@ -0,0 +1,93 @@
+package qbr
+
+import (
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// Union combines the rows of the read query with the rows of the given queries, removing
+// duplicate rows, for example:
+//
+//	active := New("read").Model(User{}).Select(id).Where(Eq(status, "active"))
+//	qb := New("read").Model(Admin{}).Select(id).Union(active).Sort(NewSortAsc(id)).Limit(10)
+//
+// The queries are read *Query or *TypedQuery values selecting the same count of columns, and
+// their params are bound after the params of the query, in their order. The sort parameters,
+// limit and offset of the query are applied to the combined rows, so they reference the
+// selected columns by their names, and in SQL Server limits are rendered as FETCH instead of
+// TOP. The combined queries are parenthesized, so their own sort parameters and limits apply
+// to their rows, except in SQLite, which does not parenthesize them.
+//
+// Locking clauses and keyset pagination of combined queries are rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Union(queries ...domain.Subquery) *Query {
+	return qb.addCompounds(domain.CompoundUnion, queries)
+}
+
+// UnionAll combines the rows of the read query with the rows of the given queries, keeping
+// duplicate rows, see Union.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) UnionAll(queries ...domain.Subquery) *Query {
+	return qb.addCompounds(domain.CompoundUnionAll, queries)
+}
+
+// Intersect keeps the rows of the read query which are returned by the given queries too,
+// see Union. INTERSECT takes precedence over UNION and EXCEPT, and set operations are grouped
+// by combining a query with its own set operations, for example a.Union(b.Intersect(c)),
+// except in SQLite, which applies set operations from left to right.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Intersect(queries ...domain.Subquery) *Query {
+	return qb.addCompounds(domain.CompoundIntersect, queries)
+}
+
+// Except removes the rows returned by the given queries from the rows of the read query, see
+// Union. It is rendered as MINUS in Oracle.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Except(queries ...domain.Subquery) *Query {
+	return qb.addCompounds(domain.CompoundExcept, queries)
+}
+
+// GetCompounds returns the set operations of the query, or nil if no set operations are set.
+func (qb *Query) GetCompounds() []domain.Compound {
+	return qb.compounds
+}
+
+// addCompounds adds the set operation of the given type with each of the queries, see Union.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) addCompounds(t domain.CompoundType, queries []domain.Subquery) *Query {
+	// add set operations
+	for _, sub := range queries {
+		// check is query nil
+		if sub == nil {
+			return qb.addError(fmt.Errorf("%w: %s without query", ErrInvalidCompound, t))
+		}
+
+		// add set operation
+		qb.compounds = append(qb.compounds, domain.Compound{Type: t, Subquery: sub})
+	}
+
+	// return query
+	return qb
+}
+
+// checkCompounds checks the set operations of the query.
+func (qb *Query) checkCompounds() error {
+	switch {
+	case len(qb.compounds) == 0:
+		return nil
+	case qb.operation != domain.OperationRead:
+		return fmt.Errorf("%w: %s on %v query", ErrInvalidCompound, qb.compounds[0].Type, qb.operation)
+	case qb.lock != nil:
+		return fmt.Errorf("%w: %s with locking clause", ErrInvalidCompound, qb.compounds[0].Type)
+	case len(qb.after) > 0:
+		return fmt.Errorf("%w: %s with keyset pagination", ErrInvalidCompound, qb.compounds[0].Type)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,372 @@
+package qbr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// OnConflict sets the conflict target of the insert query to the unique index of the given
+// fields, which are columns or index expressions, for example for an index on lower(email):
+//
+//	qb.OnConflict(Func("lower", NewField(WithDB("email"))).As(""))
+//
+// The conflict action is set by DoUpdate, DoUpdateSet or DoNothing, and the predicate of partial unique indexes by
+// OnConflictWhere. Conflict clauses on other than create queries are rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) OnConflict(target ...*domain.Field) *Query {
+	// check fields
+	for _, field := range target {
+		if field == nil {
+			return qb.addError(errors.New("nil field in conflict target"))
+		}
+	}
+
+	// set target
+	conflict := qb.initConflict()
+	conflict.Target = nil
+	for _, field := range target {
+		conflict.Target = append(conflict.Target, *field)
+	}
+
+	// return query
+	return qb
+}
+
+// OnConflictConstraint sets the conflict target of the insert query to the named constraint,
+// for example "ON CONFLICT ON CONSTRAINT users_email_key", see OnConflict.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) OnConflictConstraint(name string) *Query {
+	// check name
+	if !tableNamePattern.MatchString(name) || strings.Contains(name, ".") {
+		return qb.addError(fmt.Errorf("invalid conflict constraint %q", name))
+	}
+
+	// set constraint
+	qb.initConflict().Constraint = name
+
+	// return query
+	return qb
+}
+
+// OnConflictWhere adds the predicate of the partial unique index of the conflict target,
+// for example "ON CONFLICT (email) WHERE deleted_at IS NULL", see OnConflict. The predicate
+// must imply the predicate of the index when the statement is planned, so conditions without
+// params, such as Cond(Expr("deleted_at IS NULL")), should be used.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) OnConflictWhere(conds ...domain.Condition) *Query {
+	// add conditions
+	conflict := qb.initConflict()
+	conflict.Where = append(conflict.Where, conds...)
+
+	// return query
+	return qb
+}
+
+// DoUpdateSet sets the conflict action of the insert query to DO UPDATE with the given data,
+// whose values may be fields and expressions of the existing row and of the row proposed for
+// insertion, see Excluded, for example:
+//
+//	count := NewField(WithDB("count"))
+//	qb.OnConflict(id).DoUpdateSet(
+//		NewData(name, Excluded(name)),
+//		NewData(count, Add(NewField(WithDB("events.count")), Excluded(count))),
+//	)
+//
+// In contrast to Set, zero values are set too. Columns of the existing row must be
+// qualified with the table in expressions, since they are ambiguous otherwise.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) DoUpdateSet(data ...*domain.Data) *Query {
+	// check data
+	if len(data) == 0 {
+		return qb.addError(errors.New("DoUpdateSet without data"))
+	}
+	for _, d := range data {
+		if d == nil || d.Field == nil {
+			return qb.addError(errors.New("nil field in conflict data"))
+		}
+	}
+
+	// set action
+	conflict := qb.initConflict()
+	if err := setConflictAction(conflict, domain.ConflictDoUpdate); err != nil {
+		return qb.addError(err)
+	}
+
+	// add data, field values are set to the column
+	for _, d := range data {
+		if field, ok := d.Value.(*domain.Field); ok {
+			d = NewData(d.Field, toExpressions(field)[0])
+		}
+		conflict.Updates = append(conflict.Updates, *d)
+	}
+
+	// return query
+	return qb
+}
+
+// DoUpdate sets the conflict action of the insert query to DO UPDATE, setting the given fields
+// to their values proposed for insertion, for example for an upsert of a struct:
+//
+//	qb := NewUpsert().SetStruct(user).OnConflict(email).DoUpdate(name, updatedAt)
+//
+// Without fields, all the inserted columns except the columns of the conflict target are set
+// to their proposed values when the query is built, so only the conflict target has to be
+// specified. The tenant column is never updated.
+//
+// In MySQL the clause is built as "ON DUPLICATE KEY UPDATE name = VALUES(name)", whose
+// conflict target is every unique index of the table, so OnConflict is not required there.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) DoUpdate(fields ...*domain.Field) *Query {
+	// check fields
+	for _, field := range fields {
+		if field == nil {
+			return qb.addError(errors.New("nil field in conflict update"))
+		}
+	}
+
+	// set action
+	conflict := qb.initConflict()
+	if err := setConflictAction(conflict, domain.ConflictDoUpdate); err != nil {
+		return qb.addError(err)
+	}
+
+	// update inserted columns
+	if len(fields) == 0 {
+		conflict.UpdateInserted = true
+		return qb
+	}
+
+	// add data of proposed values
+	for _, field := range fields {
+		conflict.Updates = append(conflict.Updates, *NewData(field, Excluded(field).Expression))
+	}
+
+	// return query
+	return qb
+}
+
+// DoNothing sets the conflict action of the insert query to DO NOTHING, so rows which conflict
+// with existing rows are skipped, for example for idempotent inserts. The conflict target is
+// optional, without it conflicts on any unique index are skipped. ExecInsert reports if the
+// row was skipped, and RETURNING clauses return only the inserted rows, so Find of TypedQuery
+// returns the inserted rows only, for example to get their IDs. MySQL has no DO NOTHING
+// action, so the query fails to build there, see Ignore for INSERT IGNORE.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) DoNothing() *Query {
+	// set action
+	if err := setConflictAction(qb.initConflict(), domain.ConflictDoNothing); err != nil {
+		return qb.addError(err)
+	}
+
+	// return query
+	return qb
+}
+
+// Ignore makes the insert query skip the rows which cannot be inserted, with INSERT IGNORE in
+// MySQL, for example for idempotent inserts:
+//
+//	NewCreate(WithDialect(MySQL)).SetStruct(user).Ignore() // INSERT IGNORE INTO users ...
+//
+// MySQL has no DO NOTHING action, and INSERT IGNORE is not modeled by DoNothing, because it
+// skips more than conflicts: rows with other errors, such as invalid values or missing
+// foreign keys, are skipped or inserted with adjusted values too, and every skipped row or
+// adjusted value is reported as a warning instead of an error. ExecInsert reports the rows
+// which were skipped, and the warnings are counted by "SHOW COUNT(*) WARNINGS" or listed by
+// "SHOW WARNINGS" on the connection of the insert, so pass a *sql.Conn or *sql.Tx to check
+// them.
+//
+// INSERT IGNORE is only supported by the MySQL dialect, queries with Ignore fail to build in
+// the other dialects, and Ignore on other than create queries is rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Ignore() *Query {
+	// set ignore
+	qb.ignore = true
+
+	// return query
+	return qb
+}
+
+// GetIgnore reports whether the insert query skips the rows which cannot be inserted, see Ignore.
+func (qb *Query) GetIgnore() bool {
+	return qb.ignore
+}
+
+// WithSkipConflicts makes InsertManyChunked skip the rows which conflict with existing rows on
+// any unique index, with an ON CONFLICT DO NOTHING clause on every statement. MySQL does not
+// support DO NOTHING, so InsertManyChunked fails there with the option.
+func WithSkipConflicts() Option {
+	return func(o *Options) error {
+		// skip conflicts
+		o.SkipConflicts = true
+		return nil
+	}
+}
+
+// InsertResult is the result of an insert which skips conflicting rows, see DoNothing and Ignore.
+type InsertResult struct {
+	Inserted int64 // Number of inserted rows.
+	Skipped  int64 // Number of rows skipped due to a conflict.
+}
+
+// ExecInsert executes the insert query as Exec, and reports how many of its rows were inserted
+// or skipped due to a conflict, see DoNothing, or skipped by INSERT IGNORE, see Ignore.
+//
+// Returns the result, or an error if the query is not an insert query, or could not be built
+// or executed.
+func (qb *Query) ExecInsert(ctx context.Context, exec Executor) (InsertResult, error) {
+	// check is insert
+	if qb.operation != domain.OperationCreate {
+		return InsertResult{}, fmt.Errorf("ExecInsert on %v query", qb.operation)
+	}
+
+	// execute query
+	result, err := qb.Exec(ctx, exec)
+	if err != nil {
+		return InsertResult{}, err
+	}
+
+	// return result
+	n := result.RowsAffected()
+	return InsertResult{Inserted: n, Skipped: int64(len(qb.rows)+1) - n}, nil
+}
+
+// NewUpsert creates a new insert query builder with the given options, whose rows are updated
+// instead if they conflict with existing rows, for example:
+//
+//	NewUpsert().SetStruct(user).OnConflict(id).DoUpdate()
+//
+// Upserts are create queries, so the fields are inserted as for NewCreate, and the conflict
+// action must be set by DoUpdate, DoUpdateSet or DoNothing, otherwise Validate fails.
+//
+// Returns the created query builder.
+func NewUpsert(opts ...Option) *Query {
+	// create query with conflict clause
+	qb := NewCreate(opts...)
+	qb.initConflict()
+
+	// return query
+	return qb
+}
+
+// Excluded returns the field of the row proposed for insertion in the conflict action of
+// an insert query, for example "excluded.count", or "VALUES(count)" in MySQL, see DoUpdateSet.
+func Excluded(field *domain.Field) *domain.Field {
+	column := field.Column(domain.OperationCreate)
+	return (&domain.Expression{Kind: domain.ExpressionExcluded, Field: NewField(WithDB(column))}).As(column)
+}
+
+// GetConflict returns the conflict clause of the query, or nil if no conflict clause is set.
+func (qb *Query) GetConflict() *domain.Conflict {
+	return qb.conflict
+}
+
+// initConflict returns the conflict clause of the query, creating it if not set.
+func (qb *Query) initConflict() *domain.Conflict {
+	// init conflict
+	if qb.conflict == nil {
+		qb.conflict = &domain.Conflict{}
+	}
+
+	// return conflict
+	return qb.conflict
+}
+
+// setConflictAction sets the action of the conflict clause.
+//
+// Returns an error if a different action is set.
+func setConflictAction(conflict *domain.Conflict, action domain.ConflictAction) error {
+	// check is conflicting
+	if conflict.Action != "" && conflict.Action != action {
+		return fmt.Errorf("conflicting conflict actions: %s and %s", conflict.Action, action)
+	}
+
+	// set action
+	conflict.Action = action
+	return nil
+}
+
+// withInsertedUpdates returns the query with the updates of the conflict clause set to the
+// proposed values of the inserted columns, except the columns of the conflict target and the
+// tenant column, if DoUpdate was called without fields, see DoUpdate. The query is cloned
+// before it is modified, unless it is a clone already, which is the case if clone is false.
+//
+// Returns the query, or an error if no column is left to update.
+func (qb *Query) withInsertedUpdates(clone bool) (*Query, error) {
+	// check is updating inserted columns
+	if qb.conflict == nil || !qb.conflict.UpdateInserted {
+		return qb, nil
+	}
+
+	// clone query
+	if clone {
+		qb = qb.Clone()
+	}
+
+	// columns of conflict target
+	target := make(map[string]bool, len(qb.conflict.Target))
+	for _, field := range qb.conflict.Target {
+		target[field.Column(domain.OperationCreate)] = true
+	}
+
+	// inserted columns, of a subquery or of the data
+	var fields []*domain.Field
+	if qb.insertSelect != nil {
+		for i := range qb.insertSelect.Columns {
+			fields = append(fields, &qb.insertSelect.Columns[i])
+		}
+	}
+	for _, d := range qb.data {
+		fields = append(fields, d.Field)
+	}
+
+	// add proposed values of inserted columns
+	for _, field := range fields {
+		if field == nil || field.Tenant || target[field.Column(domain.OperationCreate)] {
+			continue
+		}
+		qb.conflict.Updates = append(qb.conflict.Updates, *NewData(field, Excluded(field).Expression))
+	}
+
+	// check updates
+	if len(qb.conflict.Updates) == 0 {
+		return nil, fmt.Errorf("%w: DO UPDATE without columns to update", ErrInvalidConflict)
+	}
+
+	// return query
+	qb.conflict.UpdateInserted = false
+	return qb, nil
+}
+
+// checkConflict checks the conflict clause of the query.
+func (qb *Query) checkConflict() error {
+	switch c := qb.conflict; {
+	case qb.ignore && qb.operation != domain.OperationCreate:
+		return fmt.Errorf("%w: INSERT IGNORE on %v query", ErrInvalidConflict, qb.operation)
+	case c == nil:
+		return nil
+	case qb.operation != domain.OperationCreate:
+		return fmt.Errorf("%w: ON CONFLICT on %v query", ErrInvalidConflict, qb.operation)
+	case c.Action == "":
+		return fmt.Errorf("%w: ON CONFLICT without action", ErrInvalidConflict)
+	case len(c.Target) > 0 && c.Constraint != "":
+		return fmt.Errorf("%w: conflict target with both columns and constraint %q", ErrInvalidConflict, c.Constraint)
+	case len(c.Where) > 0 && len(c.Target) == 0:
+		return fmt.Errorf("%w: conflict target predicate without columns", ErrInvalidConflict)
+	case c.Action == domain.ConflictDoUpdate && len(c.Target) == 0 && c.Constraint == "" && sqlbuilder.HasConflictTarget(qb.options.dialect()):
+		return fmt.Errorf("%w: %s without conflict target", ErrInvalidConflict, c.Action)
+	default:
+		return nil
+	}
+}
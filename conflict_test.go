@@ -0,0 +1,61 @@
+package qbr_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestIgnore(t *testing.T) {
+	// insert of user in dialect
+	insert := func(d domain.Dialect) *qbr.Query {
+		return qbr.NewCreate(qbr.WithDialect(d)).Model(user{}).SetStruct(user{Name: "ann", Email: "ann@example.com"}).Ignore()
+	}
+
+	t.Run("mysql", func(t *testing.T) {
+		qbrtest.Golden(t, insert(qbr.MySQL))
+	})
+
+	t.Run("other dialects", func(t *testing.T) {
+		for _, d := range []domain.Dialect{qbr.Postgres, qbr.SQLite, qbr.SQLServer, qbr.Oracle, qbr.DB2} {
+			_, err := insert(d).Build()
+			wantError(t, err, "INSERT IGNORE is not supported by the "+d.Name()+" dialect")
+		}
+	})
+
+	t.Run("update query", func(t *testing.T) {
+		_, err := qbr.NewUpdate(qbr.WithDialect(qbr.MySQL)).Table("users").
+			Set(qbr.NewData(qbr.NewField(qbr.WithDB("name")), "ann")).Where(qbr.Eq(qbr.NewField(qbr.WithDB("id")), 1)).Ignore().Build()
+		if !errors.Is(err, qbr.ErrInvalidConflict) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrInvalidConflict)
+		}
+	})
+
+	t.Run("skipped rows", func(t *testing.T) {
+		// insert skipped with a warning, which affects no rows
+		exec := &fakeExecutor{affected: 0}
+		result, err := insert(qbr.MySQL).ExecInsert(context.Background(), exec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// check result
+		if result.Inserted != 0 || result.Skipped != 1 {
+			t.Fatalf("got %+v, want 0 inserted and 1 skipped row", result)
+		}
+		if len(exec.queries) != 1 || !strings.HasPrefix(exec.queries[0], "INSERT IGNORE ") {
+			t.Fatalf("got statements %q, want a single INSERT IGNORE", exec.queries)
+		}
+	})
+}
+
+func TestDoNothingMySQL(t *testing.T) {
+	// DO NOTHING is not mapped to INSERT IGNORE
+	_, err := qbr.NewCreate(qbr.WithDialect(qbr.MySQL)).Model(user{}).SetStruct(user{Name: "ann"}).DoNothing().Build()
+	wantError(t, err, "DO NOTHING is not supported by the mysql dialect")
+}
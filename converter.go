@@ -0,0 +1,106 @@
+package qbr
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// RegisterConverter registers a function converting values of the type T to values bound as
+// query params, for example for types which do not implement driver.Valuer:
+//
+//	qbr.RegisterConverter(func(d Money) (any, error) { return d.String(), nil })
+//
+// The converter is used for values of T and non-nil pointers to T in data, conditions and
+// expressions, nil pointers are bound as NULL. A converter registered for T replaces the
+// converter registered before. Registration is safe for concurrent use.
+func RegisterConverter[T any](fn func(T) (any, error)) {
+	sqlbuilder.RegisterConverter(reflect.TypeFor[T](), func(value any) (any, error) {
+		return fn(value.(T))
+	})
+}
+
+// ScanFunc scans the column value src into the value pointed to by dest, src is one of the
+// source types of sql.Scanner, or nil for NULL.
+type ScanFunc[T any] func(src any, dest *T) error
+
+// scanFuncs contains the registered scan functions by the types of the scanned values.
+var scanFuncs = struct {
+	sync.RWMutex
+	funcs map[reflect.Type]func(src any, dest any) error
+}{funcs: make(map[reflect.Type]func(src any, dest any) error)}
+
+// RegisterScanner registers a function scanning column values into struct fields of the
+// type T, which is used by ScanAll and the execution helpers in place of the default
+// scanning, for example for types which do not implement sql.Scanner.
+//
+// Fields of pointers to T are set to nil for NULL values, and to a new value scanned by
+// fn otherwise. A function registered for T replaces the function registered before.
+// Registration is safe for concurrent use.
+func RegisterScanner[T any](fn ScanFunc[T]) {
+	// register scan function
+	scanFuncs.Lock()
+	defer scanFuncs.Unlock()
+	scanFuncs.funcs[reflect.TypeFor[T]()] = func(src any, dest any) error {
+		return fn(src, dest.(*T))
+	}
+
+	// clear fields of models, structs of T are not flattened anymore
+	modelFieldsCache.Clear()
+}
+
+// registeredScanner returns a scanner into the struct field pointed to by dest, if a scan
+// function is registered for the type of the field or the type pointed to by the field.
+//
+// Returns the scanner and true if a scan function is registered.
+func registeredScanner(dest reflect.Value) (*funcScanner, bool) {
+	// field type
+	t := dest.Type().Elem()
+
+	// find scan function
+	scanFuncs.RLock()
+	defer scanFuncs.RUnlock()
+	if fn, ok := scanFuncs.funcs[t]; ok {
+		return &funcScanner{dest: dest, fn: fn}, true
+	}
+	if t.Kind() == reflect.Ptr {
+		if fn, ok := scanFuncs.funcs[t.Elem()]; ok {
+			return &funcScanner{dest: dest, fn: fn, ptr: true}, true
+		}
+	}
+
+	// not found
+	return nil, false
+}
+
+// funcScanner scans a column value with a registered scan function, see RegisterScanner.
+type funcScanner struct {
+	dest reflect.Value                 // Pointer to the struct field.
+	fn   func(src any, dest any) error // Scan function.
+	ptr  bool                          // Is the field a pointer.
+}
+
+// Scan scans the source into the struct field.
+func (s *funcScanner) Scan(src any) error {
+	// check is pointer field
+	if !s.ptr {
+		return s.fn(src, s.dest.Interface())
+	}
+
+	// set nil for null
+	field := s.dest.Elem()
+	if src == nil {
+		field.SetZero()
+		return nil
+	}
+
+	// scan new value
+	v := reflect.New(field.Type().Elem())
+	if err := s.fn(src, v.Interface()); err != nil {
+		return fmt.Errorf("scan %v: %w", field.Type().Elem(), err)
+	}
+	field.Set(v)
+	return nil
+}
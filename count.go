@@ -0,0 +1,156 @@
+package qbr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// windowCountColumn is the column of the total rows selected by FindAndCount with
+// the window count option.
+const windowCountColumn = "qbr_window_count"
+
+// countResult is the cached result of FindAndCount.
+type countResult[T any] struct {
+	Items []T
+	Total int64
+}
+
+// FindAndCount executes the query using the given executor, scans the resulting rows
+// into dest and returns the total count of the rows matching the conditions of the
+// query, ignoring its sort parameters, limit and offset.
+//
+// The total is counted with a derived COUNT(*) query using the same conditions, which
+// counts the rows of the query in a subquery if it is distinct, grouped or has set
+// operations, or with COUNT(*) OVER () in the page query if the query has the
+// WithWindowCount option and no set operations. The count query is skipped if the
+// page is shorter than the limit, since the total is known then. The results of
+// cached queries are read from the cache if stored, see Query.Cached.
+//
+// Returns the total count, or an error if a query could not be built, executed or
+// scanned.
+func (q *TypedQuery[T]) FindAndCount(ctx context.Context, exec Executor, dest *[]T) (int64, error) {
+	// check is destination not nil
+	if dest == nil {
+		return 0, errors.New("nil destination in FindAndCount")
+	}
+
+	// derive context with timeout
+	ctx, cancel := q.query.withTimeout(ctx)
+	defer cancel()
+
+	// page query
+	page := q.query.Clone()
+
+	// total count of window function
+	var total *int64
+	var extra map[string]any
+	if q.query.options.WindowCount && len(q.query.compounds) == 0 {
+		page.selects = append(page.selects, *Expr("COUNT(*) OVER ()").As(windowCountColumn))
+		extra = map[string]any{windowCountColumn: &total}
+	}
+
+	// build page query
+	stmt, err := page.BuildContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// cached results
+	var result countResult[T]
+	if q.query.isCached() && q.query.cacheGet(ctx, "count", stmt, &result) {
+		*dest = result.Items
+		return result.Total, nil
+	}
+
+	// execute page query
+	rows, err := page.queryContext(ctx, exec, stmt)
+	if err != nil {
+		return 0, err
+	}
+
+	// scan page
+	result.Items, err = scanAll[T](rows, page.options, extra)
+	if err != nil {
+		return 0, q.query.timeoutError(ctx, stmt, err)
+	}
+
+	// count total
+	switch {
+	case total != nil: // counted by window function
+		result.Total = *total
+	case (page.limit == 0 || uint64(len(result.Items)) < page.limit) && (len(result.Items) > 0 || page.offset == 0): // known from the page
+		result.Total = int64(page.offset) + int64(len(result.Items))
+	default: // counted by count query
+		// count query
+		count := q.query.Clone()
+		count.sort = nil
+		count.limit = 0
+		count.offset = 0
+		count.lock = nil
+
+		// count the rows of distinct and grouped queries in a subquery
+		if count.distinct || len(count.distinctOn) > 0 || len(count.groupBy) > 0 || len(count.compounds) > 0 {
+			outer := New(domain.OperationRead)
+			outer.options = count.options
+			count = outer.From(As(count, "qbr_count"))
+		}
+		count.selects = []domain.Field{*NewCountField(NewAllField())}
+		count.omits = nil
+
+		// execute count query
+		result.Total, err = count.count(ctx, exec)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// cache results
+	if q.query.isCached() {
+		q.query.cacheSet(ctx, "count", stmt, result)
+	}
+
+	// set items
+	*dest = result.Items
+
+	// return total count
+	return result.Total, nil
+}
+
+// count executes the count query using the given executor and scans the count.
+//
+// Returns the count, or an error if the query could not be built, executed or scanned.
+func (qb *Query) count(ctx context.Context, exec Executor) (int64, error) {
+	// build query
+	stmt, err := qb.BuildContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// execute query
+	rows, err := qb.queryContext(ctx, exec, stmt)
+	if err != nil {
+		return 0, err
+	}
+
+	// close rows
+	defer rows.Close()
+
+	// check count row
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, errors.New("count query returned no rows")
+	}
+
+	// scan count
+	var total int64
+	if err := rows.Scan(&total); err != nil {
+		return 0, err
+	}
+
+	// return count
+	return total, rows.Close()
+}
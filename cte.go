@@ -0,0 +1,74 @@
+package qbr
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// With adds the common table expression of the subquery with the given name to the query,
+// which is rendered in the WITH clause before the statement, for example:
+//
+//	recent := NewQuery[Order]("read").Where(Gt(FieldOf[Order]("created_at"), since))
+//	qb := New("read").Model(Order{}).With("recent", recent).From("recent")
+//
+// The subquery is a read *Query or *TypedQuery, and the params of the expressions are bound
+// before the params of the statement, in the order of the expressions. The expression is
+// referenced by its name as a table in From and Join, which are called after With so the
+// table prefix (see WithTablePrefix) is not added to the name. Common table expressions on
+// create queries are rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) With(name string, sub domain.Subquery) *Query {
+	return qb.addCTE(name, sub, false)
+}
+
+// WithRecursive adds the recursive common table expression of the subquery with the given name
+// to the query, which can reference itself by its name, see With. The WITH clause is "WITH
+// RECURSIVE" if any of the expressions of the query is recursive, except in SQL Server, which
+// has no RECURSIVE keyword.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) WithRecursive(name string, sub domain.Subquery) *Query {
+	return qb.addCTE(name, sub, true)
+}
+
+// GetCTEs returns the common table expressions of the query, or nil if no expressions are set.
+func (qb *Query) GetCTEs() []domain.CTE {
+	return qb.ctes
+}
+
+// addCTE adds the common table expression of the subquery with the given name, see With.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) addCTE(name string, sub domain.Subquery, recursive bool) *Query {
+	// check expression
+	switch {
+	case !aliasPattern.MatchString(name):
+		return qb.addError(fmt.Errorf("%w: invalid name %q", ErrInvalidCTE, name))
+	case sub == nil:
+		return qb.addError(fmt.Errorf("%w: %s without subquery", ErrInvalidCTE, name))
+	case qb.isCTE(name):
+		return qb.addError(fmt.Errorf("%w: duplicate name %q", ErrInvalidCTE, name))
+	}
+
+	// add expression
+	qb.ctes = append(qb.ctes, domain.CTE{Name: name, Subquery: sub, Recursive: recursive})
+
+	// return query
+	return qb
+}
+
+// isCTE checks if the table is the name of a common table expression of the query.
+func (qb *Query) isCTE(table string) bool {
+	return slices.ContainsFunc(qb.ctes, func(cte domain.CTE) bool { return cte.Name == table })
+}
+
+// checkCTEs checks the common table expressions of the query.
+func (qb *Query) checkCTEs() error {
+	if len(qb.ctes) > 0 && qb.operation == domain.OperationCreate {
+		return fmt.Errorf("%w: WITH on %v query", ErrInvalidCTE, qb.operation)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package qbr
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// DebugSQL builds the query without validating it and returns the SQL with the
+// parameters inlined, see Statement.DebugSQL.
+//
+// The result is intended for debugging only and must never be executed.
+func (qb *Query) DebugSQL() (string, error) {
+	// build query
+	query, params, err := qb.ToSql("", qb.options.Placeholder)
+	if err != nil {
+		return "", err
+	}
+
+	// inline params
+	return inlineParams(query, params, qb.options.Placeholder), nil
+}
+
+// DebugSQL returns the SQL of the statement with the parameters inlined, so it
+// can be copied to a database console.
+//
+// Strings are quoted and escaped, times are formatted as RFC3339, byte slices
+// as hex and nil values as NULL. If the count of placeholders and parameters
+// differs, the mismatch is annotated in a trailing comment.
+//
+// The result is intended for debugging only and must never be executed.
+func (s *Statement) DebugSQL() string {
+	return inlineParams(s.SQL, s.Params, s.Placeholder)
+}
+
+// inlineParams replaces the placeholders of the query by the formatted parameters.
+//
+// Placeholders in comments and without a parameter are kept as they are, and the mismatch of the
+// placeholders and parameters count is annotated in a trailing comment.
+func inlineParams(query string, params []any, plc domain.SqlPlaceholder) string {
+	// result query
+	var b strings.Builder
+	b.Grow(len(query))
+
+	// placeholders count and used parameters
+	placeholders := 0
+	used := make([]bool, len(params))
+
+	// replace placeholders
+	for i := 0; i < len(query); i++ {
+		// skip comments, such as optimizer hints
+		if strings.HasPrefix(query[i:], "/*") {
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i - 4
+			}
+			b.WriteString(query[i : i+end+4])
+			i += end + 3
+			continue
+		}
+
+		// check is placeholder
+		if !strings.HasPrefix(query[i:], string(plc)) {
+			b.WriteByte(query[i])
+			continue
+		}
+
+		// placeholder and parameter index
+		end := i + len(plc)
+		index := placeholders
+
+		// find numbered placeholder index
+		if plc == domain.SqlDollar || plc == domain.SqlAtP || plc == domain.SqlColon {
+			// find number end
+			for end < len(query) && query[end] >= '0' && query[end] <= '9' {
+				end++
+			}
+
+			// parse number
+			n, err := strconv.Atoi(query[i+len(plc) : end])
+			if err != nil {
+				b.WriteByte(query[i])
+				continue
+			}
+			index = n - 1
+		}
+
+		// count placeholder
+		placeholders++
+
+		// write parameter, or keep placeholder without parameter
+		if index >= 0 && index < len(params) {
+			b.WriteString(formatDebugValue(params[index]))
+			used[index] = true
+		} else {
+			b.WriteString(query[i:end])
+		}
+
+		// skip placeholder
+		i = end - 1
+	}
+
+	// count used parameters
+	usedCount := 0
+	for _, u := range used {
+		if u {
+			usedCount++
+		}
+	}
+
+	// annotate mismatch
+	if usedCount != placeholders || usedCount != len(params) {
+		fmt.Fprintf(&b, " /* placeholders and params mismatch: %d placeholders, %d params */", placeholders, len(params))
+	}
+
+	// return query
+	return b.String()
+}
+
+// formatDebugValue formats the value as a SQL literal for debugging.
+func formatDebugValue(value any) string {
+	// resolve driver values
+	if v, ok := value.(driver.Valuer); ok {
+		dv, err := v.Value()
+		if err != nil {
+			return fmt.Sprintf("/* %v */", err)
+		}
+		value = dv
+	}
+
+	// format value
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return quoteDebugString(v)
+	case []byte:
+		if v == nil {
+			return "NULL"
+		}
+		return `'\x` + hex.EncodeToString(v) + `'`
+	case time.Time:
+		return quoteDebugString(v.Format(time.RFC3339Nano))
+	case *time.Time:
+		if v == nil {
+			return "NULL"
+		}
+		return quoteDebugString(v.Format(time.RFC3339Nano))
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v)
+	default:
+		// format named byte slices as binary values
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return formatDebugValue(rv.Bytes())
+		}
+		return quoteDebugString(fmt.Sprint(v))
+	}
+}
+
+// quoteDebugString quotes the string as a SQL string literal.
+func quoteDebugString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
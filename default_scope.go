@@ -0,0 +1,103 @@
+package qbr
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// defaultScope is a default condition registered for a model.
+type defaultScope struct {
+	ops   []domain.OperationType
+	conds []domain.Condition
+}
+
+// defaultScopes contains the default conditions of the models by their struct types.
+var defaultScopes = struct {
+	sync.RWMutex
+	scopes map[reflect.Type][]defaultScope
+}{scopes: make(map[reflect.Type][]defaultScope)}
+
+// DefaultScope registers default conditions which are added to every SELECT query on
+// the model type T, see DefaultScopeOn.
+func DefaultScope[T any](conds ...domain.Condition) {
+	DefaultScopeOn[T]([]domain.OperationType{domain.OperationRead}, conds...)
+}
+
+// DefaultScopeOn registers default conditions which are added to every query with one
+// of the given operations on the model type T, for example:
+//
+//	qbr.DefaultScopeOn[Article]([]domain.OperationType{domain.OperationRead, domain.OperationUpdate}, qbr.Eq(status, "published"))
+//
+// The conditions of all default scopes of a model are combined with AND, and they are
+// copied when a query is built, see Query.Unscoped to skip them. Registration is safe
+// for concurrent use, so default scopes can be registered in the init functions of
+// several files.
+func DefaultScopeOn[T any](ops []domain.OperationType, conds ...domain.Condition) {
+	// model type
+	t := reflect.TypeFor[T]()
+
+	// register default scope
+	defaultScopes.Lock()
+	defer defaultScopes.Unlock()
+	defaultScopes.scopes[t] = append(defaultScopes.scopes[t], defaultScope{
+		ops:   slices.Clone(ops),
+		conds: cloneScopeConditions(conds),
+	})
+}
+
+// Unscoped disables the default conditions of the model of the query, see DefaultScope.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Unscoped() *Query {
+	// disable default scopes
+	qb.unscoped = true
+
+	// return query
+	return qb
+}
+
+// defaultConditions returns copies of the default conditions of the model of the query
+// for its operation, or nil if the query is unscoped.
+//
+// Returns an error if any default condition has a nil field.
+func (qb *Query) defaultConditions() ([]domain.Condition, error) {
+	// check is unscoped
+	if qb.unscoped {
+		return nil, nil
+	}
+
+	// model type
+	t := structTypeOf(qb.model)
+	if t == nil {
+		return nil, nil
+	}
+
+	// get default scopes
+	defaultScopes.RLock()
+	scopes := defaultScopes.scopes[t]
+	defaultScopes.RUnlock()
+
+	// default conditions
+	var conds []domain.Condition
+	for _, scope := range scopes {
+		// check is operation scoped
+		if !slices.Contains(scope.ops, qb.operation) {
+			continue
+		}
+
+		// check conditions fields
+		if err := checkConditionFields(scope.conds); err != nil {
+			return nil, fmt.Errorf("invalid default scope of %v: %w", t, err)
+		}
+
+		// add copied conditions
+		conds = append(conds, removeZeroCondition(qb.options.ZeroValuePolicy, cloneScopeConditions(scope.conds)...)...)
+	}
+
+	// return conditions
+	return conds, nil
+}
@@ -0,0 +1,108 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// SQL dialects of the generated SQL, see WithDialect.
+var (
+	// Postgres is the dialect of PostgreSQL, which is the default dialect: params use the "$1"
+	// placeholders and identifiers are quoted with double quotes.
+	Postgres = sqlbuilder.Postgres
+
+	// MySQL is the dialect of MySQL and MariaDB: params use the "?" placeholders, identifiers are
+	// quoted with backticks, and offsets without limit are rendered with the maximum limit.
+	MySQL = sqlbuilder.MySQL
+
+	// SQLite is the dialect of SQLite: params use the "?" placeholders, identifiers are quoted with
+	// double quotes, and offsets without limit are rendered with "LIMIT -1".
+	SQLite = sqlbuilder.SQLite
+
+	// SQLServer is the dialect of Microsoft SQL Server: params use the "@p1" placeholders,
+	// identifiers are quoted with brackets, limits without offset are rendered as TOP, and
+	// offsets as OFFSET and FETCH clauses, which require sort parameters, see
+	// ErrOffsetWithoutOrder.
+	SQLServer = sqlbuilder.SQLServer
+
+	// Oracle is the dialect of Oracle Database 12c and later: params use the ":1" placeholders,
+	// identifiers are quoted with double quotes, and limits and offsets are rendered as OFFSET
+	// and FETCH FIRST clauses.
+	Oracle = sqlbuilder.Oracle
+
+	// DB2 is the dialect of IBM Db2 11.1 and later: params use the "?" placeholders, identifiers
+	// are quoted with double quotes, and limits and offsets are rendered as OFFSET and FETCH
+	// FIRST clauses.
+	DB2 = sqlbuilder.DB2
+)
+
+// WithDialect sets the SQL dialect of the generated SQL, which defines the placeholder of the
+// params, the quoting of identifiers and the LIMIT and OFFSET syntax. The default is Postgres.
+//
+// The placeholder of the dialect is used unless a placeholder is set by WithPlaceholder.
+func WithDialect(d domain.Dialect) Option {
+	return func(o *Options) error {
+		// check is nil
+		if d == nil {
+			return errors.New("nil dialect")
+		}
+
+		// check is conflicting
+		if o.Dialect != nil && o.Dialect != d {
+			return fmt.Errorf("conflicting dialect options: %q and %q", o.Dialect.Name(), d.Name())
+		}
+
+		// set dialect
+		o.Dialect = d
+		return nil
+	}
+}
+
+// WithDialect sets the SQL dialect of the query and its placeholder, see WithDialect.
+//
+// It overrides the dialect set by the WithDialect option and the placeholder set before, the
+// placeholder can be overridden afterwards by Placeholder.
+func (qb *Query) WithDialect(d domain.Dialect) *Query {
+	// check is nil
+	if d == nil {
+		return qb.addError(errors.New("nil dialect"))
+	}
+
+	// set dialect and placeholder
+	qb.options.Dialect = d
+	qb.options.Placeholder = d.Placeholder()
+
+	// return query
+	return qb
+}
+
+// GetDialect returns the SQL dialect of the query.
+func (qb *Query) GetDialect() domain.Dialect {
+	return qb.options.dialect()
+}
+
+// dialect returns the dialect of the options, Postgres for options without dialect.
+func (o Options) dialect() domain.Dialect {
+	// check is dialect set
+	if o.Dialect == nil {
+		return Postgres
+	}
+
+	// return dialect
+	return o.Dialect
+}
+
+// sqlDialect returns the dialect of the options with the given placeholder, which is used to
+// build the SQL.
+func (o Options) sqlDialect(placeholder domain.SqlPlaceholder) domain.Dialect {
+	// check is placeholder set
+	if placeholder == "" {
+		return o.dialect()
+	}
+
+	// return dialect with placeholder
+	return sqlbuilder.WithPlaceholder(o.dialect(), placeholder)
+}
@@ -0,0 +1,171 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// Diff compares the fields of the structs old and new of the struct type T, or a pointer to it,
+// and returns the fields which changed and their new values by their "db" annotation, for
+// update queries which set only the changed columns, see UpdateDiff.
+//
+// Fields which are ignored for update operations, such as fields annotated with "ignore_on=update"
+// or read-only fields annotated with "only_on=read", and the version column of optimistic locking
+// are not compared. The fields of embedded and nested structs are compared too, see SetStruct.
+// Pointer fields are compared by their pointees, and values of types with an Equal method, such as
+// time.Time, are compared by it; other values are compared deeply. The
+// struct tag is taken from the given options, see WithTagName.
+//
+// Returns the changed fields in the order of their declaration and their new values, or an error
+// if the structs could not be compared.
+func Diff[T any](old, new T, opts ...Option) ([]*domain.Field, map[string]any, error) {
+	// create options
+	options, err := newOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// struct values
+	oldVal, newVal, err := diffValues(old, new)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// extract fields
+	fields, err := modelFields(oldVal.Type(), options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// compare fields
+	var changed []*domain.Field
+	values := make(map[string]any)
+	for _, f := range fields {
+		// check is compared
+		field := f.field
+		if field.Version || isFieldIgnored(field, domain.OperationUpdate) {
+			continue
+		}
+
+		// check is changed, fields of nil nested structs are nil
+		oldValue, value := diffFieldValue(oldVal, f.index), diffFieldValue(newVal, f.index)
+		if isEqualValue(oldValue, value) {
+			continue
+		}
+
+		// add changed field
+		changed = append(changed, field)
+		values[field.DB] = value
+	}
+
+	// return changes
+	return changed, values, nil
+}
+
+// UpdateDiff returns an update query which sets the columns which changed from old to new, see
+// Diff. The values are set as by SetMap, so changes to zero values are set too and nil values are
+// set to NULL, and new is the model of the query. If T has a "version" annotated column, the query
+// is locked with the version of old, see SkipVersionCheck.
+//
+// Errors are stored in the query and returned by ToSql, which is ErrNoChanges if the structs are
+// equal. The conditions of the updated rows are added with Where.
+func UpdateDiff[T any](old, new T, opts ...Option) *Query {
+	// update query of the model
+	qb := NewUpdate(opts...).Model(new)
+
+	// check query errors
+	if qb.err != nil {
+		return qb
+	}
+
+	// changed values
+	changed, values, err := Diff(old, new, opts...)
+	if err != nil {
+		return qb.addError(err)
+	}
+
+	// check is changed
+	if len(changed) == 0 {
+		return qb.addError(ErrNoChanges)
+	}
+
+	// lock version of old struct
+	data, err := extractDataFromStruct(old, qb.options)
+	if err != nil {
+		return qb.addError(err)
+	}
+	qb.setVersion(data)
+
+	// set changed values
+	return qb.SetMap(values)
+}
+
+// diffValues returns the struct values of old and new, dereferencing pointers.
+//
+// Returns the values, or an error if they are not structs or nil pointers.
+func diffValues[T any](old, new T) (reflect.Value, reflect.Value, error) {
+	// struct values
+	oldVal, newVal := reflect.ValueOf(old), reflect.ValueOf(new)
+
+	// dereference pointers
+	if oldVal.Kind() == reflect.Ptr {
+		if oldVal.IsNil() || newVal.IsNil() {
+			return reflect.Value{}, reflect.Value{}, errors.New("nil struct in Diff")
+		}
+		oldVal, newVal = oldVal.Elem(), newVal.Elem()
+	}
+
+	// check is struct
+	if oldVal.Kind() != reflect.Struct {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("unsupported diff type: %v", reflect.TypeFor[T]())
+	}
+
+	// return values
+	return oldVal, newVal, nil
+}
+
+// diffFieldValue returns the value of the struct field of v at the index sequence, or nil if a
+// pointer to an embedded or nested struct on the way is nil.
+func diffFieldValue(v reflect.Value, index []int) any {
+	// check is field reachable
+	fv, ok := valueByIndex(v, index)
+	if !ok {
+		return nil
+	}
+
+	// return value
+	return fv.Interface()
+}
+
+// isEqualValue checks if the values of a struct field are equal, comparing pointers by their
+// pointees, values with an Equal method by it, and other values deeply.
+func isEqualValue(a, b any) bool {
+	// values by reflect
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	// check is valid
+	if !va.IsValid() || !vb.IsValid() {
+		return va.IsValid() == vb.IsValid()
+	}
+
+	// compare pointees
+	if va.Kind() == reflect.Ptr && vb.Kind() == reflect.Ptr {
+		if va.IsNil() || vb.IsNil() {
+			return va.IsNil() == vb.IsNil()
+		}
+		return isEqualValue(va.Elem().Interface(), vb.Elem().Interface())
+	}
+
+	// compare by equal method, for example of time.Time
+	if m := va.MethodByName("Equal"); m.IsValid() &&
+		m.Type().NumIn() == 1 && m.Type().In(0) == va.Type() &&
+		m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.Bool && vb.Type() == va.Type() {
+		return m.Call([]reflect.Value{vb})[0].Bool()
+	}
+
+	// compare deeply
+	return reflect.DeepEqual(a, b)
+}
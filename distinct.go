@@ -0,0 +1,71 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// Distinct removes duplicate rows from the result of the read query, see DistinctOn for keeping
+// the first row of every group of rows instead.
+//
+// SELECT DISTINCT ...
+func (qb *Query) Distinct() *Query {
+	// set distinct
+	qb.distinct = true
+
+	// return query
+	return qb
+}
+
+// DistinctOn keeps the first row of every group of rows of the read query with equal values of
+// the given fields, in the order of the sort parameters of the query, which must start with the
+// fields, for example the latest order of every user:
+//
+//	qb.DistinctOn(FieldOf[Order]("user_id")).
+//		Sort(NewSortAsc(FieldOf[Order]("user_id")), NewSortDesc(FieldOf[Order]("created_at")))
+//
+// DISTINCT ON is specific to PostgreSQL, queries with DistinctOn fail to build in the other
+// built-in dialects. Computed fields are compared by their expression.
+//
+// SELECT DISTINCT ON (fields) ...
+func (qb *Query) DistinctOn(fields ...*domain.Field) *Query {
+	// add fields
+	for _, field := range fields {
+		// check is field nil
+		if field == nil {
+			return qb.addError(errors.New("nil field in DISTINCT ON"))
+		}
+
+		// add field
+		qb.distinctOn = append(qb.distinctOn, *field)
+	}
+
+	// return query
+	return qb
+}
+
+// GetDistinct reports whether duplicate rows are removed from the result of the query, see
+// Distinct.
+func (qb *Query) GetDistinct() bool {
+	return qb.distinct
+}
+
+// GetDistinctOn returns the fields of the DISTINCT ON clause of the query, or nil if no fields
+// are set.
+func (qb *Query) GetDistinctOn() []domain.Field {
+	return qb.distinctOn
+}
+
+// checkDistinct checks the DISTINCT and DISTINCT ON clauses of the query.
+func (qb *Query) checkDistinct() error {
+	switch {
+	case (qb.distinct || len(qb.distinctOn) > 0) && qb.operation != domain.OperationRead:
+		return fmt.Errorf("DISTINCT on %v query", qb.operation)
+	case qb.distinct && len(qb.distinctOn) > 0:
+		return errors.New("DISTINCT with DISTINCT ON")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+// Package qbr is a flexible and lightweight query builder.
+//
+// # Deterministic output
+//
+// The generated queries are fully deterministic: building the same query twice
+// always produces byte-identical SQL and parameters, so the SQL can be used as
+// a cache key or fingerprint. Columns are rendered in the order they were added
+// to the query, and columns extracted from a struct are rendered in the order
+// in which the fields are declared in the struct. The build pipeline never
+// iterates over maps; maps are only used for lookups.
+//
+// Every API accepting a map processes its entries in sorted key order, for
+// example the values of Query.SetMap and the filters of ParseJSONFilter and
+// ParseURLFilter, so the output does not depend on the random iteration order
+// of Go maps. New APIs accepting maps must follow this rule, which can be
+// checked with qbrtest.Deterministic.
+//
+// # Binary values
+//
+// Byte slices are bound as binary values, for example to PostgreSQL bytea or
+// MySQL BLOB columns. A nil byte slice is no value: it is skipped in data and
+// conditions like other nil values, and set to NULL by SetMap. An empty but
+// non-nil byte slice is a value regardless of the zero value policy, so
+// it is inserted, updated and compared as an empty binary value. Use
+// domain.ValueNull to set a binary column to NULL explicitly. DebugSQL renders
+// binary values in the hex format, for example '\x0a0b'.
+//
+// Built statements bind copies of byte slices, so modifying a byte slice after
+// building does not change the statement. The copy costs an allocation per
+// byte slice param and can be disabled with WithoutArgCopy.
+package qbr
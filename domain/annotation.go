@@ -8,4 +8,20 @@ const (
 	QueryQbr      QueryAnnotationType = "qbr"
 	QueryDB       QueryAnnotationType = "db"
 	QueryIgnoreOn QueryAnnotationType = "ignore_on"
+	QueryOnlyOn   QueryAnnotationType = "only_on"
+	QueryColOn    QueryAnnotationType = "col_on_"
+	QueryTable    QueryAnnotationType = "table"
+	QueryVersion  QueryAnnotationType = "version"
+	QueryTenant   QueryAnnotationType = "tenant"
+	QueryJSON     QueryAnnotationType = "json"
+	QueryDefault  QueryAnnotationType = "default"
+	QueryPK       QueryAnnotationType = "pk"
+	QueryGen      QueryAnnotationType = "generated"
+	QueryDup      QueryAnnotationType = "duplicate"
+	QueryKeepZero QueryAnnotationType = "keep_zero"
+)
+
+// Client-side defaults of the default annotation.
+const (
+	DefaultNewUUID = "new_uuid" // Random UUID of version 4.
 )
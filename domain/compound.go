@@ -0,0 +1,18 @@
+package domain
+
+// Compound type, the set operation combining the rows of queries.
+type CompoundType string
+
+// Compound types.
+const (
+	CompoundUnion     CompoundType = "UNION"
+	CompoundUnionAll  CompoundType = "UNION ALL"
+	CompoundIntersect CompoundType = "INTERSECT"
+	CompoundExcept    CompoundType = "EXCEPT"
+)
+
+// Compound model of a query combined with the rows of a statement, for example "UNION ALL (SELECT ...)".
+type Compound struct {
+	Type     CompoundType // Set operation.
+	Subquery Subquery     // Combined query, wrapped in parentheses.
+}
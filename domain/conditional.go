@@ -1,8 +1,127 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 // Condition model.
 type Condition struct {
 	Field    *Field
 	Operator OperatorType
 	Value    any
 }
+
+// String returns a readable representation of the condition for logs and tests,
+// for example "(status = 'active' AND (age > 18 OR parent_consent = true))".
+//
+// The representation does not depend on any SQL dialect. Logical conditions are
+// wrapped in parentheses, nested empty logical conditions are left out (see
+// PruneConditions), empty logical conditions are rendered as "()" and nil fields
+// as "<nil>". Negated conditions are rendered as "NOT (...)" of their sub
+// conditions joined by AND.
+func (c Condition) String() string {
+	// negated condition
+	if c.Operator == OperatorNot {
+		conds, _ := c.Value.([]Condition)
+		return "NOT " + Condition{Operator: OperatorAnd, Value: conds}.String()
+	}
+
+	// logical condition
+	if c.Operator == OperatorAnd || c.Operator == OperatorOr {
+		// sub conditions
+		conds, _ := c.Value.([]Condition)
+		conds = PruneConditions(conds)
+
+		// sub conditions strings
+		strs := make([]string, len(conds))
+		for i, cond := range conds {
+			strs[i] = cond.String()
+		}
+
+		// return logical condition
+		return "(" + strings.Join(strs, " "+c.Operator.String()+" ") + ")"
+	}
+
+	// field name
+	field := "<nil>"
+	if c.Field != nil {
+		field = c.Field.String()
+	}
+
+	// boolean expression condition
+	if c.Operator == OperatorExpression {
+		return field
+	}
+
+	// null value condition
+	if v, ok := c.Value.(ValueType); ok && v == ValueNull {
+		if c.Operator == OperatorNotEqual {
+			return field + " IS NOT NULL"
+		}
+		return field + " IS NULL"
+	}
+
+	// range condition
+	if bounds, ok := c.Value.([]any); ok && c.Operator == OperatorBetween && len(bounds) == 2 {
+		return fmt.Sprintf("%s BETWEEN %s AND %s", field, formatConditionValue(bounds[0]), formatConditionValue(bounds[1]))
+	}
+
+	// return simple condition
+	return fmt.Sprintf("%s %s %s", field, c.Operator, formatConditionValue(c.Value))
+}
+
+// PruneConditions returns the conditions without the logical conditions (AND, OR and NOT)
+// which have no sub conditions after pruning, such as And() or Or(And(), Not()), whose SQL
+// would be empty. The sub conditions of the other logical conditions are pruned too.
+func PruneConditions(conds []Condition) []Condition {
+	// pruned conditions
+	result := make([]Condition, 0, len(conds))
+	for _, cond := range conds {
+		// prune sub conditions of logical condition
+		if sub, ok := cond.Value.([]Condition); ok &&
+			(cond.Operator == OperatorAnd || cond.Operator == OperatorOr || cond.Operator == OperatorNot) {
+			// check is empty
+			sub = PruneConditions(sub)
+			if len(sub) == 0 {
+				continue
+			}
+			cond.Value = sub
+		}
+
+		// add condition
+		result = append(result, cond)
+	}
+
+	// return conditions
+	return result
+}
+
+// formatConditionValue formats the value of a condition for String.
+func formatConditionValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case *Field:
+		return v.String()
+	case *Expression:
+		return v.String()
+	case Subquery:
+		return "(subquery)"
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatConditionValue(item)
+		}
+		return "(" + strings.Join(items, ", ") + ")"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case time.Time:
+		return "'" + v.Format(time.RFC3339Nano) + "'"
+	case fmt.Stringer:
+		return "'" + strings.ReplaceAll(v.String(), "'", "''") + "'"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+// Conflict action type.
+type ConflictAction string
+
+// Conflict actions.
+const (
+	ConflictDoUpdate  ConflictAction = "DO UPDATE"
+	ConflictDoNothing ConflictAction = "DO NOTHING"
+)
+
+// Conflict model of a conflict clause of inserts, for example
+// "ON CONFLICT (email) WHERE deleted_at IS NULL DO UPDATE SET name = excluded.name".
+type Conflict struct {
+	Target     []Field        // Columns or index expressions of the unique index of the conflict target.
+	Constraint string         // Named constraint of the conflict target, used instead of Target.
+	Where      []Condition    // Predicate of the partial unique index of the conflict target.
+	Action     ConflictAction // Conflict action, empty if no action is set.
+	Updates    []Data         // Updated columns and their values or expressions of DO UPDATE.
+
+	// UpdateInserted is true if DO UPDATE sets the inserted columns except the columns of the
+	// conflict target to their proposed values, in addition to Updates.
+	UpdateInserted bool
+}
@@ -0,0 +1,8 @@
+package domain
+
+// CTE model of a common table expression of a statement, for example "WITH recent AS (SELECT ...)".
+type CTE struct {
+	Name      string   // Name of the common table expression, referenced as a table.
+	Subquery  Subquery // Query of the common table expression, wrapped in parentheses.
+	Recursive bool     // Is the common table expression recursive, see WITH RECURSIVE.
+}
@@ -0,0 +1,19 @@
+package domain
+
+// Dialect is a SQL dialect, which defines the syntax of the generated SQL that differs between
+// databases.
+type Dialect interface {
+	// Name returns the name of the dialect, for example "postgres".
+	Name() string
+
+	// Placeholder returns the placeholder of the params of the dialect.
+	Placeholder() SqlPlaceholder
+
+	// QuoteIdent quotes the segments of the optionally qualified identifier which need quoting,
+	// for example reserved words of the dialect.
+	QuoteIdent(name string) string
+
+	// LimitOffset returns the clause limiting the rows to the given limit after skipping the
+	// given offset, or an empty string if both are zero.
+	LimitOffset(limit, offset uint64) string
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expression kind.
+type ExpressionKind int
+
+// Expression kinds.
+const (
+	ExpressionField ExpressionKind = iota
+	ExpressionLiteral
+	ExpressionBinary
+	ExpressionFunction
+	ExpressionExtract
+	ExpressionCase
+	ExpressionRaw
+	ExpressionExcluded
+	ExpressionSubquery
+)
+
+// Expression model.
+//
+// Expressions are trees of fields, literals, binary operators and function calls,
+// used as computed columns, for example "price * quantity".
+type Expression struct {
+	Kind     ExpressionKind // Expression kind.
+	Field    *Field         // Field of field and excluded expressions.
+	Value    any            // Value of literal expressions, bound as a parameter.
+	Name     string         // Operator of binary expressions, function name, the part of extract expressions or SQL of raw expressions.
+	Operands []*Expression  // Operands of binary, function, extract and raw expressions, results of case expressions.
+	Whens    []Condition    // Conditions of the branches of case expressions, the else result is the last operand.
+	Subquery Subquery       // Query of subquery expressions, wrapped in parentheses after the operator in Name, if any.
+}
+
+// Fields returns the fields referenced by the expression and its operands.
+func (e *Expression) Fields() []*Field {
+	// check is field or excluded expression
+	if e.Kind == ExpressionField || e.Kind == ExpressionExcluded {
+		return []*Field{e.Field}
+	}
+
+	// operands fields
+	var fields []*Field
+	for _, operand := range e.Operands {
+		fields = append(fields, operand.Fields()...)
+	}
+
+	// conditions fields
+	for _, cond := range e.Whens {
+		fields = append(fields, conditionFields(cond)...)
+	}
+
+	// return fields
+	return fields
+}
+
+// conditionFields returns the fields of the condition and its sub conditions.
+func conditionFields(cond Condition) []*Field {
+	// check is logical condition
+	sub, ok := cond.Value.([]Condition)
+	if !ok {
+		return []*Field{cond.Field}
+	}
+
+	// sub conditions fields
+	var fields []*Field
+	for _, c := range sub {
+		fields = append(fields, conditionFields(c)...)
+	}
+
+	// return fields
+	return fields
+}
+
+// String returns a readable representation of the expression for logs and tests,
+// for example "(price * quantity)".
+func (e *Expression) String() string {
+	// operands strings
+	operands := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		operands[i] = operand.String()
+	}
+
+	switch e.Kind {
+	case ExpressionField:
+		return e.Field.String()
+	case ExpressionExcluded:
+		return "excluded." + e.Field.String()
+	case ExpressionSubquery:
+		return strings.TrimSpace(e.Name + " (subquery)")
+	case ExpressionLiteral:
+		return formatConditionValue(e.Value)
+	case ExpressionBinary:
+		return "(" + strings.Join(operands, " "+e.Name+" ") + ")"
+	case ExpressionFunction:
+		return e.Name + "(" + strings.Join(operands, ", ") + ")"
+	case ExpressionExtract:
+		return fmt.Sprintf("EXTRACT(%s FROM %s)", e.Name, strings.Join(operands, ", "))
+	case ExpressionRaw:
+		// replace placeholders by operands
+		var b strings.Builder
+		for i, part := range strings.Split(strings.ReplaceAll(e.Name, "??", "\x00"), "?") {
+			if i > 0 {
+				if i <= len(operands) {
+					b.WriteString(operands[i-1])
+				} else {
+					b.WriteString("?")
+				}
+			}
+			b.WriteString(strings.ReplaceAll(part, "\x00", "?"))
+		}
+
+		// return raw expression
+		return b.String()
+	case ExpressionCase:
+		// branches strings
+		var b strings.Builder
+		b.WriteString("CASE")
+		for i, cond := range e.Whens {
+			fmt.Fprintf(&b, " WHEN %s THEN %s", cond, operands[i])
+		}
+
+		// else string
+		if len(operands) > len(e.Whens) {
+			fmt.Fprintf(&b, " ELSE %s", operands[len(e.Whens)])
+		}
+
+		// return case expression
+		b.WriteString(" END")
+		return b.String()
+	default:
+		return fmt.Sprintf("ExpressionKind(%d)", int(e.Kind))
+	}
+}
+
+// As returns a computed field with the given alias, which selects the expression
+// as the alias column, for example "price * quantity AS line_total".
+//
+// The alias is not used when the field is rendered in conditions or sort parameters.
+func (e *Expression) As(alias string) *Field {
+	return &Field{
+		DB:          alias,
+		Aggregation: AggregationNone,
+		Expression:  e,
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "fmt"
+
 // Aggregation type.
 type AggregationType int
 
@@ -12,7 +14,52 @@ const (
 
 // Field model.
 type Field struct {
-	DB          string          // DB field name.
-	Aggregation AggregationType // Aggregation type.
-	IgnoreOn    []OperationType // Slice with ignored operations.
+	DB          string                   // DB field name.
+	Aggregation AggregationType          // Aggregation type.
+	IgnoreOn    []OperationType          // Slice with ignored operations.
+	OnlyOn      []OperationType          // Slice with the only allowed operations, if not empty.
+	Columns     map[OperationType]string // DB field names overriding DB per operation.
+	Expression  *Expression              // Expression computing the field, DB is its alias.
+	Version     bool                     // Is the version column of optimistic locking.
+	Tenant      bool                     // Is the tenant column of tenant scoping.
+	JSON        bool                     // Is the value stored as JSON.
+	Default     string                   // Client-side default of zero values on create, for example DefaultNewUUID.
+	PK          bool                     // Is a column of the primary key.
+	Generated   bool                     // Is the value generated by the database on create, which is not inserted.
+	Unsafe      bool                     // Is the DB field name interpolated without identifier validation.
+	Duplicate   bool                     // Is allowed to share its DB field name with other fields of the model.
+	KeepZero    bool                     // Are zero values kept regardless of the zero value policy.
+	Table       string                   // Table of the model of the field, set by FieldOf.
+	Err         error                    // Error of the field, such as an unknown field of FieldOf, returned by the queries using it.
+}
+
+// Column returns the DB field name of the field for the given operation, which
+// is the DB field overridden by the column set for the operation in Columns.
+func (f *Field) Column(op OperationType) string {
+	// check is column overridden for operation
+	if column, ok := f.Columns[op]; ok {
+		return column
+	}
+
+	// return default column
+	return f.DB
+}
+
+// String returns the DB field name, or the expression of computed fields, wrapped in
+// the aggregation function, if any, for example "COUNT(id)".
+func (f Field) String() string {
+	// field name
+	name := f.DB
+	if f.Expression != nil {
+		name = f.Expression.String()
+	}
+
+	switch f.Aggregation {
+	case AggregationCount:
+		return fmt.Sprintf("COUNT(%s)", name)
+	case AggregationSum:
+		return fmt.Sprintf("SUM(%s)", name)
+	default:
+		return name
+	}
 }
@@ -0,0 +1,17 @@
+package domain
+
+// Index hint type.
+type IndexHintType string
+
+// Index hint types.
+const (
+	IndexUse    IndexHintType = "USE INDEX"
+	IndexForce  IndexHintType = "FORCE INDEX"
+	IndexIgnore IndexHintType = "IGNORE INDEX"
+)
+
+// IndexHint model of a MySQL index hint of a table, for example "FORCE INDEX (idx_created_at)".
+type IndexHint struct {
+	Type    IndexHintType // Index hint type.
+	Indexes []string      // Hinted indexes.
+}
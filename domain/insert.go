@@ -0,0 +1,8 @@
+package domain
+
+// InsertSelect model of the rows of an insert which are selected by a query, for example
+// "INSERT INTO archive (id, name) SELECT ...".
+type InsertSelect struct {
+	Columns  []Field  // Inserted columns, all the columns of the table in their order if empty.
+	Subquery Subquery // Query selecting the inserted rows.
+}
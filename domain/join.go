@@ -0,0 +1,28 @@
+package domain
+
+// Join type.
+type JoinType string
+
+// Join types.
+const (
+	JoinInner JoinType = "INNER JOIN"
+	JoinLeft  JoinType = "LEFT JOIN"
+	JoinRight JoinType = "RIGHT JOIN"
+	JoinFull  JoinType = "FULL JOIN"
+	JoinCross JoinType = "CROSS JOIN"
+)
+
+// Source model of a table or subquery of a statement, for example "(SELECT ...) AS t".
+type Source struct {
+	Table      string      // Table, if Subquery is nil.
+	Subquery   Subquery    // Subquery, wrapped in parentheses.
+	Alias      string      // Alias of the table or subquery, required for subqueries.
+	IndexHints []IndexHint // Index hints of the table, rendered after the alias.
+}
+
+// Join model of a joined table or subquery, for example "LEFT JOIN orders AS o ON o.user_id = users.id".
+type Join struct {
+	Type   JoinType    // Join type.
+	Source Source      // Joined table or subquery.
+	On     []Condition // Join conditions joined by AND, empty for cross joins.
+}
@@ -0,0 +1,27 @@
+package domain
+
+// Lock strength type.
+type LockStrength string
+
+// Lock strengths.
+const (
+	LockUpdate LockStrength = "UPDATE"
+	LockShare  LockStrength = "SHARE"
+)
+
+// Lock wait policy type.
+type LockWait string
+
+// Lock wait policies.
+const (
+	LockWaitDefault LockWait = ""
+	LockNoWait      LockWait = "NOWAIT"
+	LockSkipLocked  LockWait = "SKIP LOCKED"
+)
+
+// Lock model of a row-level locking clause, for example "FOR UPDATE OF users SKIP LOCKED".
+type Lock struct {
+	Strength LockStrength // Lock strength, empty if no locking clause is set.
+	Of       []string     // Locked tables, all tables if empty.
+	Wait     LockWait     // Wait policy for locked rows.
+}
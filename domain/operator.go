@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"sync"
+)
+
 // Operator type.
 type OperatorType int
 
@@ -13,4 +18,71 @@ const (
 	OperatorGreaterThanOrEqual
 	OperatorOr
 	OperatorAnd
+	OperatorExpression
+	OperatorNot
+	OperatorIn
+	OperatorNotIn
+	OperatorBetween
+	OperatorLike
+	OperatorNotLike
+	OperatorILike
+	OperatorRegexMatch
 )
+
+// operatorsMu guards the string representations and the registration of custom operators.
+var operatorsMu sync.RWMutex
+
+// operatorStrings contains the string representations of the operator types.
+var operatorStrings = map[OperatorType]string{
+	OperatorEqual:              "=",
+	OperatorNotEqual:           "!=",
+	OperatorLessThan:           "<",
+	OperatorGreaterThan:        ">",
+	OperatorLessThanOrEqual:    "<=",
+	OperatorGreaterThanOrEqual: ">=",
+	OperatorOr:                 "OR",
+	OperatorAnd:                "AND",
+	OperatorExpression:         "",
+	OperatorNot:                "NOT",
+	OperatorIn:                 "IN",
+	OperatorNotIn:              "NOT IN",
+	OperatorBetween:            "BETWEEN",
+	OperatorLike:               "LIKE",
+	OperatorNotLike:            "NOT LIKE",
+	OperatorILike:              "ILIKE",
+	OperatorRegexMatch:         "~",
+}
+
+// String returns the string representation of the operator, for example ">=".
+func (o OperatorType) String() string {
+	// get operator string
+	operatorsMu.RLock()
+	s, ok := operatorStrings[o]
+	operatorsMu.RUnlock()
+	if ok {
+		return s
+	}
+
+	// unknown operator
+	return fmt.Sprintf("OperatorType(%d)", int(o))
+}
+
+// NewOperator returns the operator type of the custom operator with the given name, which is
+// its string representation, creating a new operator type if the name is not registered yet.
+// Registration is safe for concurrent use.
+func NewOperator(name string) OperatorType {
+	operatorsMu.Lock()
+	defer operatorsMu.Unlock()
+
+	// find registered operator
+	for o, s := range operatorStrings {
+		if s == name && o > OperatorRegexMatch {
+			return o
+		}
+	}
+
+	// register operator
+	o := OperatorType(len(operatorStrings))
+	operatorStrings[o] = name
+	return o
+}
@@ -9,8 +9,19 @@ const (
 	SortDesc SortType = "DESC"
 )
 
+// Order of NULL values of a sort.
+type NullsOrder string
+
+// Orders of NULL values.
+const (
+	NullsDefault NullsOrder = ""      // Default order of the database.
+	NullsFirst   NullsOrder = "FIRST" // NULL values before other values.
+	NullsLast    NullsOrder = "LAST"  // NULL values after other values.
+)
+
 // Sort model
 type Sort struct {
 	Field *Field
 	Type  SortType
+	Nulls NullsOrder // Order of NULL values, the default order of the database if empty.
 }
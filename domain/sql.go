@@ -7,4 +7,6 @@ type SqlPlaceholder string
 const (
 	SqlDollar   SqlPlaceholder = "$"
 	SqlQuestion SqlPlaceholder = "?"
+	SqlAtP      SqlPlaceholder = "@p"
+	SqlColon    SqlPlaceholder = ":"
 )
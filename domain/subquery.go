@@ -0,0 +1,11 @@
+package domain
+
+// Subquery is a query nested in a statement, for example a joined subquery or the subquery of an
+// IN condition.
+type Subquery interface {
+	// BuildSubquery builds the SQL of the query in the dialect of the enclosing statement. The
+	// params of the query are appended to the given params of the statement, so the numbered
+	// placeholders of the query follow its placeholders. It returns the SQL without parentheses,
+	// the updated params, and an error if the query could not be built.
+	BuildSubquery(d Dialect, params []any) (string, []any, error)
+}
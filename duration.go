@@ -0,0 +1,83 @@
+package qbr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// DurationFormat defines how time.Duration params are bound.
+type DurationFormat int
+
+// Duration formats.
+const (
+	// DurationInterval binds durations as interval strings, for example "90.5 seconds", it is
+	// the default format, intended for PostgreSQL interval columns.
+	DurationInterval DurationFormat = iota + 1
+	// DurationSeconds binds durations as float64 numbers of seconds.
+	DurationSeconds
+	// DurationTime binds durations as time strings, for example "-01:30:00.5", intended for
+	// MySQL TIME columns.
+	DurationTime
+)
+
+// WithDurationFormat sets the format of bound time.Duration params, the default is DurationInterval.
+func WithDurationFormat(format DurationFormat) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.DurationFormat != 0 && o.DurationFormat != format {
+			return fmt.Errorf("conflicting duration format options: %d and %d", o.DurationFormat, format)
+		}
+
+		// set format
+		o.DurationFormat = format
+		return nil
+	}
+}
+
+// Age returns an expression of the interval between the current date and the value of the
+// given timestamp field, using the PostgreSQL AGE function.
+//
+// AGE(field)
+func Age(field *domain.Field) *domain.Expression {
+	return Func("AGE", field)
+}
+
+// Since returns an expression of the interval between the current time and the value of the
+// given timestamp field, so rows older than 30 days are selected with:
+//
+//	qbr.Gt(qbr.Since(CreatedAt).As("age"), 30*24*time.Hour)
+//
+// NOW() - field
+func Since(field *domain.Field) *domain.Expression {
+	return Sub(Func("NOW"), field)
+}
+
+// formatDuration returns the value of the duration in the given format.
+func formatDuration(d time.Duration, format DurationFormat) any {
+	switch format {
+	case DurationSeconds:
+		return d.Seconds()
+	case DurationTime:
+		// sign of duration
+		sign := ""
+		if d < 0 {
+			sign, d = "-", -d
+		}
+
+		// time parts
+		h, m, s := d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second
+
+		// format time
+		str := fmt.Sprintf("%s%02d:%02d:%02d", sign, h, m, s)
+		if frac := d % time.Second / time.Microsecond; frac != 0 {
+			str += "." + strings.TrimRight(fmt.Sprintf("%06d", frac), "0")
+		}
+		return str
+	default:
+		return strconv.FormatFloat(float64(d.Microseconds())/1e6, 'f', -1, 64) + " seconds"
+	}
+}
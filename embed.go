@@ -0,0 +1,280 @@
+package qbr
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// maxNestingDepth is the maximum depth of the embedded and nested structs flattened by
+// flattenFields, which stops the recursion of self-referencing types.
+const maxNestingDepth = 8
+
+// structField is a field of a struct type or of its embedded and nested structs, see
+// flattenFields.
+type structField struct {
+	index  []int         // Index sequence of the struct field, see reflect.Value.FieldByIndex.
+	field  *domain.Field // Field with the prefix of its nested structs.
+	nested bool          // Is the field of a nested struct with a prefix, not of embedded structs only.
+}
+
+// flattenFields returns the fields of the struct type t and of its embedded and nested structs,
+// in the order of the struct fields.
+//
+// Embedded structs and pointers to structs without a tag contribute their fields as if they
+// were fields of t, including embedded structs of unexported types. Nested structs whose tag ends with "_", for example `db:"address_"`,
+// contribute their fields with the tag as prefix of their columns, such as "address_city".
+// Structs handled as a single value, such as times, scanners and "json" fields, are not flattened.
+//
+// Returns the fields, or an error if the fields of a struct could not be extracted.
+func flattenFields(t reflect.Type, o Options) ([]structField, error) {
+	return appendFlattenedFields(nil, t, nil, "", o, 0)
+}
+
+// appendFlattenedFields appends the fields of the struct type t at the index sequence of its
+// struct field to result, with the given prefix of their columns, see flattenFields. The prefix
+// is empty for t and its embedded structs only.
+func appendFlattenedFields(result []structField, t reflect.Type, index []int, prefix string, o Options, depth int) ([]structField, error) {
+	// extract fields
+	fields, err := extractFieldsFromType(t, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// add fields
+	for i, field := range fields {
+		// field index sequence
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		// check is flattened struct
+		st, nested, ok := flattenedStruct(t.Field(i), field, o)
+		if ok && depth < maxNestingDepth {
+			if result, err = appendFlattenedFields(result, st, fieldIndex, prefix+nested, o, depth+1); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// add field
+		if field != nil {
+			result = append(result, structField{index: fieldIndex, field: prefixField(field, prefix), nested: prefix != ""})
+		}
+	}
+
+	// return fields
+	return result, nil
+}
+
+// flattenedStruct checks if the struct field of the extracted field, which is nil for untagged
+// fields, is an embedded or nested struct whose fields are flattened, see flattenFields.
+//
+// Returns the struct type, the prefix of the columns of its fields and true, or false if the
+// struct field is not flattened.
+func flattenedStruct(ft reflect.StructField, field *domain.Field, o Options) (reflect.Type, string, bool) {
+	// struct type
+	t := ft.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// check is struct of fields, the exported fields of embedded structs of unexported types are
+	// flattened too, unless they are embedded by pointer, which cannot be allocated
+	if !ft.IsExported() && !(ft.Anonymous && ft.Type.Kind() == reflect.Struct) ||
+		t.Kind() != reflect.Struct || isValueStruct(t) {
+		return nil, "", false
+	}
+
+	// check is embedded or nested struct
+	tag, tagged := ft.Tag.Lookup(o.TagName)
+	switch {
+	case ft.Anonymous && !tagged:
+		return t, "", true
+	case field != nil && !field.JSON && strings.HasSuffix(tag, "_"):
+		return t, tag, true
+	default:
+		return nil, "", false
+	}
+}
+
+// isValueStruct checks if the struct type is stored as a single value instead of being
+// flattened, which is the case for times, types implementing sql.Scanner or driver.Valuer and
+// types with a registered scan function, see RegisterScanner.
+func isValueStruct(t reflect.Type) bool {
+	// check is time or scanned type
+	pt := reflect.PointerTo(t)
+	if t == reflect.TypeFor[time.Time]() || pt.Implements(reflect.TypeFor[sql.Scanner]()) ||
+		pt.Implements(reflect.TypeFor[driver.Valuer]()) {
+		return true
+	}
+
+	// check is scan function registered
+	scanFuncs.RLock()
+	defer scanFuncs.RUnlock()
+	_, ok := scanFuncs.funcs[t]
+	return ok
+}
+
+// prefixField returns a copy of the field whose columns have the given prefix, or the field
+// itself if the prefix is empty.
+func prefixField(field *domain.Field, prefix string) *domain.Field {
+	// check is prefix set
+	if prefix == "" {
+		return field
+	}
+
+	// prefix columns
+	prefixed := *field
+	prefixed.DB = prefix + field.DB
+	if field.Columns != nil {
+		prefixed.Columns = make(map[domain.OperationType]string, len(field.Columns))
+		for op, column := range field.Columns {
+			prefixed.Columns[op] = prefix + column
+		}
+	}
+
+	// return prefixed field
+	return &prefixed
+}
+
+// fieldByIndex returns the nested struct field of v at the index sequence, allocating the nil
+// pointers to embedded and nested structs on the way, see flattenFields.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		// allocate nil pointer to struct
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		// nested field
+		v = v.Field(x)
+	}
+
+	// return field
+	return v
+}
+
+// modelFieldsCache caches the fields of the struct types returned by modelFields by their
+// fieldsKey, so the fields of a model are flattened once instead of on every build. The cache
+// is cleared by RegisterScanner, which changes the structs handled as a single value.
+var modelFieldsCache sync.Map
+
+// modelFields returns the fields of the struct type t and of its embedded and nested structs,
+// see flattenFields, without the fields of embedded structs shadowed by a less nested field of
+// the same column, as the fields of embedded structs are shadowed by the fields of the outer
+// struct in Go. The prefixed fields of nested structs are never shadowed.
+//
+// The fields are computed once per struct type and options, see modelFieldsCache, and are
+// shared by the callers, which must not modify them.
+//
+// Returns the fields, or an error if the fields of a struct could not be extracted, which wraps
+// ErrDuplicateColumn if fields of different structs of the same depth map to the same column,
+// or a prefixed field of a nested struct maps to the column of another field.
+func modelFields(t reflect.Type, o Options) ([]structField, error) {
+	// cache key
+	key := fieldsKey{t: t, tagName: o.TagName, snakeCase: o.SnakeCaseColumns, strict: strictAnnotations.Load()}
+
+	// check is fields cached
+	if fields, ok := modelFieldsCache.Load(key); ok {
+		return slices.Clone(fields.([]structField)), nil
+	}
+
+	// compute fields
+	fields, err := computeModelFields(t, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// cache fields
+	modelFieldsCache.Store(key, fields)
+
+	// return fields
+	return slices.Clone(fields), nil
+}
+
+// computeModelFields returns the fields of modelFields without the cache.
+func computeModelFields(t reflect.Type, o Options) ([]structField, error) {
+	// flatten fields
+	fields, err := flattenFields(t, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// remove fields of embedded structs shadowed by less nested fields of embedded structs
+	var result []structField
+	for _, field := range fields {
+		if field.nested || !slices.ContainsFunc(fields, func(f structField) bool {
+			return !f.nested && f.field.DB == field.field.DB && len(f.index) < len(field.index)
+		}) {
+			result = append(result, field)
+		}
+	}
+
+	// check columns of different structs, which are of the same depth or of nested structs
+	// after removing the shadowed fields, the fields of a struct are checked by
+	// checkDuplicateColumns
+	for i, field := range result {
+		for _, other := range result[:i] {
+			if other.field.DB == field.field.DB &&
+				!slices.Equal(other.index[:len(other.index)-1], field.index[:len(field.index)-1]) &&
+				!(other.field.Duplicate && field.field.Duplicate) {
+				return nil, fmt.Errorf("%w: embedded or nested fields of %v map to column %q", ErrDuplicateColumn, t, field.field.DB)
+			}
+		}
+	}
+
+	// return fields
+	return result, nil
+}
+
+// extractModelFields returns the fields of modelFields without their index sequences, for
+// finding the fields of a model by their columns and annotations.
+//
+// Returns the fields, or an error if the fields of a struct could not be extracted.
+func extractModelFields(t reflect.Type, o Options) ([]*domain.Field, error) {
+	// extract fields
+	fields, err := modelFields(t, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// fields without index sequences
+	result := make([]*domain.Field, len(fields))
+	for i, field := range fields {
+		result[i] = field.field
+	}
+
+	// return fields
+	return result, nil
+}
+
+// valueByIndex returns the nested struct field of v at the index sequence, see fieldByIndex,
+// without allocating nil pointers.
+//
+// Returns the field, or false if a pointer to an embedded or nested struct on the way is nil.
+func valueByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		// dereference pointer to struct
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+
+		// nested field
+		v = v.Field(x)
+	}
+
+	// return field
+	return v, true
+}
@@ -0,0 +1,116 @@
+package qbr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+// base is a struct embedded by the models of the tests.
+type base struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+// address is a struct nested by the models of the tests.
+type address struct {
+	City string `db:"city"`
+}
+
+func TestEmbeddedFields(t *testing.T) {
+	t.Run("shadowed", func(t *testing.T) {
+		// name of the embedded struct is shadowed by the name of the outer struct
+		type customer struct {
+			base
+			Name string `db:"name"`
+		}
+		qbrtest.Golden(t, qbr.NewCreate().Table("customers").SetStruct(customer{base: base{ID: 1, Name: "inner"}, Name: "outer"}))
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		type customer struct {
+			base
+			Address address `db:"address_"`
+		}
+		qbrtest.Golden(t, qbr.NewCreate().Table("customers").SetStruct(customer{base: base{ID: 1, Name: "ann"}, Address: address{City: "Oslo"}}))
+	})
+
+	t.Run("nested collision", func(t *testing.T) {
+		// prefixed column of the nested struct is not shadowed by the field of the outer struct
+		type customer struct {
+			AddressCity string  `db:"address_city"`
+			Address     address `db:"address_"`
+		}
+		_, err := qbr.NewCreate().Table("customers").SetStruct(customer{AddressCity: "Oslo", Address: address{City: "Bergen"}}).Build()
+		if !errors.Is(err, qbr.ErrDuplicateColumn) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrDuplicateColumn)
+		}
+	})
+
+	t.Run("embedded collision", func(t *testing.T) {
+		// fields of embedded structs of the same depth map to the same column
+		type audit struct {
+			Name string `db:"name"`
+		}
+		type customer struct {
+			base
+			audit
+		}
+		_, err := qbr.NewCreate().Table("customers").SetStruct(customer{}).Build()
+		if !errors.Is(err, qbr.ErrDuplicateColumn) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrDuplicateColumn)
+		}
+	})
+}
+
+func TestModelFieldsScanner(t *testing.T) {
+	// point is flattened until a scanner is registered for it
+	type point struct {
+		X int `db:"x"`
+		Y int `db:"y"`
+	}
+	type place struct {
+		ID  int64 `db:"id"`
+		Loc point `db:"loc_"`
+	}
+	insert := func() string {
+		query, _, err := qbr.NewCreate().Table("places").SetStruct(place{ID: 1, Loc: point{X: 1, Y: 2}}).ToSQL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return query
+	}
+
+	// flattened point
+	if got, want := insert(), "INSERT INTO places (id, loc_x, loc_y) VALUES ($1, $2, $3) RETURNING *"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// cached fields are cleared by the registration
+	qbr.RegisterScanner(func(src any, dest *point) error { return nil })
+	if got, want := insert(), "INSERT INTO places (id, loc_) VALUES ($1, $2) RETURNING *"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func BenchmarkModelFields(b *testing.B) {
+	// model with embedded and nested structs
+	type customer struct {
+		base
+		Email   string  `db:"email"`
+		Status  string  `db:"status"`
+		Home    address `db:"home_"`
+		Work    address `db:"work_"`
+		Version int     `db:"version"`
+	}
+	value := customer{base: base{ID: 1, Name: "ann"}, Email: "ann@example.com", Home: address{City: "Oslo"}}
+
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := qbr.NewCreate().Table("customers").SetStruct(value).Build(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package qbr
+
+import (
+	"errors"
+
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// Query validation errors.
+var (
+	ErrEmptySet               = errors.New("update without data to set")
+	ErrEmptyInsert            = errors.New("insert without data")
+	ErrMissingWhere           = errors.New("update or delete without conditions")
+	ErrConflictingAnnotations = errors.New("conflicting annotations")
+	ErrDuplicateColumn        = errors.New("duplicate column")
+	ErrMissingTenant          = errors.New("tenant scoped query without tenant")
+	ErrInvalidLock            = sqlbuilder.ErrInvalidLock
+	ErrInvalidConflict        = errors.New("invalid conflict clause")
+	ErrInvalidJoin            = errors.New("invalid join")
+	ErrInvalidCTE             = errors.New("invalid common table expression")
+	ErrInvalidCompound        = errors.New("invalid set operation")
+	ErrUnsafeIdentifier       = errors.New("unsafe identifier")
+	ErrNoChanges              = errors.New("update without changes")
+	ErrZeroCondition          = errors.New("condition dropped because of a zero value")
+	ErrForeignField           = errors.New("condition on field of model not in query")
+	ErrHavingWithoutGroupBy   = errors.New("HAVING without GROUP BY")
+	ErrOffsetWithoutOrder     = sqlbuilder.ErrOffsetWithoutOrder
+)
+
+// Execution errors.
+var (
+	// ErrStaleRecord is returned by Exec if an update with optimistic locking affected no rows.
+	ErrStaleRecord = errors.New("stale record: version changed or record deleted")
+
+	// ErrNotFound is returned by Result.MustAffectOne if the statement affected no rows.
+	ErrNotFound = errors.New("no rows affected")
+
+	// ErrTooManyRows is returned by Result.MustAffectOne if the statement affected more than one row.
+	ErrTooManyRows = errors.New("more than one row affected")
+)
+
+// Pagination errors.
+var (
+	// ErrInvalidCursor is returned by DecodeCursor if the cursor is malformed or does not
+	// contain the expected count of values.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
@@ -0,0 +1,213 @@
+package qbr
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// Executor executes SQL queries.
+//
+// It is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Exec builds the query and executes it using the given executor, without returning
+// any rows. The statement is logged by the logger of the query, if any.
+//
+// Returns the result of the statement with the affected rows, see Result, or an error if
+// the query could not be built or executed, or the driver could not report the affected rows. The query is not executed if it has any errors. ErrStaleRecord is
+// returned if an update with optimistic locking (see SetStruct) affected no rows, see
+// Timeout for the timeout of the execution.
+// The write hooks are called before and after the statement is executed, see
+// BeforeWrite and AfterWrite.
+func (qb *Query) Exec(ctx context.Context, exec Executor) (*Result, error) {
+	// derive context with timeout
+	ctx, cancel := qb.withTimeout(ctx)
+	defer cancel()
+
+	// call before write hooks
+	q, err := qb.runBeforeWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// build query
+	stmt, err := q.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// set statement timeout
+	if err := q.setStatementTimeout(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	// execute query
+	res, err := exec.ExecContext(q.routeContext(ctx), stmt.SQL, stmt.Params...)
+
+	// log query
+	if logger := q.options.Logger; logger != nil {
+		logger.LogQuery(ctx, stmt, err)
+	}
+
+	// check is query failed
+	if err != nil {
+		return nil, q.timeoutError(ctx, stmt, err)
+	}
+
+	// fetch result
+	result, err := newResult(res)
+	if err != nil {
+		return nil, err
+	}
+
+	// check version lock
+	if q.isVersionLocked() && result.RowsAffected() == 0 {
+		return result, ErrStaleRecord
+	}
+
+	// call after write hooks
+	if err := q.runAfterWrite(ctx, q.afterWriteHooks(), stmt, result.RowsAffected()); err != nil {
+		return result, err
+	}
+
+	// return result
+	return result, nil
+}
+
+// queryContext executes the statement of the query using the given executor, the statement
+// is logged by the logger of the query, if any. The context must have the timeout of the
+// query, see withTimeout.
+//
+// Returns the resulting rows, or an error if the statement could not be executed.
+func (qb *Query) queryContext(ctx context.Context, exec Executor, stmt *Statement) (*sql.Rows, error) {
+	// set statement timeout
+	if err := qb.setStatementTimeout(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	// execute query
+	rows, err := exec.QueryContext(qb.routeContext(ctx), stmt.SQL, stmt.Params...)
+
+	// log query
+	if logger := qb.options.Logger; logger != nil {
+		logger.LogQuery(ctx, stmt, err)
+	}
+
+	// return rows
+	return rows, qb.timeoutError(ctx, stmt, err)
+}
+
+// ExecContext builds the query and executes it using the given executor, as Exec, and returns
+// the result of the driver, for code written against database/sql.
+//
+// Returns the result of the driver, or an error if the query could not be built or executed,
+// see Exec.
+func (qb *Query) ExecContext(ctx context.Context, exec Executor) (sql.Result, error) {
+	// execute query
+	result, err := qb.Exec(ctx, exec)
+	if result == nil {
+		return nil, err
+	}
+
+	// return result of driver
+	return result.SQLResult(), err
+}
+
+// QueryContext builds the query and executes it using the given executor, returning the rows,
+// which must be closed by the caller. The statement is logged by the logger of the query, if any.
+//
+// The rows are read after QueryContext returns, so the timeout of the query (see Timeout) is
+// only applied as the statement timeout of the database with the WithStatementTimeout option,
+// use a context with a deadline otherwise.
+//
+// Returns the rows, or an error if the query could not be built or executed. The query is not
+// executed if it has any errors.
+func (qb *Query) QueryContext(ctx context.Context, exec Executor) (*sql.Rows, error) {
+	// build query
+	stmt, err := qb.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// execute query
+	return qb.queryContext(ctx, exec, stmt)
+}
+
+// SelectContext builds the query, executes it using the given executor and scans the resulting
+// rows into dest, which is a pointer to a slice of structs or of pointers to structs, whose
+// fields are mapped to the columns as by ScanAll. Scanned rows are appended to the slice.
+//
+// Returns an error if dest is not a pointer to a slice of structs, or if the query could not be
+// built, executed or scanned. The query is not executed if it has any errors.
+func (qb *Query) SelectContext(ctx context.Context, exec Executor, dest any) error {
+	// check destination
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("unsupported select destination: %T", dest)
+	}
+
+	// derive context with timeout
+	ctx, cancel := qb.withTimeout(ctx)
+	defer cancel()
+
+	// build query
+	stmt, err := qb.BuildContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// execute query
+	rows, err := qb.queryContext(ctx, exec, stmt)
+	if err != nil {
+		return err
+	}
+
+	// scan rows
+	if err := scanSlice(rows, v.Elem(), qb.options, nil); err != nil {
+		return qb.timeoutError(ctx, stmt, err)
+	}
+	return nil
+}
+
+// GetContext builds the query, executes it using the given executor and scans the first
+// resulting row into dest, which is a pointer to a struct whose fields are mapped to the columns
+// as by ScanAll. Further rows are discarded, so the query should select a single row, for
+// example by its primary key or with Limit.
+//
+// Returns sql.ErrNoRows if the query returned no rows, or an error if dest is not a pointer to
+// a struct, or if the query could not be built, executed or scanned. The query is not executed
+// if it has any errors.
+func (qb *Query) GetContext(ctx context.Context, exec Executor, dest any) error {
+	// check destination
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported get destination: %T", dest)
+	}
+
+	// derive context with timeout
+	ctx, cancel := qb.withTimeout(ctx)
+	defer cancel()
+
+	// build query
+	stmt, err := qb.BuildContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// execute query
+	rows, err := qb.queryContext(ctx, exec, stmt)
+	if err != nil {
+		return err
+	}
+
+	// scan row
+	if err := scanOne(rows, v.Elem(), qb.options); err != nil {
+		return qb.timeoutError(ctx, stmt, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package qbr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ExplainPlan is the execution plan of a statement, parsed from the JSON output of the
+// PostgreSQL EXPLAIN (FORMAT JSON, ANALYZE, BUFFERS) statement.
+type ExplainPlan struct {
+	Plan          *PlanNode       `json:"Plan"`           // Root node of the plan.
+	PlanningTime  float64         `json:"Planning Time"`  // Planning time in milliseconds.
+	ExecutionTime float64         `json:"Execution Time"` // Execution time in milliseconds.
+	Raw           json.RawMessage `json:"-"`              // JSON output of the statement.
+}
+
+// PlanNode is a node of an execution plan, see ExplainPlan.
+type PlanNode struct {
+	NodeType            string      `json:"Node Type"`             // Node type, for example "Seq Scan".
+	RelationName        string      `json:"Relation Name"`         // Scanned table, if any.
+	Alias               string      `json:"Alias"`                 // Alias of the scanned table, if any.
+	IndexName           string      `json:"Index Name"`            // Scanned index, if any.
+	StartupCost         float64     `json:"Startup Cost"`          // Estimated startup cost.
+	TotalCost           float64     `json:"Total Cost"`            // Estimated total cost.
+	PlanRows            float64     `json:"Plan Rows"`             // Estimated rows.
+	ActualStartupTime   float64     `json:"Actual Startup Time"`   // Startup time in milliseconds.
+	ActualTotalTime     float64     `json:"Actual Total Time"`     // Total time in milliseconds per loop.
+	ActualRows          float64     `json:"Actual Rows"`           // Rows per loop.
+	ActualLoops         float64     `json:"Actual Loops"`          // Loops of the node.
+	SharedHitBlocks     int64       `json:"Shared Hit Blocks"`     // Shared blocks found in the buffer cache.
+	SharedReadBlocks    int64       `json:"Shared Read Blocks"`    // Shared blocks read from disk.
+	SharedDirtiedBlocks int64       `json:"Shared Dirtied Blocks"` // Shared blocks dirtied.
+	SharedWrittenBlocks int64       `json:"Shared Written Blocks"` // Shared blocks written.
+	TempReadBlocks      int64       `json:"Temp Read Blocks"`      // Temporary blocks read.
+	TempWrittenBlocks   int64       `json:"Temp Written Blocks"`   // Temporary blocks written.
+	Plans               []*PlanNode `json:"Plans"`                 // Child nodes.
+}
+
+// Nodes returns the nodes of the plan in depth-first order, starting with the root node.
+func (p *ExplainPlan) Nodes() []*PlanNode {
+	// nodes
+	var nodes []*PlanNode
+
+	// walk plan
+	stack := []*PlanNode{p.Plan}
+	for len(stack) > 0 {
+		// pop node
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == nil {
+			continue
+		}
+
+		// add node and push children in reverse order
+		nodes = append(nodes, node)
+		for i := len(node.Plans) - 1; i >= 0; i-- {
+			stack = append(stack, node.Plans[i])
+		}
+	}
+
+	// return nodes
+	return nodes
+}
+
+// HasNode checks if the plan has a node of the given type on the given table, or on any
+// table if relation is empty, for example HasNode("Seq Scan", "users").
+func (p *ExplainPlan) HasNode(nodeType, relation string) bool {
+	// find node
+	for _, node := range p.Nodes() {
+		if node.NodeType == nodeType && (relation == "" || node.RelationName == relation) {
+			return true
+		}
+	}
+
+	// not found
+	return false
+}
+
+// ExplainJSON builds the query and executes it with EXPLAIN (FORMAT JSON, ANALYZE, BUFFERS)
+// using the given executor, so the statement is planned and executed by PostgreSQL.
+//
+// Since ANALYZE executes the statement, insert, update and delete queries modify the rows
+// of the table; explain them in a transaction which is rolled back afterwards.
+//
+// Returns the parsed plan, or an error if the query could not be built or executed, or
+// its plan could not be parsed.
+func (qb *Query) ExplainJSON(ctx context.Context, exec Executor) (*ExplainPlan, error) {
+	// derive context with timeout
+	ctx, cancel := qb.withTimeout(ctx)
+	defer cancel()
+
+	// build query
+	stmt, err := qb.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// explain statement
+	explain := *stmt
+	explain.SQL = "EXPLAIN (FORMAT JSON, ANALYZE, BUFFERS) " + stmt.SQL
+
+	// execute explain
+	rows, err := qb.queryContext(ctx, exec, &explain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// scan output
+	var raw []byte
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("explain returned no rows")
+	}
+	if err := rows.Scan(&raw); err != nil {
+		return nil, err
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	// parse plans
+	var plans []ExplainPlan
+	if err := json.Unmarshal(raw, &plans); err != nil {
+		return nil, fmt.Errorf("parse explain output: %w", err)
+	}
+	if len(plans) == 0 {
+		return nil, errors.New("explain returned no plan")
+	}
+
+	// return plan
+	plan := &plans[0]
+	plan.Raw = raw
+	return plan, nil
+}
@@ -0,0 +1,212 @@
+package qbr
+
+import "github.com/tyrenix/qbr/domain"
+
+// Lit returns an expression of the given literal value, which is bound as a parameter.
+func Lit(value any) *domain.Expression {
+	return &domain.Expression{
+		Kind:  domain.ExpressionLiteral,
+		Value: value,
+	}
+}
+
+// Subquery returns an expression of the read query in parentheses, for example a scalar
+// subquery in a selected field or a condition:
+//
+//	Gt(FieldOf[Order]("total"), Subquery(NewQuery[Order]("read").Select(Avg(FieldOf[Order]("total")))))
+//
+// The params of the subquery are bound at the position of the expression.
+func Subquery(sub domain.Subquery) *domain.Expression {
+	return &domain.Expression{
+		Kind:     domain.ExpressionSubquery,
+		Subquery: sub,
+	}
+}
+
+// Add returns an expression adding the given operands.
+//
+// left + right
+func Add(left, right any) *domain.Expression {
+	return newBinaryExpression("+", left, right)
+}
+
+// Sub returns an expression subtracting the right operand from the left operand.
+//
+// left - right
+func Sub(left, right any) *domain.Expression {
+	return newBinaryExpression("-", left, right)
+}
+
+// Mul returns an expression multiplying the given operands.
+//
+// left * right
+func Mul(left, right any) *domain.Expression {
+	return newBinaryExpression("*", left, right)
+}
+
+// Div returns an expression dividing the left operand by the right operand.
+//
+// left / right
+func Div(left, right any) *domain.Expression {
+	return newBinaryExpression("/", left, right)
+}
+
+// Func returns an expression calling the SQL function with the given name and arguments,
+// for example Func("COALESCE", discount, 0).
+//
+// The name must be a valid SQL identifier, otherwise building the query fails.
+func Func(name string, args ...any) *domain.Expression {
+	return &domain.Expression{
+		Kind:     domain.ExpressionFunction,
+		Name:     name,
+		Operands: toExpressions(args...),
+	}
+}
+
+// Extract returns an expression extracting the given part of a date or time operand,
+// for example Extract("YEAR", createdAt).
+//
+// EXTRACT(part FROM from)
+//
+// The part must be a valid SQL identifier, otherwise building the query fails.
+func Extract(part string, from any) *domain.Expression {
+	return &domain.Expression{
+		Kind:     domain.ExpressionExtract,
+		Name:     part,
+		Operands: toExpressions(from),
+	}
+}
+
+// Coalesce returns an expression of the first of the given operands which is not NULL,
+// the fallback literals are bound as parameters.
+//
+// COALESCE(value, fallbacks...)
+func Coalesce(value any, fallbacks ...any) *domain.Expression {
+	return Func("COALESCE", append([]any{value}, fallbacks...)...)
+}
+
+// NullIf returns an expression which is NULL if the given operands are equal, and the
+// left operand otherwise.
+//
+// NULLIF(left, right)
+func NullIf(left, right any) *domain.Expression {
+	return Func("NULLIF", left, right)
+}
+
+// Greatest returns an expression of the greatest of the given operands. It is rendered as MAX
+// of the operands in SQLite, and as MAX of a VALUES table in SQL Server, which ignores NULL
+// operands as GREATEST in PostgreSQL and SQL Server 2022.
+//
+// GREATEST(values...)
+func Greatest(values ...any) *domain.Expression {
+	return Func("GREATEST", values...)
+}
+
+// Least returns an expression of the least of the given operands, rendered as MIN in SQLite and
+// SQL Server, see Greatest.
+//
+// LEAST(values...)
+func Least(values ...any) *domain.Expression {
+	return Func("LEAST", values...)
+}
+
+// Count returns an expression of the count of the rows in which the operand is not NULL, or
+// of all rows for the all field, for example Count(NewAllField()).As("total").
+//
+// COUNT(value)
+func Count(value any) *domain.Expression {
+	return Func("COUNT", value)
+}
+
+// Sum returns an expression of the sum of the operand over the rows of a group.
+//
+// SUM(value)
+func Sum(value any) *domain.Expression {
+	return Func("SUM", value)
+}
+
+// Avg returns an expression of the average of the operand over the rows of a group.
+//
+// AVG(value)
+func Avg(value any) *domain.Expression {
+	return Func("AVG", value)
+}
+
+// Min returns an expression of the smallest value of the operand over the rows of a group.
+//
+// MIN(value)
+func Min(value any) *domain.Expression {
+	return Func("MIN", value)
+}
+
+// Max returns an expression of the largest value of the operand over the rows of a group.
+//
+// MAX(value)
+func Max(value any) *domain.Expression {
+	return Func("MAX", value)
+}
+
+// Round returns an expression rounding the value to the given number of decimal places,
+// so the rounding of NUMERIC values is done by the database without float conversion.
+//
+// ROUND(value, scale)
+func Round(value any, scale int) *domain.Expression {
+	return Func("ROUND", value, scale)
+}
+
+// Expr returns a raw SQL expression for database specific functions, for example
+// Expr("ts_rank(search_vector, plainto_tsquery(?))", query).As("rank").
+//
+// Each "?" of the SQL is replaced by the next argument: fields and expressions are
+// rendered in place, any other value is bound as a parameter using the placeholder
+// of the query. "??" is rendered as a literal "?". The SQL is used as is, so it must
+// never contain user input.
+func Expr(sql string, args ...any) *domain.Expression {
+	return &domain.Expression{
+		Kind:     domain.ExpressionRaw,
+		Name:     sql,
+		Operands: toExpressions(args...),
+	}
+}
+
+// newBinaryExpression returns an expression applying the operator to the given operands.
+func newBinaryExpression(operator string, left, right any) *domain.Expression {
+	return &domain.Expression{
+		Kind:     domain.ExpressionBinary,
+		Name:     operator,
+		Operands: toExpressions(left, right),
+	}
+}
+
+// toExpressions converts the given operands to expressions.
+//
+// Fields are converted to field expressions, or to their expression if they are computed
+// fields, expressions and case expressions are used as is, subqueries are converted to subquery
+// expressions, and any other value is converted to a literal.
+func toExpressions(operands ...any) []*domain.Expression {
+	// expressions
+	exprs := make([]*domain.Expression, len(operands))
+
+	// convert operands
+	for i, operand := range operands {
+		switch v := operand.(type) {
+		case *domain.Expression:
+			exprs[i] = v
+		case *CaseExpression:
+			exprs[i] = v.expr
+		case *domain.Field:
+			if v != nil && v.Expression != nil {
+				exprs[i] = v.Expression
+				continue
+			}
+			exprs[i] = &domain.Expression{Kind: domain.ExpressionField, Field: v}
+		case domain.Subquery:
+			exprs[i] = Subquery(v)
+		default:
+			exprs[i] = Lit(v)
+		}
+	}
+
+	// return expressions
+	return exprs
+}
@@ -0,0 +1,34 @@
+package qbr_test
+
+import (
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestGreatestLeast(t *testing.T) {
+	// read of the greatest and least of columns with a literal in dialect
+	read := func(d domain.Dialect) *qbr.Query {
+		age, id := qbr.FieldOf[user]("age"), qbr.FieldOf[user]("id")
+		return qbr.NewRead(qbr.WithDialect(d)).Table("users").Select(
+			qbr.Greatest(age, id, 18).As("high"),
+			qbr.Least(age, 65).As("low"),
+		)
+	}
+
+	for _, d := range []domain.Dialect{qbr.Postgres, qbr.MySQL, qbr.SQLite, qbr.SQLServer, qbr.Oracle} {
+		t.Run(d.Name(), func(t *testing.T) {
+			qbrtest.Golden(t, read(d))
+		})
+	}
+
+	t.Run("single operand", func(t *testing.T) {
+		// the greatest of a single operand is not aggregated
+		query, _, err := qbr.NewRead(qbr.WithDialect(qbr.SQLite)).Table("users").Select(qbr.Greatest(qbr.FieldOf[user]("age")).As("high")).ToSQL()
+		if err != nil || query != "SELECT age AS high FROM users" {
+			t.Fatalf("got %q, %v, want SELECT age AS high FROM users", query, err)
+		}
+	})
+}
@@ -12,8 +12,9 @@ import (
 // each one to the created Field model before returning it. The returned Field
 // model is fully constructed and ready to use.
 //
-// The FieldOption functions can be used to set the DB field name, ignored
-// operations, and aggregation type for the created Field model.
+// The FieldOption functions can be used to set the DB field name, per operation
+// field names, ignored or allowed operations, and aggregation type for the
+// created Field model.
 func NewField(options ...FieldOption) *domain.Field {
 	// field
 	f := &domain.Field{}
@@ -30,8 +31,9 @@ func NewField(options ...FieldOption) *domain.Field {
 // NewFieldFromStruct creates a Field model from a specified field in a struct.
 // It takes an input struct 's' and a 'fieldName' string, and returns a pointer
 // to a Field model representing the specified field. If 's' is a pointer, it is
-// dereferenced before processing. If 's' is not a struct, or if the specified
-// field does not exist, the function returns nil.
+// dereferenced before processing. If 's' is not a struct, if the specified
+// field does not exist, or if its annotations could not be parsed, the function
+// returns nil.
 func NewFieldFromStruct(s any, fieldName string) *domain.Field {
 	val := reflect.ValueOf(s)
 	t := reflect.TypeOf(s)
@@ -54,7 +56,13 @@ func NewFieldFromStruct(s any, fieldName string) *domain.Field {
 	}
 
 	// extract field from struct
-	return extractFieldFromStruct(field)
+	f, err := extractFieldFromStruct(t, field, Options{TagName: string(domain.QueryDB)})
+	if err != nil {
+		return nil
+	}
+
+	// return field
+	return f
 }
 
 // FieldOption is a function that configures a Field model.
@@ -78,6 +86,36 @@ func WithIgnoreOn(ignoreOn ...domain.OperationType) FieldOption {
 	}
 }
 
+// WithOnlyOn returns a FieldOption that sets the only allowed operations for a Field model.
+//
+// It takes a variable number of OperationType values as arguments, and returns a FieldOption that
+// appends each argument to the OnlyOn field of the Field model. The field is ignored for every
+// operation not contained in OnlyOn.
+func WithOnlyOn(onlyOn ...domain.OperationType) FieldOption {
+	return func(f *domain.Field) {
+		f.OnlyOn = append(f.OnlyOn, onlyOn...)
+	}
+}
+
+// WithColumnOn returns a FieldOption that sets the DB field name used for the given operations
+// instead of the DB field of a Field model.
+//
+// It is useful when an operation targets another relation than the others, for example when
+// reads come from a view with renamed columns.
+func WithColumnOn(column string, ops ...domain.OperationType) FieldOption {
+	return func(f *domain.Field) {
+		// init columns
+		if f.Columns == nil {
+			f.Columns = make(map[domain.OperationType]string, len(ops))
+		}
+
+		// set column for operations
+		for _, op := range ops {
+			f.Columns[op] = column
+		}
+	}
+}
+
 // WithAggregation sets the aggregation type for a Field model.
 //
 // It takes an AggregationType and returns a FieldOption that sets the
@@ -101,26 +139,30 @@ func NewAllField() *domain.Field {
 // NewSumField creates a new Field model with sum aggregation type.
 //
 // It takes the existing Field model and creates a new one with the same
-// DB field and with AggregationType set to AggregationSum.
+// DB fields and with AggregationType set to AggregationSum.
 //
 // Returns the created Field model with sum aggregation type.
 func NewSumField(field *domain.Field) *domain.Field {
 	return &domain.Field{
 		DB:          field.DB,
 		Aggregation: domain.AggregationSum,
+		Columns:     field.Columns,
+		Expression:  field.Expression,
 	}
 }
 
 // NewCountField creates new Field model with count type.
 //
 // It takes the existing Field model and creates a new one with the same
-// DB field and with Type set to FieldCount.
+// DB fields and with Type set to FieldCount.
 //
 // Returns created Field model with count type.
 func NewCountField(field *domain.Field) *domain.Field {
 	return &domain.Field{
 		DB:          field.DB,
 		Aggregation: domain.AggregationCount,
+		Columns:     field.Columns,
+		Expression:  field.Expression,
 	}
 }
 
@@ -0,0 +1,74 @@
+package qbr_test
+
+import (
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+)
+
+func TestNewFieldFromStruct(t *testing.T) {
+	// model with annotated fields
+	type account struct {
+		ID       int64  `db:"id" qbr:"pk"`
+		Email    string `db:"email" qbr:"ignore_on=update"`
+		Password string `db:"password" qbr:"ignore_on=read only_on=create"`
+		Note     string
+	}
+
+	t.Run("field", func(t *testing.T) {
+		field := qbr.NewFieldFromStruct(&account{}, "Email")
+		if field == nil || field.DB != "email" || len(field.IgnoreOn) != 1 || field.IgnoreOn[0] != domain.OperationUpdate {
+			t.Fatalf("got field %+v, want email ignored on update", field)
+		}
+	})
+
+	t.Run("primary key", func(t *testing.T) {
+		if field := qbr.NewFieldFromStruct(account{}, "ID"); field == nil || !field.PK {
+			t.Fatalf("got field %+v, want primary key id", field)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, s := range map[string]any{
+			"Note":     account{},
+			"Password": account{},
+			"Name":     account{},
+			"ID":       1,
+		} {
+			if field := qbr.NewFieldFromStruct(s, name); field != nil {
+				t.Fatalf("got field %+v for %s, want nil", field, name)
+			}
+		}
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		for _, s := range []any{nil, (*account)(nil), "account", []account{}} {
+			if field := qbr.NewFieldFromStruct(s, "ID"); field != nil {
+				t.Fatalf("got field %+v for %T, want nil", field, s)
+			}
+		}
+	})
+}
+
+func TestFieldOf(t *testing.T) {
+	t.Run("unknown field", func(t *testing.T) {
+		qb := qbr.NewRead().Model(user{}).Where(qbr.Eq(qbr.FieldOf[user]("x"), 1))
+		_, _, err := qb.ToSQL()
+		wantError(t, err, `unknown field "x" for model qbr_test.user`)
+		_, err = qb.Build()
+		wantError(t, err, `unknown field "x" for model qbr_test.user`)
+	})
+
+	t.Run("snake case columns", func(t *testing.T) {
+		type order struct {
+			UserID int64
+		}
+		if field := qbr.FieldOf[order]("user_id", qbr.WithSnakeCaseColumns()); field.Err != nil || field.DB != "user_id" {
+			t.Fatalf("got field %s, %v, want user_id", field, field.Err)
+		}
+		if field := qbr.FieldOf[order]("user_id"); field.Err == nil {
+			t.Fatalf("got field %s, want error without snake case columns", field)
+		}
+	})
+}
@@ -0,0 +1,309 @@
+package qbr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// filterOperators contains the operators accepted in URL and JSON filters by their names.
+var filterOperators = map[string]domain.OperatorType{
+	"eq":     domain.OperatorEqual,
+	"ne":     domain.OperatorNotEqual,
+	"lt":     domain.OperatorLessThan,
+	"gt":     domain.OperatorGreaterThan,
+	"lte":    domain.OperatorLessThanOrEqual,
+	"gte":    domain.OperatorGreaterThanOrEqual,
+	"like":   domain.OperatorLike,
+	"in":     domain.OperatorIn,
+	"not_in": domain.OperatorNotIn,
+}
+
+// filterOperatorNames contains the names of the operators accepted in filters.
+var filterOperatorNames = map[domain.OperatorType]string{
+	domain.OperatorEqual:              "eq",
+	domain.OperatorNotEqual:           "ne",
+	domain.OperatorLessThan:           "lt",
+	domain.OperatorGreaterThan:        "gt",
+	domain.OperatorLessThanOrEqual:    "lte",
+	domain.OperatorGreaterThanOrEqual: "gte",
+	domain.OperatorLike:               "like",
+	domain.OperatorIn:                 "in",
+	domain.OperatorNotIn:              "not_in",
+}
+
+// JSON filter keys.
+const (
+	jsonFilterAnd   = "and"
+	jsonFilterOr    = "or"
+	jsonFilterField = "field"
+	jsonFilterOp    = "op"
+	jsonFilterValue = "value"
+)
+
+// jsonFilter is a node of the JSON filter document used for formatting.
+type jsonFilter struct {
+	And   *[]jsonFilter `json:"and,omitempty"`
+	Or    *[]jsonFilter `json:"or,omitempty"`
+	Field string        `json:"field,omitempty"`
+	Op    string        `json:"op,omitempty"`
+	Value any           `json:"value,omitempty"`
+}
+
+// ParseJSONFilter parses the JSON filter document to a condition on the fields of the model.
+//
+// The document is a tree of logical and comparison nodes:
+//
+//	{"and": [{"field": "status", "op": "eq", "value": "active"}, {"or": [...]}]}
+//
+// Comparison nodes reference fields of the model by their "db" annotation, only
+// fields which are not ignored for read operations are allowed. The accepted
+// operators are eq, ne, lt, gt, lte, gte, like, in and not_in, the value is converted
+// to the Go type of the field, and a null value compares the field with NULL. The
+// values of in and not_in are arrays whose elements are converted to the Go type of
+// the field, for example {"field": "status", "op": "in", "value": ["a", "b"]}, and like
+// patterns are accepted only for string fields. The nesting depth of logical nodes is
+// limited by maxDepth, if it is not 0.
+//
+// Returns the condition, or an error if the document is invalid.
+func ParseJSONFilter(data []byte, model any, maxDepth int) (domain.Condition, error) {
+	// model type
+	t := structTypeOf(model)
+	if t == nil {
+		return domain.Condition{}, fmt.Errorf("unsupported filter model type: %T", model)
+	}
+
+	// extract fields
+	fields, err := extractFieldsFromType(t, Options{TagName: string(domain.QueryDB)})
+	if err != nil {
+		return domain.Condition{}, err
+	}
+
+	// parse document
+	return parseJSONFilterNode(json.RawMessage(data), t, fields, 1, maxDepth)
+}
+
+// FormatJSONFilter formats the condition to the JSON filter document, see ParseJSONFilter.
+//
+// Returns the document, or an error if the condition uses an operator not supported by filters.
+func FormatJSONFilter(cond domain.Condition) ([]byte, error) {
+	// format condition
+	node, err := formatJSONFilterNode(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	// marshal document
+	return json.Marshal(node)
+}
+
+// parseJSONFilterNode parses the JSON filter node at the given nesting depth.
+func parseJSONFilterNode(data json.RawMessage, t reflect.Type, fields []*domain.Field, depth, maxDepth int) (domain.Condition, error) {
+	// check nesting depth
+	if maxDepth > 0 && depth > maxDepth {
+		return domain.Condition{}, fmt.Errorf("filter nesting depth exceeds %d", maxDepth)
+	}
+
+	// decode node
+	var node map[string]json.RawMessage
+	if err := json.Unmarshal(data, &node); err != nil {
+		return domain.Condition{}, fmt.Errorf("invalid filter node: %w", err)
+	}
+
+	// logical nodes
+	for _, key := range []string{jsonFilterAnd, jsonFilterOr} {
+		// check is logical node
+		raw, ok := node[key]
+		if !ok {
+			continue
+		}
+
+		// logical operator
+		operator := domain.OperatorAnd
+		if key == jsonFilterOr {
+			operator = domain.OperatorOr
+		}
+
+		// check is single key node
+		if len(node) != 1 {
+			return domain.Condition{}, fmt.Errorf("filter node %q must not contain other keys", key)
+		}
+
+		// decode sub nodes
+		var subs []json.RawMessage
+		if err := json.Unmarshal(raw, &subs); err != nil {
+			return domain.Condition{}, fmt.Errorf("invalid %q filter node: %w", key, err)
+		}
+
+		// parse sub nodes
+		conds := make([]domain.Condition, 0, len(subs))
+		var errs []error
+		for _, sub := range subs {
+			cond, err := parseJSONFilterNode(sub, t, fields, depth+1, maxDepth)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			conds = append(conds, cond)
+		}
+
+		// check is sub nodes errors found
+		if len(errs) > 0 {
+			return domain.Condition{}, errors.Join(errs...)
+		}
+
+		// return logical condition
+		return domain.Condition{Operator: operator, Value: conds}, nil
+	}
+
+	// parse comparison node
+	return parseJSONFilterComparison(node, t, fields)
+}
+
+// parseJSONFilterComparison parses the JSON filter comparison node.
+func parseJSONFilterComparison(node map[string]json.RawMessage, t reflect.Type, fields []*domain.Field) (domain.Condition, error) {
+	// check keys
+	for _, key := range sortedKeys(node) {
+		if key != jsonFilterField && key != jsonFilterOp && key != jsonFilterValue {
+			return domain.Condition{}, fmt.Errorf("unknown filter key %q", key)
+		}
+	}
+
+	// decode column and operator
+	var column, op string
+	if err := json.Unmarshal(node[jsonFilterField], &column); err != nil {
+		return domain.Condition{}, fmt.Errorf("invalid filter field: %w", err)
+	}
+	if raw, ok := node[jsonFilterOp]; ok {
+		if err := json.Unmarshal(raw, &op); err != nil {
+			return domain.Condition{}, fmt.Errorf("invalid filter operator: %w", err)
+		}
+	} else {
+		op = "eq"
+	}
+
+	// get operator
+	operator, ok := filterOperators[op]
+	if !ok {
+		return domain.Condition{}, fmt.Errorf("field %q: unsupported operator %q", column, op)
+	}
+
+	// find field
+	index := findFilterField(fields, column)
+	if index < 0 {
+		return domain.Condition{}, fmt.Errorf("unknown column %q", column)
+	}
+
+	// check operator for field type
+	if err := checkFilterOperator(operator, t.Field(index).Type); err != nil {
+		return domain.Condition{}, fmt.Errorf("field %q: %w", column, err)
+	}
+
+	// null value
+	raw := node[jsonFilterValue]
+	if len(raw) == 0 || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		// check is null supported by operator
+		if operator != domain.OperatorEqual && operator != domain.OperatorNotEqual {
+			return domain.Condition{}, fmt.Errorf("field %q: operator %q does not support null", column, op)
+		}
+
+		// return null condition
+		return domain.Condition{Field: fields[index], Operator: operator, Value: domain.ValueNull}, nil
+	}
+
+	// convert values of in and not_in to field type
+	if operator == domain.OperatorIn || operator == domain.OperatorNotIn {
+		// decode array
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return domain.Condition{}, fmt.Errorf("field %q: operator %q requires an array value: %w", column, op, err)
+		}
+
+		// convert items
+		list := make([]any, 0, len(items))
+		for _, item := range items {
+			value := reflect.New(t.Field(index).Type)
+			if err := json.Unmarshal(item, value.Interface()); err != nil {
+				return domain.Condition{}, fmt.Errorf("field %q: invalid value: %w", column, err)
+			}
+			list = append(list, value.Elem().Interface())
+		}
+
+		// return condition
+		return domain.Condition{Field: fields[index], Operator: operator, Value: list}, nil
+	}
+
+	// convert value to field type
+	value := reflect.New(t.Field(index).Type)
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return domain.Condition{}, fmt.Errorf("field %q: invalid value: %w", column, err)
+	}
+
+	// return condition
+	return domain.Condition{
+		Field:    fields[index],
+		Operator: operator,
+		Value:    value.Elem().Interface(),
+	}, nil
+}
+
+// checkFilterOperator checks that the operator of a filter is supported for the field of the
+// given type, LIKE patterns are supported only for string fields.
+func checkFilterOperator(operator domain.OperatorType, t reflect.Type) error {
+	// check is pattern on string field
+	if operator == domain.OperatorLike && t.Kind() != reflect.String {
+		return fmt.Errorf("operator %q requires a string field", filterOperatorNames[operator])
+	}
+
+	// operator is supported
+	return nil
+}
+
+// formatJSONFilterNode formats the condition to the JSON filter node.
+func formatJSONFilterNode(cond domain.Condition) (jsonFilter, error) {
+	// logical condition
+	if cond.Operator == domain.OperatorAnd || cond.Operator == domain.OperatorOr {
+		// sub conditions
+		conds, _ := cond.Value.([]domain.Condition)
+
+		// format sub conditions
+		nodes := make([]jsonFilter, 0, len(conds))
+		for _, sub := range conds {
+			node, err := formatJSONFilterNode(sub)
+			if err != nil {
+				return jsonFilter{}, err
+			}
+			nodes = append(nodes, node)
+		}
+
+		// return logical node
+		if cond.Operator == domain.OperatorOr {
+			return jsonFilter{Or: &nodes}, nil
+		}
+		return jsonFilter{And: &nodes}, nil
+	}
+
+	// check field
+	if cond.Field == nil {
+		return jsonFilter{}, errors.New("nil field in condition")
+	}
+
+	// get operator name
+	op, ok := filterOperatorNames[cond.Operator]
+	if !ok {
+		return jsonFilter{}, fmt.Errorf("field %q: unsupported filter operator %v", cond.Field.DB, cond.Operator)
+	}
+
+	// null value
+	value := cond.Value
+	if v, ok := value.(domain.ValueType); ok && v == domain.ValueNull {
+		value = nil
+	}
+
+	// return comparison node
+	return jsonFilter{Field: cond.Field.DB, Op: op, Value: value}, nil
+}
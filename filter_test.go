@@ -0,0 +1,37 @@
+package qbr_test
+
+import (
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestParseJSONFilter(t *testing.T) {
+	// saved search of users
+	doc := `{"and":[{"field":"status","op":"in","value":["a","b"]},{"or":[{"field":"age","op":"gte","value":18},{"field":"name","op":"not_in","value":["x"]}]}]}`
+
+	t.Run("in", func(t *testing.T) {
+		cond, err := qbr.ParseJSONFilter([]byte(doc), user{}, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		qbrtest.Golden(t, qbr.NewRead().Model(user{}).Where(cond))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		cond, err := qbr.ParseJSONFilter([]byte(doc), user{}, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := qbr.FormatJSONFilter(cond)
+		if err != nil || string(got) != doc {
+			t.Fatalf("got %s, %v, want %s", got, err, doc)
+		}
+	})
+
+	t.Run("in without array", func(t *testing.T) {
+		_, err := qbr.ParseJSONFilter([]byte(`{"field":"status","op":"in","value":"a"}`), user{}, 0)
+		wantError(t, err, `operator "in" requires an array value`)
+	})
+}
@@ -0,0 +1,288 @@
+package qbr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// Fingerprint returns a stable hash of the structure of the query, for grouping the metrics of
+// queries by their shape.
+//
+// The fingerprint covers the operation type, the table of the query (see GetTable, the function
+// set by TableFunc is not evaluated) or its FROM source, the common table expressions, the
+// distinct clause, the selected and set columns in their order, the columns and the fingerprint
+// of the subquery of inserted rows (see InsertFromSelect), the joins and the joined sources of
+// writes with the fingerprints of joined subqueries and of nested subqueries, the tree of
+// conditions with their operators, the groups, the set operations with the fingerprints of
+// combined queries, the sort columns, the presence of keyset pagination, limit and offset and
+// the locking and conflict clauses. Bound values are not included, and IN lists and the rows of
+// multi-row inserts share a single marker regardless of their length, so queries differing only
+// in their values, for example their pagination, have the same fingerprint.
+//
+// The fingerprint of executed statements is logged by the logger of NewSlogLogger, see
+// Statement.Fingerprint.
+func (qb *Query) Fingerprint() string {
+	// hash
+	h := sha256.New()
+
+	// write operation and table
+	fmt.Fprintf(h, "%s\x00%s\x00", qb.operation, qb.prefixTable(qb.GetTable()))
+
+	// write index hints
+	for _, hint := range qb.indexHints {
+		fmt.Fprintf(h, "index\x00%s\x00%s\x00", hint.Type, strings.Join(hint.Indexes, ","))
+	}
+
+	// write distinct
+	fmt.Fprintf(h, "distinct\x00%t\x00", qb.distinct)
+	for _, field := range qb.distinctOn {
+		fmt.Fprintf(h, "distinct_on\x00%s\x00", fingerprintField(&field))
+	}
+
+	// write selected columns
+	for _, field := range qb.selects {
+		fmt.Fprintf(h, "select\x00%s\x00", fingerprintField(&field))
+	}
+
+	// write set columns
+	for _, d := range qb.data {
+		fmt.Fprintf(h, "set\x00%s\x00", fingerprintField(d.Field))
+	}
+
+	// write selected rows of insert
+	if s := qb.insertSelect; s != nil {
+		for _, field := range s.Columns {
+			fmt.Fprintf(h, "insert\x00%s\x00", fingerprintField(&field))
+		}
+		fmt.Fprintf(h, "insert_select\x00%s\x00", fingerprintSubquery(s.Subquery))
+	}
+
+	// write common table expressions
+	for _, cte := range qb.ctes {
+		fmt.Fprintf(h, "with\x00%s\x00%t\x00%s\x00", cte.Name, cte.Recursive, fingerprintSubquery(cte.Subquery))
+	}
+
+	// write from source
+	if qb.from != nil {
+		fmt.Fprintf(h, "from\x00%s\x00", fingerprintSource(*qb.from))
+	}
+
+	// write joins, with the fingerprints of joined queries
+	for _, join := range qb.joins {
+		fmt.Fprintf(h, "join\x00%s\x00%s\x00", join.Type, fingerprintSource(join.Source))
+		for _, cond := range join.On {
+			fmt.Fprintf(h, "on\x00%s\x00", fingerprintCondition(cond))
+		}
+	}
+	for _, src := range qb.using {
+		fmt.Fprintf(h, "using\x00%s\x00", fingerprintSource(src))
+	}
+	fmt.Fprintf(h, "alias\x00%s\x00", qb.alias)
+
+	// write set operations, with the fingerprints of combined queries
+	for _, c := range qb.compounds {
+		fmt.Fprintf(h, "compound\x00%s\x00%s\x00", c.Type, fingerprintSubquery(c.Subquery))
+	}
+
+	// write multi-row presence, the count of rows is not included
+	fmt.Fprintf(h, "rows\x00%t\x00", len(qb.rows) > 0)
+
+	// write conditions
+	for _, cond := range qb.conditions {
+		fmt.Fprintf(h, "where\x00%s\x00", fingerprintCondition(cond))
+	}
+
+	// write groups
+	for _, field := range qb.groupBy {
+		fmt.Fprintf(h, "group\x00%s\x00", fingerprintField(&field))
+	}
+	for _, cond := range qb.having {
+		fmt.Fprintf(h, "having\x00%s\x00", fingerprintCondition(cond))
+	}
+
+	// write sort columns
+	for _, sort := range qb.sort {
+		fmt.Fprintf(h, "sort\x00%s\x00%s\x00%s\x00", fingerprintField(sort.Field), sort.Type, sort.Nulls)
+	}
+
+	// write keyset pagination presence
+	fmt.Fprintf(h, "after\x00%t\x00", len(qb.after) > 0)
+
+	// write limit and offset presence
+	fmt.Fprintf(h, "%t\x00%t\x00", qb.limit > 0, qb.offset > 0)
+
+	// write locking clause
+	if qb.lock != nil {
+		fmt.Fprintf(h, "lock\x00%s\x00%s\x00%s\x00", qb.lock.Strength, strings.Join(qb.lock.Of, ","), qb.lock.Wait)
+	}
+
+	// write conflict clause
+	if qb.ignore {
+		io.WriteString(h, "ignore\x00")
+	}
+	if c := qb.conflict; c != nil {
+		fmt.Fprintf(h, "conflict\x00%s\x00%s\x00%t\x00", c.Action, c.Constraint, c.UpdateInserted)
+		for _, field := range c.Target {
+			fmt.Fprintf(h, "target\x00%s\x00", fingerprintField(&field))
+		}
+		for _, cond := range c.Where {
+			fmt.Fprintf(h, "where\x00%s\x00", fingerprintCondition(cond))
+		}
+		for _, d := range c.Updates {
+			fmt.Fprintf(h, "set\x00%s\x00", fingerprintField(d.Field))
+		}
+	}
+
+	// return fingerprint
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// fingerprintCondition returns the shape of the condition, with its values replaced by markers,
+// see Fingerprint.
+func fingerprintCondition(cond domain.Condition) string {
+	// logical condition
+	if cond.Operator == domain.OperatorAnd || cond.Operator == domain.OperatorOr {
+		// sub conditions
+		conds, _ := cond.Value.([]domain.Condition)
+
+		// sub conditions shapes
+		strs := make([]string, len(conds))
+		for i, c := range conds {
+			strs[i] = fingerprintCondition(c)
+		}
+
+		// return logical condition
+		return "(" + strings.Join(strs, " "+cond.Operator.String()+" ") + ")"
+	}
+
+	// negated condition
+	if cond.Operator == domain.OperatorNot {
+		conds, _ := cond.Value.([]domain.Condition)
+		return "NOT " + fingerprintCondition(And(conds...))
+	}
+
+	// boolean expression condition
+	if cond.Operator == domain.OperatorExpression {
+		return fingerprintField(cond.Field)
+	}
+
+	// null value condition
+	if v, ok := cond.Value.(domain.ValueType); ok && v == domain.ValueNull {
+		return fmt.Sprintf("%s %s NULL", fingerprintField(cond.Field), cond.Operator)
+	}
+
+	// column value condition
+	if field, ok := cond.Value.(*domain.Field); ok {
+		return fmt.Sprintf("%s %s %s", fingerprintField(cond.Field), cond.Operator, fingerprintField(field))
+	}
+
+	// subquery condition
+	if sub, ok := cond.Value.(domain.Subquery); ok {
+		return fmt.Sprintf("%s %s (%s)", fingerprintField(cond.Field), cond.Operator, fingerprintSubquery(sub))
+	}
+
+	// expression value condition
+	if expr, ok := cond.Value.(*domain.Expression); ok {
+		var b strings.Builder
+		writeExpressionShape(&b, expr)
+		return fmt.Sprintf("%s %s %s", fingerprintField(cond.Field), cond.Operator, b.String())
+	}
+
+	// return simple condition
+	return fmt.Sprintf("%s %s ?", fingerprintField(cond.Field), cond.Operator)
+}
+
+// fingerprintField returns the shape of the field, with the literals of its expression replaced
+// by markers, see Fingerprint.
+func fingerprintField(field *domain.Field) string {
+	// check is nil
+	if field == nil {
+		return "<nil>"
+	}
+
+	// check is computed field
+	if field.Expression == nil {
+		return field.String()
+	}
+
+	// write expression shape
+	var b strings.Builder
+	writeExpressionShape(&b, field.Expression)
+
+	// return field with alias and aggregation
+	return fmt.Sprintf("%s AS %s/%d", b.String(), field.DB, field.Aggregation)
+}
+
+// writeExpressionShape writes the shape of the expression to w, with its literals replaced by
+// markers, see Fingerprint.
+func writeExpressionShape(w io.Writer, e *domain.Expression) {
+	// check is nil
+	if e == nil {
+		io.WriteString(w, "<nil>")
+		return
+	}
+
+	// write kind and name
+	switch e.Kind {
+	case domain.ExpressionField:
+		fmt.Fprint(w, fingerprintField(e.Field))
+		return
+	case domain.ExpressionExcluded:
+		fmt.Fprintf(w, "excluded.%s", fingerprintField(e.Field))
+		return
+	case domain.ExpressionSubquery:
+		fmt.Fprintf(w, "%s(%s)", e.Name, fingerprintSubquery(e.Subquery))
+		return
+	case domain.ExpressionLiteral:
+		io.WriteString(w, "?")
+		return
+	default:
+		fmt.Fprintf(w, "%d:%s(", e.Kind, e.Name)
+	}
+
+	// write branches
+	for _, cond := range e.Whens {
+		fmt.Fprintf(w, "%s;", fingerprintCondition(cond))
+	}
+
+	// write operands
+	for _, operand := range e.Operands {
+		writeExpressionShape(w, operand)
+		io.WriteString(w, ";")
+	}
+	io.WriteString(w, ")")
+}
+
+// fingerprintSource returns the shape of the table or subquery source, see Fingerprint.
+func fingerprintSource(source domain.Source) string {
+	// check is subquery
+	if source.Subquery != nil {
+		return fmt.Sprintf("(%s) AS %s", fingerprintSubquery(source.Subquery), source.Alias)
+	}
+
+	// index hints
+	var hints string
+	for _, hint := range source.IndexHints {
+		hints += fmt.Sprintf(" %s (%s)", hint.Type, strings.Join(hint.Indexes, ","))
+	}
+
+	// return table
+	return fmt.Sprintf("%s AS %s%s", source.Table, source.Alias, hints)
+}
+
+// fingerprintSubquery returns the fingerprint of the subquery, or its type if it has no
+// fingerprint, see Fingerprint.
+func fingerprintSubquery(sub domain.Subquery) string {
+	// check is query with fingerprint
+	if sub, ok := sub.(interface{ Fingerprint() string }); ok {
+		return sub.Fingerprint()
+	}
+
+	// return subquery type
+	return fmt.Sprintf("%T", sub)
+}
@@ -0,0 +1,29 @@
+package qbr
+
+import "github.com/tyrenix/qbr/domain"
+
+// Point returns the expression of a PostGIS geography point with the given longitude and
+// latitude, which are bound as params:
+//
+//	ST_MakePoint(?, ?)::geography
+//
+// The geospatial helpers require the PostGIS extension of PostgreSQL.
+func Point(lng, lat float64) *domain.Expression {
+	return Expr("ST_MakePoint(?, ?)::geography", lng, lat)
+}
+
+// DWithin returns a condition that checks if the geography of the given field is within the
+// given distance in meters of the point, see Point:
+//
+//	ST_DWithin(field, point, meters)
+func DWithin(field *domain.Field, point *domain.Expression, meters float64) domain.Condition {
+	return Cond(Func("ST_DWithin", field, point, meters))
+}
+
+// Distance returns the expression of the distance in meters between the geography of the given
+// field and the point, see Point. Use As to select or sort by the distance:
+//
+//	qbr.Distance(LocationField, qbr.Point(lng, lat)).As("dist")
+func Distance(field *domain.Field, point *domain.Expression) *domain.Expression {
+	return Func("ST_Distance", field, point)
+}
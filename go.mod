@@ -1,3 +1,12 @@
 module github.com/tyrenix/qbr
 
 go 1.23.3
+
+require github.com/jackc/pgx/v5 v5.7.1
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	golang.org/x/crypto v0.27.0 // indirect
+	golang.org/x/text v0.18.0 // indirect
+)
@@ -0,0 +1,89 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// GroupBy adds the fields to the GROUP BY clause of the read query, for reporting queries with
+// aggregate expressions, for example:
+//
+//	qb.Select(FieldOf[Order]("user_id"), Sum(FieldOf[Order]("total")).As("spent")).
+//		GroupBy(FieldOf[Order]("user_id")).
+//		Having(Gt(Count(NewAllField()).As("orders"), 10))
+//
+// Computed fields are grouped by their expression. Groups on other than read queries are
+// rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) GroupBy(fields ...*domain.Field) *Query {
+	// add fields
+	for _, field := range fields {
+		// check is field nil
+		if field == nil {
+			return qb.addError(errors.New("nil field in GROUP BY"))
+		}
+
+		// add field
+		qb.groupBy = append(qb.groupBy, *field)
+	}
+
+	// return query
+	return qb
+}
+
+// Having adds the conditions to the HAVING clause of the read query, which filters the groups
+// of the query, see GroupBy. The conditions are joined by AND and conditions on computed fields
+// compare their expression, such as an aggregate expression, not its alias.
+//
+// Zero values are handled as by Where, so conditions with zero values are not added unless they
+// are kept by the zero value policy, see IncludeZeroValues. Conditions on other than read
+// queries are rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Having(conds ...domain.Condition) *Query {
+	// check conditions fields
+	if err := checkConditionFields(conds); err != nil {
+		return qb.addError(err)
+	}
+
+	// check zero conditions in strict mode
+	if qb.options.StrictZeroConditions {
+		if err := checkZeroConditions(qb.options.ZeroValuePolicy, conds); err != nil {
+			return qb.addError(err)
+		}
+	}
+
+	// add conditions without zero conditions
+	qb.having = append(qb.having, removeZeroCondition(qb.options.ZeroValuePolicy, conds...)...)
+
+	// return query
+	return qb
+}
+
+// GetGroupBy returns the fields of the GROUP BY clause of the query, or nil if no fields are set.
+func (qb *Query) GetGroupBy() []domain.Field {
+	return qb.groupBy
+}
+
+// GetHaving returns the conditions of the HAVING clause of the query, or nil if no conditions
+// are set.
+func (qb *Query) GetHaving() []domain.Condition {
+	return qb.having
+}
+
+// checkGroupBy checks the GROUP BY and HAVING clauses of the query.
+func (qb *Query) checkGroupBy() error {
+	switch {
+	case len(qb.groupBy) > 0 && qb.operation != domain.OperationRead:
+		return fmt.Errorf("GROUP BY on %v query", qb.operation)
+	case len(qb.having) > 0 && qb.operation != domain.OperationRead:
+		return fmt.Errorf("HAVING on %v query", qb.operation)
+	case len(qb.having) > 0 && len(qb.groupBy) == 0:
+		return fmt.Errorf("%w: group the rows to filter them by HAVING, or use Where", ErrHavingWithoutGroupBy)
+	default:
+		return nil
+	}
+}
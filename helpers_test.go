@@ -0,0 +1,204 @@
+package qbr_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// user is the model of the tests.
+type user struct {
+	ID     int64  `db:"id"`
+	Name   string `db:"name"`
+	Email  string `db:"email"`
+	Age    int    `db:"age"`
+	Status string `db:"status"`
+}
+
+// TableName returns the table of the model.
+func (user) TableName() string {
+	return "users"
+}
+
+// fakeExecutor is an executor recording the executed statements, whose statements affect the
+// given count of rows.
+type fakeExecutor struct {
+	mu       sync.Mutex
+	affected int64
+	queries  []string
+	args     [][]any
+}
+
+// ExecContext records the statement and returns its result.
+func (e *fakeExecutor) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	// record statement
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+
+	// return result
+	return fakeResult(e.affected), nil
+}
+
+// QueryContext records the statement and fails, since the executor returns no rows.
+func (e *fakeExecutor) QueryContext(_ context.Context, query string, args ...any) (*sql.Rows, error) {
+	// record statement
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+
+	// return error
+	return nil, errors.New("fake executor returns no rows")
+}
+
+// fakeResult is the result of a statement of fakeExecutor with the affected rows.
+type fakeResult int64
+
+// LastInsertId returns no id.
+func (r fakeResult) LastInsertId() (int64, error) {
+	return 0, errors.New("no last insert id")
+}
+
+// RowsAffected returns the affected rows.
+func (r fakeResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+// wantError fails the test if err is nil or its message does not contain want.
+func wantError(t *testing.T, err error, want string) {
+	t.Helper()
+
+	// check error
+	if err == nil {
+		t.Fatalf("got no error, want error containing %q", want)
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("got error %q, want error containing %q", err, want)
+	}
+}
+
+// recordingDriver is a database driver recording the statements of its connections, for tests
+// of transactions, whose statements affect no rows and return no rows.
+type recordingDriver struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+// openRecording returns a database of a new recording driver, which is closed by the cleanup
+// of the test.
+func openRecording(t *testing.T) (*sql.DB, *recordingDriver) {
+	t.Helper()
+
+	// open database
+	drv := &recordingDriver{}
+	db := sql.OpenDB(drv)
+	t.Cleanup(func() { _ = db.Close() })
+
+	// return database and driver
+	return db, drv
+}
+
+// record records the statement.
+func (d *recordingDriver) record(query string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, query)
+}
+
+// statements returns the recorded statements.
+func (d *recordingDriver) statements() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.queries...)
+}
+
+// Connect returns a connection of the driver.
+func (d *recordingDriver) Connect(context.Context) (driver.Conn, error) {
+	return recordingConn{d}, nil
+}
+
+// Driver returns the driver.
+func (d *recordingDriver) Driver() driver.Driver {
+	return recordingDriverFunc(func(string) (driver.Conn, error) { return recordingConn{d}, nil })
+}
+
+// recordingDriverFunc opens the connections of recordingDriver.
+type recordingDriverFunc func(name string) (driver.Conn, error)
+
+// Open opens a connection.
+func (f recordingDriverFunc) Open(name string) (driver.Conn, error) {
+	return f(name)
+}
+
+// recordingConn is a connection of recordingDriver.
+type recordingConn struct {
+	drv *recordingDriver
+}
+
+// Prepare is not supported, statements are executed by ExecContext and QueryContext.
+func (c recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("recording driver does not prepare statements")
+}
+
+// Close closes the connection.
+func (c recordingConn) Close() error {
+	return nil
+}
+
+// Begin records and begins a transaction.
+func (c recordingConn) Begin() (driver.Tx, error) {
+	c.drv.record("BEGIN")
+	return recordingTx(c), nil
+}
+
+// ExecContext records the statement.
+func (c recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.drv.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+// QueryContext records the statement and returns no rows.
+func (c recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.drv.record(query)
+	return recordingRows{}, nil
+}
+
+// recordingTx is a transaction of recordingConn.
+type recordingTx recordingConn
+
+// Commit records the commit.
+func (tx recordingTx) Commit() error {
+	tx.drv.record("COMMIT")
+	return nil
+}
+
+// Rollback records the rollback.
+func (tx recordingTx) Rollback() error {
+	tx.drv.record("ROLLBACK")
+	return nil
+}
+
+// recordingRows are the empty rows of recordingConn.
+type recordingRows struct{}
+
+// Columns returns no columns.
+func (recordingRows) Columns() []string {
+	return nil
+}
+
+// Close closes the rows.
+func (recordingRows) Close() error {
+	return nil
+}
+
+// Next reports the end of the rows.
+func (recordingRows) Next([]driver.Value) error {
+	return io.EOF
+}
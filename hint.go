@@ -0,0 +1,41 @@
+package qbr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hint adds an optimizer hint to the query, for example a pg_hint_plan hint:
+//
+//	qb.Hint("IndexScan(users users_email_idx)")
+//
+// The hints are rendered in a single hint comment at the head of the statement in the order
+// they were added, for example "/*+ IndexScan(users users_email_idx) */ SELECT ...". Hints
+// are not part of the params and must never contain user input; hints containing "/*" or "*/"
+// are stored as an error of the query.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Hint(text string) *Query {
+	// check is valid hint
+	text = strings.TrimSpace(text)
+	if text == "" || strings.Contains(text, "/*") || strings.Contains(text, "*/") {
+		return qb.addError(fmt.Errorf("invalid hint %q", text))
+	}
+
+	// add hint
+	qb.hints = append(qb.hints, text)
+
+	// return query
+	return qb
+}
+
+// withHints returns the query with the hint comment of the hints of the query, see Hint.
+func (qb *Query) withHints(query string) string {
+	// check is hints set
+	if len(qb.hints) == 0 {
+		return query
+	}
+
+	// return query with hint comment
+	return "/*+ " + strings.Join(qb.hints, " ") + " */ " + query
+}
@@ -0,0 +1,144 @@
+package qbr
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// BeforeWriteHook is called by Exec before an INSERT, UPDATE or DELETE statement is built
+// and executed, with the operation, the table and the model of the query.
+//
+// The hook can mutate the model, if it is a pointer to the struct set by Query.SetStruct,
+// for example to stamp the user who changed the record, the changed fields are set in the
+// query. A hook error vetoes the statement, which is not executed then.
+type BeforeWriteHook func(ctx context.Context, op domain.OperationType, table string, model any) error
+
+// AfterWriteHook is called by Exec after an INSERT, UPDATE or DELETE statement has been
+// executed successfully, with the executed statement and the number of affected rows.
+//
+// A hook error is returned by Exec, the statement has been executed then.
+type AfterWriteHook func(ctx context.Context, op domain.OperationType, table string, stmt *Statement, rowsAffected int64) error
+
+// writeHooks contains the registered write hooks.
+var writeHooks struct {
+	sync.RWMutex
+	before []BeforeWriteHook
+	after  []AfterWriteHook
+}
+
+// BeforeWrite registers a hook called before write statements are executed, see
+// BeforeWriteHook. Hooks are called in registration order, and registration is safe
+// for concurrent use.
+func BeforeWrite(hook BeforeWriteHook) {
+	writeHooks.Lock()
+	defer writeHooks.Unlock()
+	writeHooks.before = append(writeHooks.before, hook)
+}
+
+// AfterWrite registers a hook called after write statements are executed, see
+// AfterWriteHook. Hooks are called in registration order, and registration is safe
+// for concurrent use.
+func AfterWrite(hook AfterWriteHook) {
+	writeHooks.Lock()
+	defer writeHooks.Unlock()
+	writeHooks.after = append(writeHooks.after, hook)
+}
+
+// runBeforeWrite calls the before write hooks for the query.
+//
+// Returns the query with the data of the model fields changed by the hooks, or an error
+// of a hook.
+func (qb *Query) runBeforeWrite(ctx context.Context) (*Query, error) {
+	// get hooks
+	writeHooks.RLock()
+	hooks := slices.Clone(writeHooks.before)
+	writeHooks.RUnlock()
+
+	// check is write with hooks
+	if len(hooks) == 0 || qb.operation == domain.OperationRead {
+		return qb, nil
+	}
+
+	// model data before hooks
+	before, _ := extractDataFromStruct(qb.pointerModel(), qb.options)
+
+	// call hooks
+	for _, hook := range hooks {
+		if err := hook(ctx, qb.operation, qb.GetTable(), qb.model); err != nil {
+			return nil, err
+		}
+	}
+
+	// model data after hooks
+	after, _ := extractDataFromStruct(qb.pointerModel(), qb.options)
+
+	// query with changed data
+	changed := qb
+	for i, d := range after {
+		// check is value changed
+		if d.Field.Version || (i < len(before) && reflect.DeepEqual(before[i].Value, d.Value)) {
+			continue
+		}
+
+		// copy query
+		if changed == qb {
+			changed = qb.Clone()
+		}
+
+		// replace data of field
+		changed.data = slices.DeleteFunc(changed.data, func(data domain.Data) bool {
+			return data.Field.DB == d.Field.DB
+		})
+		changed.Set(d)
+	}
+
+	// return query
+	return changed, nil
+}
+
+// afterWriteHooks returns the after write hooks to call for the query.
+func (qb *Query) afterWriteHooks() []AfterWriteHook {
+	// check is write
+	if qb.operation == domain.OperationRead {
+		return nil
+	}
+
+	// get hooks
+	writeHooks.RLock()
+	defer writeHooks.RUnlock()
+	return slices.Clone(writeHooks.after)
+}
+
+// runAfterWrite calls the given after write hooks for the executed statement of the query.
+//
+// Returns the first error of a hook.
+func (qb *Query) runAfterWrite(ctx context.Context, hooks []AfterWriteHook, stmt *Statement, rowsAffected int64) error {
+	// call hooks
+	for _, hook := range hooks {
+		if err := hook(ctx, qb.operation, qb.GetTable(), stmt, rowsAffected); err != nil {
+			return err
+		}
+	}
+
+	// return success
+	return nil
+}
+
+// pointerModel returns the model of the query if it is a non-nil pointer to a struct,
+// which can be mutated by hooks, or nil otherwise.
+func (qb *Query) pointerModel() any {
+	// model value
+	v := reflect.ValueOf(qb.model)
+
+	// check is pointer to struct
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	// return model
+	return qb.model
+}
@@ -0,0 +1,241 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// columnNamePattern matches the column names allowed in queries, optionally qualified by
+// a table and a schema.
+var columnNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*){0,2}$`)
+
+// UnsafeIdent returns a FieldOption that sets the DB field name of a Field model without the
+// identifier validation of Validate, for names which are neither safe identifiers nor columns
+// of the model, for example quoted names:
+//
+//	qbr.NewField(qbr.UnsafeIdent(`"Order Date"`))
+//
+// The name is interpolated in the SQL as it is, without quoting (see ForceQuote), so it must
+// never be taken from user input.
+func UnsafeIdent(name string) FieldOption {
+	return func(f *domain.Field) {
+		f.DB = name
+		f.Unsafe = true
+	}
+}
+
+// UnsafeTable sets the table of the query without the identifier validation of Validate, see
+// Table and UnsafeIdent. The table prefix is added to it as to other tables, but it is not
+// quoted, see ForceQuote.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) UnsafeTable(table string) *Query {
+	// set table
+	qb.table = table
+	qb.unsafeTable = true
+
+	// return query
+	return qb
+}
+
+// checkIdentifiers checks that the identifiers of the query are safe to interpolate in the
+// SQL: the column names of its fields must be valid identifiers, optionally qualified, the
+// columns of the model of the query, or set by UnsafeIdent. The tables are checked when
+// they are resolved, see checkTable.
+//
+// Returns an error for every unsafe identifier.
+func (qb *Query) checkIdentifiers() error {
+	// model columns, extracted on the first column name not matching the pattern
+	var columns []string
+	var modelChecked bool
+
+	// check fields
+	var errs []error
+	var checked []string
+	for _, field := range qb.queryFields() {
+		// check is field validated
+		if field == nil || field.Unsafe {
+			continue
+		}
+
+		// check column names
+		names := []string{field.DB}
+		for _, op := range sortedKeys(field.Columns) {
+			names = append(names, field.Columns[op])
+		}
+		for _, name := range names {
+			// check is safe identifier
+			if name == "" || name == "*" || columnNamePattern.MatchString(name) || slices.Contains(checked, name) {
+				continue
+			}
+
+			// check is model column
+			if !modelChecked {
+				columns, modelChecked = qb.modelColumns(), true
+			}
+			if slices.Contains(columns, name) {
+				continue
+			}
+
+			// add unsafe identifier error
+			checked = append(checked, name)
+			errs = append(errs, fmt.Errorf("%w: column %q", ErrUnsafeIdentifier, name))
+		}
+	}
+
+	// return errors
+	return errors.Join(errs...)
+}
+
+// checkTable checks that the resolved table of the query is safe to interpolate in the SQL,
+// which is a valid identifier optionally qualified by a schema, or set by UnsafeTable.
+func (qb *Query) checkTable(table string) error {
+	// check is table validated
+	if qb.isTableUnsafe() || tableNamePattern.MatchString(table) {
+		return nil
+	}
+
+	// return unsafe identifier error
+	return fmt.Errorf("%w: table %q", ErrUnsafeIdentifier, table)
+}
+
+// isTableUnsafe checks if the table of the query is set by UnsafeTable, and not overridden
+// by a table function.
+func (qb *Query) isTableUnsafe() bool {
+	return qb.unsafeTable && qb.tableFunc == nil
+}
+
+// modelColumns returns the column names of the fields of the model of the query, or nil
+// if the query has no struct model or its fields could not be extracted.
+func (qb *Query) modelColumns() []string {
+	// model type
+	t := structTypeOf(qb.model)
+	if t == nil {
+		return nil
+	}
+
+	// extract fields
+	fields, err := extractModelFields(t, qb.options)
+	if err != nil {
+		return nil
+	}
+
+	// column names
+	var columns []string
+	for _, field := range fields {
+		if field == nil {
+			continue
+		}
+		columns = append(columns, field.DB)
+		for _, op := range sortedKeys(field.Columns) {
+			columns = append(columns, field.Columns[op])
+		}
+	}
+
+	// return columns
+	return columns
+}
+
+// queryFields returns the fields of the query which are rendered in its SQL, including the
+// fields of expressions: the selected fields, the fields of the conditions, join conditions,
+// sort parameters, data and the conflict clause.
+func (qb *Query) queryFields() []*domain.Field {
+	var fields []*domain.Field
+
+	// add field and the fields of its expression
+	addField := func(field *domain.Field) {
+		if field == nil {
+			return
+		}
+		fields = append(fields, field)
+		if field.Expression != nil {
+			fields = append(fields, field.Expression.Fields()...)
+		}
+	}
+
+	// add the fields of a value, if it is an expression or a field
+	addValue := func(value any) {
+		switch v := value.(type) {
+		case *domain.Expression:
+			if v != nil {
+				fields = append(fields, v.Fields()...)
+			}
+		case *domain.Field:
+			addField(v)
+		}
+	}
+
+	// add the fields of conditions
+	var addConditions func(conds []domain.Condition)
+	addConditions = func(conds []domain.Condition) {
+		for _, cond := range conds {
+			if sub, ok := cond.Value.([]domain.Condition); ok {
+				addConditions(sub)
+				continue
+			}
+			addField(cond.Field)
+			addValue(cond.Value)
+		}
+	}
+
+	// selected fields
+	for i := range qb.selects {
+		addField(&qb.selects[i])
+	}
+
+	// conditions
+	addConditions(qb.conditions)
+
+	// join conditions
+	for _, join := range qb.joins {
+		addConditions(join.On)
+	}
+
+	// inserted columns of selected rows
+	if qb.insertSelect != nil {
+		for i := range qb.insertSelect.Columns {
+			addField(&qb.insertSelect.Columns[i])
+		}
+	}
+
+	// distinct fields
+	for i := range qb.distinctOn {
+		addField(&qb.distinctOn[i])
+	}
+
+	// groups
+	for i := range qb.groupBy {
+		addField(&qb.groupBy[i])
+	}
+	addConditions(qb.having)
+
+	// sort parameters
+	for _, sort := range qb.sort {
+		addField(sort.Field)
+	}
+
+	// data
+	for _, d := range qb.data {
+		addField(d.Field)
+		addValue(d.Value)
+	}
+
+	// conflict clause
+	if c := qb.conflict; c != nil {
+		for i := range c.Target {
+			addField(&c.Target[i])
+		}
+		addConditions(c.Where)
+		for _, d := range c.Updates {
+			addField(d.Field)
+			addValue(d.Value)
+		}
+	}
+
+	// return fields
+	return fields
+}
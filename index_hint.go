@@ -0,0 +1,124 @@
+package qbr
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// indexNamePattern matches the index names allowed in index hints.
+var indexNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// UseIndex adds a USE INDEX hint of the given indexes to the table of the read query, which
+// makes the optimizer of MySQL choose among these indexes only, for example:
+//
+//	qb.UseIndex("idx_created_at") // FROM orders USE INDEX (idx_created_at)
+//
+// Index hints are part of the table reference, so they are rendered after the table and its
+// alias, see Alias. Use the UseIndex function for joined tables. Index hints are specific to
+// MySQL, queries with index hints fail to build in the other dialects, and index hints on
+// other than read queries are rejected by Validate. Invalid index names are stored as an error
+// of the query, with ErrUnsafeIdentifier.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) UseIndex(indexes ...string) *Query {
+	return qb.addIndexHint(domain.IndexUse, indexes)
+}
+
+// ForceIndex adds a FORCE INDEX hint of the given indexes to the table of the read query, which
+// makes the optimizer of MySQL use a table scan only if none of the indexes can be used, see
+// UseIndex.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) ForceIndex(indexes ...string) *Query {
+	return qb.addIndexHint(domain.IndexForce, indexes)
+}
+
+// IgnoreIndex adds an IGNORE INDEX hint of the given indexes to the table of the read query,
+// which makes the optimizer of MySQL not use these indexes, see UseIndex.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) IgnoreIndex(indexes ...string) *Query {
+	return qb.addIndexHint(domain.IndexIgnore, indexes)
+}
+
+// GetIndexHints returns the index hints of the table of the query, or nil if no index hints
+// are set, see UseIndex.
+func (qb *Query) GetIndexHints() []domain.IndexHint {
+	return qb.indexHints
+}
+
+// UseIndex returns the source of the table or of its source with an alias (see As) with a
+// USE INDEX hint of the given indexes, for joined tables, for example:
+//
+//	qb.Join(UseIndex(As("orders", "o"), "idx_user_id"), on) // JOIN orders AS o USE INDEX (idx_user_id)
+//
+// Index hints of subqueries and invalid index names are reported by the join, see
+// Query.UseIndex.
+func UseIndex(source any, indexes ...string) domain.Source {
+	return withIndexHint(source, domain.IndexUse, indexes)
+}
+
+// ForceIndex returns the source of the table with a FORCE INDEX hint of the given indexes, see
+// UseIndex.
+func ForceIndex(source any, indexes ...string) domain.Source {
+	return withIndexHint(source, domain.IndexForce, indexes)
+}
+
+// IgnoreIndex returns the source of the table with an IGNORE INDEX hint of the given indexes,
+// see UseIndex.
+func IgnoreIndex(source any, indexes ...string) domain.Source {
+	return withIndexHint(source, domain.IndexIgnore, indexes)
+}
+
+// withIndexHint returns the source with the index hint of the given type, see UseIndex.
+func withIndexHint(source any, t domain.IndexHintType, indexes []string) domain.Source {
+	// source with alias
+	src, ok := source.(domain.Source)
+	if !ok {
+		src = As(source, "")
+	}
+
+	// add index hint
+	src.IndexHints = append(slices.Clone(src.IndexHints), domain.IndexHint{Type: t, Indexes: slices.Clone(indexes)})
+
+	// return source
+	return src
+}
+
+// addIndexHint adds the index hint of the given type to the table of the query, see UseIndex.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) addIndexHint(t domain.IndexHintType, indexes []string) *Query {
+	// check index hint
+	hint := domain.IndexHint{Type: t, Indexes: slices.Clone(indexes)}
+	if err := checkIndexHint(hint); err != nil {
+		return qb.addError(err)
+	}
+
+	// add index hint
+	qb.indexHints = append(qb.indexHints, hint)
+
+	// return query
+	return qb
+}
+
+// checkIndexHint checks the indexes of the index hint, which must be valid identifiers.
+func checkIndexHint(hint domain.IndexHint) error {
+	// check is index set
+	if len(hint.Indexes) == 0 {
+		return fmt.Errorf("%s without indexes", hint.Type)
+	}
+
+	// check indexes
+	for _, index := range hint.Indexes {
+		if !indexNamePattern.MatchString(index) {
+			return fmt.Errorf("%w: %s %q", ErrUnsafeIdentifier, hint.Type, index)
+		}
+	}
+
+	// return success
+	return nil
+}
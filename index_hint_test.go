@@ -0,0 +1,69 @@
+package qbr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestIndexHints(t *testing.T) {
+	// read of users in dialect
+	read := func(d domain.Dialect) *qbr.Query {
+		return qbr.NewRead(qbr.WithDialect(d)).Model(user{}).Alias("u").ForceIndex("idx_status", "idx_age").IgnoreIndex("idx_name")
+	}
+
+	t.Run("table", func(t *testing.T) {
+		qbrtest.Golden(t, read(qbr.MySQL).Where(qbr.Eq(qbr.Qualify("u", qbr.FieldOf[user]("status")), "active")))
+	})
+
+	t.Run("join", func(t *testing.T) {
+		on := qbr.Eq(qbr.NewField(qbr.WithDB("o.user_id")), qbr.NewField(qbr.WithDB("u.id")))
+		qbrtest.Golden(t, qbr.NewRead(qbr.WithDialect(qbr.MySQL)).Model(user{}).Alias("u").
+			Join(qbr.UseIndex(qbr.As("orders", "o"), "idx_user_id"), on))
+	})
+
+	t.Run("other dialects", func(t *testing.T) {
+		for _, d := range []domain.Dialect{qbr.Postgres, qbr.SQLite, qbr.SQLServer, qbr.Oracle, qbr.DB2} {
+			_, err := read(d).Build()
+			wantError(t, err, "FORCE INDEX is not supported by the "+d.Name()+" dialect")
+		}
+	})
+
+	t.Run("invalid index", func(t *testing.T) {
+		_, err := read(qbr.MySQL).UseIndex("idx; DROP TABLE users").Build()
+		if !errors.Is(err, qbr.ErrUnsafeIdentifier) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrUnsafeIdentifier)
+		}
+		_, err = read(qbr.MySQL).UseIndex().Build()
+		wantError(t, err, "USE INDEX without indexes")
+	})
+
+	t.Run("subquery", func(t *testing.T) {
+		sub := qbr.NewRead(qbr.WithDialect(qbr.MySQL)).Model(user{})
+		_, err := qbr.NewRead(qbr.WithDialect(qbr.MySQL)).Model(user{}).
+			Join(qbr.UseIndex(qbr.As(sub, "s"), "idx_age"), qbr.Eq(qbr.NewField(qbr.WithDB("s.id")), qbr.NewField(qbr.WithDB("users.id")))).Build()
+		wantError(t, err, "of subquery with index hints")
+	})
+
+	t.Run("update query", func(t *testing.T) {
+		_, err := qbr.NewUpdate(qbr.WithDialect(qbr.MySQL)).Table("users").UseIndex("idx_age").
+			Set(qbr.NewData(qbr.NewField(qbr.WithDB("name")), "ann")).Where(qbr.Eq(qbr.NewField(qbr.WithDB("id")), 1)).Build()
+		if !errors.Is(err, qbr.ErrInvalidJoin) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrInvalidJoin)
+		}
+	})
+
+	t.Run("clone and fingerprint", func(t *testing.T) {
+		qb := read(qbr.MySQL)
+		clone := qb.Clone().UseIndex("idx_email")
+		if qb.Fingerprint() == clone.Fingerprint() {
+			t.Fatal("fingerprint ignores index hints")
+		}
+		if len(qb.GetIndexHints()) != 2 {
+			t.Fatalf("clone changed index hints of the query: %+v", qb.GetIndexHints())
+		}
+	})
+}
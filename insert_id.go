@@ -0,0 +1,125 @@
+package qbr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// InsertGetID inserts the given struct, or the struct pointed to by model, into the table of T,
+// as an insert query with SetStruct, and returns the integer ID generated by the database for
+// the primary key, see InsertGetKey.
+func InsertGetID[T any](ctx context.Context, exec Executor, model T, opts ...Option) (int64, error) {
+	return InsertGetKey[int64](ctx, exec, model, opts...)
+}
+
+// InsertGetKey inserts the given struct, or the struct pointed to by model, into the table of
+// T, as an insert query with SetStruct, and returns the key of the type K generated by the
+// database for the primary key, for example a UUID. The write hooks are called as by Exec.
+//
+// The primary key is the field annotated with "pk", which must be annotated with "generated"
+// too, for example:
+//
+//	ID int64 `db:"id" qbr:"pk generated"`
+//
+// The key is returned by a RETURNING clause of the primary key column.
+//
+// Returns the key, or an error if T has no primary key, a composite or not generated primary
+// key, or the query could not be built, executed or scanned.
+func InsertGetKey[K any, T any](ctx context.Context, exec Executor, model T, opts ...Option) (K, error) {
+	var key K
+
+	// options
+	o, err := newOptions(opts...)
+	if err != nil {
+		return key, err
+	}
+
+	// find generated primary key
+	pk, err := generatedKey(structTypeOf(model), o)
+	if err != nil {
+		return key, err
+	}
+
+	// call before write hooks
+	q, err := NewCreate(opts...).SetStruct(model).Select(pk).runBeforeWrite(ctx)
+	if err != nil {
+		return key, err
+	}
+
+	// build query
+	stmt, err := q.BuildContext(ctx)
+	if err != nil {
+		return key, err
+	}
+
+	// execute query
+	rows, err := q.queryContext(ctx, exec, stmt)
+	if err != nil {
+		return key, err
+	}
+
+	// close rows
+	defer rows.Close()
+
+	// check returned row
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return key, err
+		}
+		return key, sql.ErrNoRows
+	}
+
+	// scan key
+	if err := rows.Scan(&key); err != nil {
+		return key, err
+	}
+	if err := rows.Close(); err != nil {
+		return key, err
+	}
+
+	// call after write hooks
+	return key, q.runAfterWrite(ctx, q.afterWriteHooks(), stmt, 1)
+}
+
+// generatedKey returns the field of the generated primary key of the struct type t.
+//
+// Returns an error if t is not a struct type, or has no primary key, a composite or not
+// generated primary key.
+func generatedKey(t reflect.Type, o Options) (*domain.Field, error) {
+	// check is struct
+	if t == nil {
+		return nil, errors.New("InsertGetKey requires a struct model")
+	}
+
+	// extract fields
+	fields, err := extractModelFields(t, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// primary key fields
+	var pk []*domain.Field
+	for _, field := range fields {
+		if field != nil && field.PK {
+			pk = append(pk, field)
+		}
+	}
+
+	// check primary key
+	switch {
+	case len(pk) == 0:
+		return nil, fmt.Errorf("%v has no %s annotated field", t, domain.QueryPK)
+	case len(pk) > 1:
+		return nil, fmt.Errorf("%v has a composite primary key", t)
+	case !pk[0].Generated:
+		return nil, fmt.Errorf("primary key %s of %v is not %s", pk[0].DB, t, domain.QueryGen)
+	}
+
+	// return primary key
+	return pk[0], nil
+}
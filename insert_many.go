@@ -0,0 +1,130 @@
+package qbr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// maxBindParams is the maximum number of bind parameters of a statement, which is the
+// limit of PostgreSQL.
+const maxBindParams = 65535
+
+// ChunkError is returned by InsertManyChunked if a chunk could not be inserted.
+type ChunkError struct {
+	Chunk int   // Index of the chunk.
+	Row   int   // Index of the failed row in the inserted rows, or of the first row of the chunk.
+	Err   error // Error of the chunk.
+}
+
+// Error returns the error message.
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("insert chunk %d at row %d: %v", e.Chunk, e.Row, e.Err)
+}
+
+// Unwrap returns the error of the chunk.
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// InsertManyChunked inserts the given rows into the table of T with one multi-row INSERT
+// statement per chunk of chunkSize rows, and returns the total number of affected rows.
+//
+// All the fields of T which are not ignored for create operations are inserted, including
+// zero values, so the rows of a statement share their columns. If chunkSize is zero or
+// negative, the largest chunk size which keeps the number of bind parameters of a statement
+// within the limit of PostgreSQL is used. The chunks are executed using the given executor,
+// pass a *sql.Tx to insert all chunks in a single transaction. Rows which conflict with
+// existing rows are skipped with the WithSkipConflicts option, the skipped rows are the
+// rows which are not affected then.
+//
+// Returns the affected rows of the inserted chunks, and a *ChunkError if a chunk could not
+// be built or executed; the following chunks are not executed then.
+func InsertManyChunked[T any](ctx context.Context, exec Executor, rows []T, chunkSize int, opts ...Option) (int64, error) {
+	// options
+	o, err := newOptions(opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	// compute chunk size
+	chunkSize = defaultChunkSize[T](chunkSize, o)
+
+	// create rows
+	insert, err := NewInsertRows(ctx, rows, opts...)
+	if err != nil {
+		// check is row failed
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			return 0, &ChunkError{Chunk: rowErr.Row / chunkSize, Row: rowErr.Row, Err: err}
+		}
+		return 0, err
+	}
+
+	// conflict clause
+	var conflict *domain.Conflict
+	if o.SkipConflicts {
+		conflict = &domain.Conflict{Action: domain.ConflictDoNothing}
+	}
+
+	// affected rows
+	var total int64
+
+	// insert chunks
+	for chunk, start := 0, 0; start < len(insert.Values); chunk, start = chunk+1, start+chunkSize {
+		// chunk rows
+		end := min(start+chunkSize, len(insert.Values))
+
+		// build statement
+		query, params, err := sqlbuilder.CreateInsertManySql(insert.Table, insert.Columns, insert.Values[start:end], conflict, nil, o.sqlDialect(o.Placeholder))
+		if err != nil {
+			return total, &ChunkError{Chunk: chunk, Row: start, Err: err}
+		}
+
+		// statement
+		stmt := &Statement{SQL: query, Params: bindParams(params, o), Placeholder: o.Placeholder}
+
+		// execute statement
+		result, err := exec.ExecContext(ctx, stmt.SQL, stmt.Params...)
+
+		// log statement
+		if o.Logger != nil {
+			o.Logger.LogQuery(ctx, stmt, err)
+		}
+
+		// check is statement failed
+		if err != nil {
+			return total, &ChunkError{Chunk: chunk, Row: start, Err: err}
+		}
+
+		// add affected rows
+		n, err := result.RowsAffected()
+		if err != nil {
+			return total, &ChunkError{Chunk: chunk, Row: start, Err: err}
+		}
+		total += n
+	}
+
+	// return affected rows
+	return total, nil
+}
+
+// defaultChunkSize returns the given chunk size, or if it is zero or negative, the largest
+// chunk size of rows of T which keeps the number of bind parameters of a statement within
+// the limit of PostgreSQL.
+func defaultChunkSize[T any](chunkSize int, o Options) int {
+	// check is chunk size set
+	if chunkSize > 0 {
+		return chunkSize
+	}
+
+	// compute chunk size
+	if fields, err := extractModelFields(reflect.TypeFor[T](), o); err == nil && len(fields) > 0 {
+		return max(maxBindParams/len(fields), 1)
+	}
+	return maxBindParams
+}
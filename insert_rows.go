@@ -0,0 +1,136 @@
+package qbr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// RowError is an error of a value of a row, naming the index of the row and its column.
+type RowError = sqlbuilder.RowError
+
+// InsertRows contains the rows of a model to insert into its table, for bulk loading
+// integrations such as PostgreSQL COPY.
+type InsertRows struct {
+	Table   string   // Table of the model, with the table prefix.
+	Columns []string // Inserted columns.
+	Values  [][]any  // Database values of the rows, aligned with Columns.
+}
+
+// NewInsertRows returns the rows to insert for the given models of the struct type T.
+//
+// All the fields of T which are not ignored for create operations are inserted, including
+// zero values, so the rows share their columns. The table is resolved as for queries with
+// the given options (see Query.TableFunc), the tenant column is set to the tenant of the
+// context (see TenantProvider), zero values of fields with a client-side default, such
+// as "default=new_uuid", are set to a new default, and times are converted to the time
+// zone of the options, see WithTimeZone.
+//
+// Returns the rows, or an error if they could not be created, which is a *RowError if a value
+// of a row could not be converted to a database value.
+func NewInsertRows[T any](ctx context.Context, models []T, opts ...Option) (*InsertRows, error) {
+	// query of the model
+	qb := New(domain.OperationCreate, opts...).Model(*new(T))
+
+	// check query errors
+	if qb.err != nil {
+		return nil, qb.err
+	}
+
+	// struct type
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported model type: %v", t)
+	}
+
+	// extract fields, including the fields of embedded and nested structs
+	fields, err := modelFields(t, qb.options)
+	if err != nil {
+		return nil, err
+	}
+
+	// inserted fields
+	rows := &InsertRows{}
+	var inserted []structField
+	for _, field := range fields {
+		if !isFieldIgnored(field.field, domain.OperationCreate) {
+			inserted = append(inserted, field)
+			rows.Columns = append(rows.Columns, field.field.Column(domain.OperationCreate))
+		}
+	}
+
+	// check columns
+	if len(rows.Columns) == 0 {
+		return nil, ErrEmptyInsert
+	}
+
+	// resolve table
+	table, err := qb.resolveTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if table == "" {
+		return nil, errors.New("missing table for create query")
+	}
+	if err := qb.checkTable(table); err != nil {
+		return nil, err
+	}
+	rows.Table = qb.prefixTable(table)
+
+	// tenant of rows
+	var tenant any
+	tenantIndex := -1
+	if field := qb.tenantField(); field != nil && !qb.tenantUnscoped {
+		if tenant = qb.options.Tenant(ctx); tenant == nil {
+			return nil, ErrMissingTenant
+		}
+		for i, field := range inserted {
+			if field.field.Tenant {
+				tenantIndex = i
+			}
+		}
+	}
+
+	// rows values
+	rows.Values = make([][]any, len(models))
+	for i, model := range models {
+		// model value
+		v := reflect.ValueOf(model)
+
+		// row values
+		values := make([]any, len(inserted))
+		for j, field := range inserted {
+			// field value, unset optionals and fields of nil nested structs are inserted as NULL
+			var value any
+			if fv, ok := valueByIndex(v, field.index); ok {
+				value, _, _ = resolveOptional(fv.Interface())
+			}
+			if j == tenantIndex {
+				value = tenant
+			}
+
+			// create client-side default
+			if needsDefault(field.field, value) {
+				if value, err = newDefault(value); err != nil {
+					return nil, &RowError{Row: i, Column: rows.Columns[j], Err: err}
+				}
+			}
+
+			// create database value
+			if values[j], err = sqlbuilder.FieldValue(field.field, value); err != nil {
+				return nil, &RowError{Row: i, Column: rows.Columns[j], Err: err}
+			}
+			values[j] = inTimeZone(values[j], qb.options.TimeZone)
+		}
+
+		// set row
+		rows.Values[i] = values
+	}
+
+	// return rows
+	return rows, nil
+}
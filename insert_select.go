@@ -0,0 +1,66 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// InsertFromSelect creates a create query inserting the rows selected by the read subquery into
+// the given columns of the table, for example for archiving rows:
+//
+//	old := New("read").Model(Order{}).Select(id, total).Where(Lt(createdAt, cutoff))
+//	qb := InsertFromSelect("orders_archive", []string{"id", "total"}, old)
+//
+// The subquery is a read *Query or *TypedQuery selecting a column for every inserted column, in
+// their order, and its params are bound in the statement. If no columns are given, the rows are
+// inserted into all the columns of the table in their order. The table prefix is added to the
+// table (see WithTablePrefix), and the query can have conflict and returning clauses, such as
+// OnConflict and Returning, but no values set by Set or the other setters of create queries.
+//
+// In SQLite the subquery of a query with a conflict clause must have a WHERE clause, for example
+// Where(Cond(Expr("true"))), so ON CONFLICT is not parsed as a join constraint.
+//
+// Returns the created query builder.
+func InsertFromSelect(table string, columns []string, sub domain.Subquery, opts ...Option) *Query {
+	// create query
+	qb := New(domain.OperationCreate, opts...).Table(table)
+
+	// check is subquery nil
+	if sub == nil {
+		return qb.addError(errors.New("INSERT ... SELECT without subquery"))
+	}
+
+	// inserted columns
+	fields := make([]domain.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = *NewField(WithDB(column))
+	}
+
+	// set selected rows
+	qb.insertSelect = &domain.InsertSelect{Columns: fields, Subquery: sub}
+
+	// return query
+	return qb
+}
+
+// GetInsertSelect returns the selected rows of the insert, or nil if the query does not insert
+// the rows of a subquery, see InsertFromSelect.
+func (qb *Query) GetInsertSelect() *domain.InsertSelect {
+	return qb.insertSelect
+}
+
+// checkInsertSelect checks the selected rows of the insert of the query.
+func (qb *Query) checkInsertSelect() error {
+	switch {
+	case qb.insertSelect == nil:
+		return nil
+	case qb.operation != domain.OperationCreate:
+		return fmt.Errorf("INSERT ... SELECT on %v query", qb.operation)
+	case len(qb.data) > 0 || len(qb.rows) > 0:
+		return errors.New("INSERT ... SELECT with values")
+	default:
+		return nil
+	}
+}
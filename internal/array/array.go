@@ -0,0 +1,253 @@
+// Package array binds and scans Go slices as PostgreSQL arrays for database/sql drivers
+// which do not support slices natively.
+package array
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Array is a slice bound and scanned in the PostgreSQL array text format, for example
+// "{1,2,3}" or "{\"a b\",c}". Only one-dimensional arrays of strings, integers, floats
+// and booleans are supported.
+type Array struct {
+	V any // Slice for binding, or pointer to slice for scanning.
+}
+
+// IsArray checks if the value is a slice which is bound as an array. Byte slices are
+// bound as is, since they are binary values.
+func IsArray(value any) bool {
+	// value type
+	t := reflect.TypeOf(value)
+
+	// check is slice of supported elements
+	return t != nil && t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 && isSupportedKind(t.Elem().Kind())
+}
+
+// Value returns the array in the text format, or nil for a nil slice.
+func (a Array) Value() (driver.Value, error) {
+	// slice value
+	v := reflect.ValueOf(a.V)
+
+	// check is slice
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("unsupported array type: %T", a.V)
+	}
+
+	// check is nil
+	if v.IsNil() {
+		return nil, nil
+	}
+
+	// array string
+	var b strings.Builder
+	b.WriteByte('{')
+
+	// write elements
+	for i := range v.Len() {
+		// write separator
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		// write element
+		if err := writeElement(&b, v.Index(i)); err != nil {
+			return nil, err
+		}
+	}
+
+	// return array
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan scans the array in the text format into the slice pointed to by V.
+func (a *Array) Scan(src any) error {
+	// slice value
+	v := reflect.ValueOf(a.V)
+
+	// check is pointer to slice
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("unsupported array destination: %T", a.V)
+	}
+	v = v.Elem()
+
+	// source string
+	var s string
+	switch src := src.(type) {
+	case nil:
+		v.SetZero()
+		return nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return fmt.Errorf("unsupported array source: %T", src)
+	}
+
+	// parse elements
+	elements, err := parse(s)
+	if err != nil {
+		return err
+	}
+
+	// set elements
+	result := reflect.MakeSlice(v.Type(), len(elements), len(elements))
+	for i, element := range elements {
+		if element == nil {
+			continue
+		}
+		if err := setElement(result.Index(i), *element); err != nil {
+			return fmt.Errorf("array element %d: %w", i, err)
+		}
+	}
+
+	// set slice
+	v.Set(result)
+	return nil
+}
+
+// isSupportedKind checks if the kind is a supported element kind.
+func isSupportedKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeElement writes the element in the array text format.
+func writeElement(b *strings.Builder, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		// quote string
+		b.WriteByte('"')
+		for _, r := range v.String() {
+			if r == '"' || r == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+		b.WriteByte('"')
+	case reflect.Bool:
+		b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	default:
+		return fmt.Errorf("unsupported array element type: %v", v.Type())
+	}
+
+	// return success
+	return nil
+}
+
+// setElement parses the element of the array text format into v.
+func setElement(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		v.SetBool(s == "t" || s == "true")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported array element type: %v", v.Type())
+	}
+
+	// return success
+	return nil
+}
+
+// parse parses the elements of a one-dimensional array in the text format, NULL
+// elements are nil.
+func parse(s string) ([]*string, error) {
+	// check braces
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("invalid array %q", s)
+	}
+	s = s[1 : len(s)-1]
+
+	// check is empty
+	if s == "" {
+		return []*string{}, nil
+	}
+
+	// elements
+	var elements []*string
+
+	// parse elements
+	for i := 0; i <= len(s); {
+		// element
+		var b strings.Builder
+		quoted := false
+
+		// parse quoted element
+		if i < len(s) && s[i] == '"' {
+			quoted = true
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				b.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, errors.New("unterminated quoted array element")
+			}
+			i++
+		} else {
+			// parse unquoted element
+			for ; i < len(s) && s[i] != ','; i++ {
+				if s[i] == '{' {
+					return nil, errors.New("multidimensional arrays are not supported")
+				}
+				b.WriteByte(s[i])
+			}
+		}
+
+		// add element
+		element := b.String()
+		if !quoted && strings.EqualFold(element, "NULL") {
+			elements = append(elements, nil)
+		} else {
+			elements = append(elements, &element)
+		}
+
+		// check separator
+		if i < len(s) && s[i] != ',' {
+			return nil, fmt.Errorf("invalid array element separator %q", s[i])
+		}
+		i++
+	}
+
+	// return elements
+	return elements, nil
+}
@@ -0,0 +1,55 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// buildCompounds translates the set operations to a SQL string and its params, for example
+// "UNION ALL (SELECT ...)". The combined queries are parenthesized, except in SQLite, and
+// EXCEPT is rendered as MINUS in Oracle. The params of the queries are appended in their order.
+// It returns the SQL string, the updated parameter slice, and an error if any.
+func buildCompounds(compounds []domain.Compound, d domain.Dialect, params []any) (string, []any, error) {
+	// set operation clauses
+	clauses := make([]string, len(compounds))
+
+	// create set operations
+	for i, c := range compounds {
+		// create query
+		sub, subParams, err := c.Subquery.BuildSubquery(d, params)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s query %d: %w", c.Type, i+1, err)
+		}
+		params = subParams
+
+		// parenthesize query
+		if parenthesizesCompounds(d) {
+			sub = "(" + sub + ")"
+		}
+
+		// add set operation
+		clauses[i] = compoundKeyword(c.Type, d) + " " + sub
+	}
+
+	// return set operations
+	return strings.Join(clauses, " "), params, nil
+}
+
+// compoundKeyword returns the keyword of the set operation in the dialect, which is MINUS
+// instead of EXCEPT in Oracle.
+func compoundKeyword(t domain.CompoundType, d domain.Dialect) string {
+	qd, ok := builtinDialect(d)
+	if ok && qd.minus && t == domain.CompoundExcept {
+		return "MINUS"
+	}
+	return string(t)
+}
+
+// parenthesizesCompounds checks if the queries of set operations are parenthesized, which is
+// not supported by SQLite.
+func parenthesizesCompounds(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return !ok || !qd.noCompoundParens
+}
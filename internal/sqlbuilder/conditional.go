@@ -8,13 +8,15 @@ import (
 )
 
 // buildConditions translates a condition slice to a SQL query string and its params.
-// plc is the placeholder character to use, and params is the parameter slice to append to.
+// op is the operation of the enclosing statement used to resolve the field names,
+// d is the dialect of the placeholders and identifiers, and params is the parameter slice to append to.
 // join is the operator to use to join the condition strings, default is "AND".
 // It returns the query string, the updated parameter slice, and an error if any.
-func buildConditions(conds []domain.Condition, plc domain.SqlPlaceholder, params []any, join ...string) (string, []any, error) {
-	// check check conditions count
+func buildConditions(conds []domain.Condition, op domain.OperationType, d domain.Dialect, params []any, join ...string) (string, []any, error) {
+	// check check conditions count, without empty logical conditions
+	conds = domain.PruneConditions(conds)
 	if len(conds) == 0 {
-		return "", nil, nil
+		return "", params, nil
 	}
 
 	// condition strings
@@ -31,18 +33,41 @@ func buildConditions(conds []domain.Condition, plc domain.SqlPlaceholder, params
 		switch cond.Operator {
 		case domain.OperatorAnd, domain.OperatorOr: // for logical operator: OR, AND
 			// create sub query and params
-			subQuery, subParams, err := handleLogicalCondition(cond, params, plc, cond.Operator)
+			subQuery, subParams, err := handleLogicalCondition(cond, op, params, d, cond.Operator)
 			if err != nil {
 				return "", nil, err
 			}
 
+			// add sub params
+			params = subParams
+
+			// check is sub query is empty
+			if subQuery == "" {
+				continue
+			}
+
 			// add sub query
 			condStrs = append(condStrs, fmt.Sprintf("(%s)", subQuery))
+		case domain.OperatorNot: // for negation: NOT
+			// create negated sub query and params
+			subQuery, subParams, err := handleLogicalCondition(cond, op, params, d, cond.Operator)
+			if err != nil {
+				return "", nil, err
+			}
+
 			// add sub params
 			params = subParams
+
+			// check is sub query is empty
+			if subQuery == "" {
+				continue
+			}
+
+			// add negated sub query
+			condStrs = append(condStrs, fmt.Sprintf("NOT (%s)", subQuery))
 		default: // for simple operator, >, <, <=, and so on
 			// create condition
-			conditionStr, param, err := handleSimpleCondition(cond, plc, len(params)+1)
+			conditionStr, condParams, err := handleSimpleCondition(cond, op, d, params)
 			if err != nil {
 				return "", nil, err
 			}
@@ -55,10 +80,8 @@ func buildConditions(conds []domain.Condition, plc domain.SqlPlaceholder, params
 			// add condition
 			condStrs = append(condStrs, conditionStr)
 
-			// is param not nil add param
-			if param != nil {
-				params = append(params, param)
-			}
+			// add condition params
+			params = condParams
 		}
 	}
 
@@ -69,17 +92,17 @@ func buildConditions(conds []domain.Condition, plc domain.SqlPlaceholder, params
 	return query, params, nil
 }
 
-// handleLogicalCondition processes a logical condition (AND/OR) within a query,
+// handleLogicalCondition processes a logical condition (AND/OR/NOT) within a query,
 // generating a SQL sub-query and its corresponding parameters.
 //
-// It takes a Condition object representing the logical condition, a slice of
-// current parameter values, a placeholder for SQL parameter substitution, and
-// the logical operator type (AND/OR). The function validates the condition's
+// It takes a Condition object representing the logical condition, the operation
+// of the enclosing statement, a slice of current parameter values, the dialect for SQL parameter substitution, and
+// the logical operator type (AND/OR, the sub-conditions of NOT are joined by AND). The function validates the condition's
 // value as a slice of sub-conditions, then recursively builds SQL sub-queries
 // for each condition within the logical group. The resulting SQL string and
 // updated parameter list are returned, along with an error if any occurs
 // during the process.
-func handleLogicalCondition(cond domain.Condition, params []any, plc domain.SqlPlaceholder, lgOp domain.OperatorType) (string, []any, error) {
+func handleLogicalCondition(cond domain.Condition, op domain.OperationType, params []any, d domain.Dialect, lgOp domain.OperatorType) (string, []any, error) {
 	// assert type
 	value, ok := cond.Value.([]domain.Condition)
 	if !ok {
@@ -96,7 +119,7 @@ func handleLogicalCondition(cond domain.Condition, params []any, plc domain.SqlP
 	}
 
 	// create sub query
-	subQuery, subParams, err := buildConditions(value, plc, params, subJoin)
+	subQuery, subParams, err := buildConditions(value, op, d, params, subJoin)
 	if err != nil {
 		return "", nil, err
 	}
@@ -111,40 +134,187 @@ func handleLogicalCondition(cond domain.Condition, params []any, plc domain.SqlP
 }
 
 // handleSimpleCondition processes a simple condition within a SQL query, generating a SQL condition string
-// and its corresponding parameter.
+// and its corresponding parameters.
 //
-// It takes a Condition object, a domain.SqlPlaceholder for parameter substitution, and a parameter index.
+// It takes a Condition object, the operation of the enclosing statement, the dialect for
+// parameter substitution, and the parameter slice to append to.
 // The function checks if the condition's value is of type ValueType and handles null values accordingly.
-// It retrieves the SQL operator for the given condition's operator, and constructs the SQL condition string
-// with the placeholder. If the value type or operator is not supported, it returns an error.
+// It renders the condition string with the placeholder by the function registered for the
+// condition's operator, see RegisterOperator. The expression of computed fields is used in place of the field name, its
+// literals are appended to the parameters before the condition's value. Conditions of boolean
+// expressions are rendered as the expression only. Values which are fields or expressions are
+// rendered in place of the placeholder, so columns can be compared. If the value type or operator is not supported,
+// it returns an error.
 //
-// The function returns the SQL condition string, the condition's value as a parameter, and an error if any.
-func handleSimpleCondition(cond domain.Condition, plc domain.SqlPlaceholder, paramIndex int) (string, any, error) {
+// The function returns the SQL condition string, the updated parameter slice, and an error if any.
+func handleSimpleCondition(cond domain.Condition, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// field name
+	name := getFieldName(cond.Field, op, d)
+
+	// create computed field expression
+	if cond.Field.Expression != nil {
+		expr, exprParams, err := buildExpression(cond.Field.Expression, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// set expression
+		name = expr
+		params = exprParams
+	}
+
+	// check is boolean expression
+	if cond.Operator == domain.OperatorExpression {
+		// check is computed field
+		if cond.Field.Expression == nil {
+			return "", nil, fmt.Errorf("expression condition without expression on %s", cond.Field.DB)
+		}
+
+		// return expression
+		return name, params, nil
+	}
+
 	// check if the value type is ValueType
 	if v, ok := cond.Value.(domain.ValueType); ok {
 		if v == domain.ValueNull {
 			// handle null value condition
 			if cond.Operator == domain.OperatorNotEqual {
-				return fmt.Sprintf("%s IS NOT NULL", getFieldName(cond.Field)), nil, nil
+				return fmt.Sprintf("%s IS NOT NULL", name), params, nil
 			}
 
 			// return conditional string and success
-			return fmt.Sprintf("%s IS NULL", getFieldName(cond.Field)), nil, nil
+			return fmt.Sprintf("%s IS NULL", name), params, nil
 		}
 
 		// return error
 		return "", nil, fmt.Errorf("unsupported value type: %d", v)
 	}
 
-	// get SQL operator
-	operator := getSqlOperator(cond.Operator)
-	if operator == "" {
-		return "", nil, fmt.Errorf("unsupported operator: %d", cond.Operator)
+	// get operator rendering function of dialect
+	render, err := lookupOperator(d, cond.Operator)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// create column or expression value, for example of join conditions
+	if expr, ok := valueExpression(cond.Value); ok {
+		// create expression
+		value, exprParams, err := buildExpression(expr, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// return condition string and params
+		return render(name, value), exprParams, nil
+	}
+
+	// create IN list
+	if cond.Operator == domain.OperatorIn || cond.Operator == domain.OperatorNotIn {
+		return handleInCondition(cond, name, render, op, d, params)
+	}
+
+	// create range bounds
+	if cond.Operator == domain.OperatorBetween {
+		return handleBetweenCondition(cond, name, render, op, d, params)
+	}
+
+	// convert value
+	value, err := valueToDBValue(cond.Value)
+	if err != nil {
+		return "", nil, err
 	}
 
 	// create condition string with placeholder
-	condStr := fmt.Sprintf("%s %s %s", getFieldName(cond.Field), operator, getPlaceholder(plc, paramIndex))
+	condStr := render(name, getPlaceholder(d, len(params)+1))
+
+	// return condition string, params and success
+	return condStr, append(params, value), nil
+}
+
+// handleInCondition translates a condition of the IN or NOT IN operator whose value is a list of
+// values to a SQL string and its params, with a placeholder for every value, for example
+// "id IN ($1, $2, $3)". Values which are fields or expressions are rendered in place. Conditions
+// of an empty list are rendered as "1 = 0" for IN and "1 = 1" for NOT IN, which are valid in all
+// dialects, since a list without values would be a syntax error.
+//
+// The function returns the SQL condition string, the updated parameter slice, and an error if any.
+func handleInCondition(cond domain.Condition, name string, render OperatorFunc, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// list values
+	values, ok := cond.Value.([]any)
+	if !ok {
+		return "", nil, fmt.Errorf("%s condition on %s without values or subquery", cond.Operator, cond.Field.DB)
+	}
+
+	// check is list empty
+	if len(values) == 0 {
+		if cond.Operator == domain.OperatorNotIn {
+			return "1 = 1", params, nil
+		}
+		return "1 = 0", params, nil
+	}
+
+	// create list items
+	items, params, err := buildValues(values, op, d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return condition string and params
+	return render(name, "("+strings.Join(items, ", ")+")"), params, nil
+}
+
+// handleBetweenCondition translates a condition of the BETWEEN operator whose value is the list
+// of its lower and upper bound to a SQL string and its params, for example "age BETWEEN $1 AND
+// $2". Bounds which are fields or expressions are rendered in place.
+//
+// The function returns the SQL condition string, the updated parameter slice, and an error if any.
+func handleBetweenCondition(cond domain.Condition, name string, render OperatorFunc, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// range bounds
+	bounds, ok := cond.Value.([]any)
+	if !ok || len(bounds) != 2 {
+		return "", nil, fmt.Errorf("%s condition on %s without lower and upper bound", cond.Operator, cond.Field.DB)
+	}
+
+	// create bounds
+	items, params, err := buildValues(bounds, op, d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return condition string and params
+	return render(name, items[0]+" AND "+items[1]), params, nil
+}
+
+// buildValues translates the values of a condition to placeholders, appending the values to
+// the params, or to the SQL of fields and expressions, which are rendered in place.
+//
+// The function returns the SQL of every value, the updated parameter slice, and an error if any.
+func buildValues(values []any, op domain.OperationType, d domain.Dialect, params []any) ([]string, []any, error) {
+	// values strings
+	items := make([]string, len(values))
+	for i, value := range values {
+		// create column or expression item
+		if expr, ok := valueExpression(value); ok {
+			item, exprParams, err := buildExpression(expr, op, d, params)
+			if err != nil {
+				return nil, nil, err
+			}
+			items[i] = item
+			params = exprParams
+			continue
+		}
+
+		// convert value
+		dbValue, err := valueToDBValue(value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// add placeholder
+		params = append(params, dbValue)
+		items[i] = getPlaceholder(d, len(params))
+	}
 
-	// return condition string, value and success
-	return condStr, cond.Value, nil
+	// return values strings and params
+	return items, params, nil
 }
@@ -0,0 +1,154 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// insertKeyword returns the keyword starting the insert, which is "INSERT IGNORE" for ignored
+// inserts (see Query.Ignore) in dialects with ON DUPLICATE KEY UPDATE clauses, or "INSERT".
+// It returns an error for ignored inserts in other dialects.
+func insertKeyword(ignore bool, d domain.Dialect) (string, error) {
+	// check is ignored insert
+	if !ignore {
+		return "INSERT", nil
+	}
+
+	// check is supported
+	if conflictStyleOf(d) != conflictOnDuplicate {
+		return "", fmt.Errorf("INSERT IGNORE is not supported by the %s dialect", d.Name())
+	}
+
+	// return ignored insert
+	return "INSERT IGNORE", nil
+}
+
+// buildConflict translates the conflict clause to a SQL string and its params in the syntax of
+// the dialect, for example "ON CONFLICT (email) WHERE deleted_at IS NULL DO UPDATE SET name =
+// excluded.name", or "ON DUPLICATE KEY UPDATE name = VALUES(name)" in MySQL, whose conflict
+// clauses have no conflict target and no DO NOTHING action, see insertKeyword for INSERT IGNORE.
+// Index expressions of the conflict target are wrapped in parentheses.
+// It returns the SQL string, the updated parameter slice, and an error if any.
+func buildConflict(conflict *domain.Conflict, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// build clause in syntax of dialect
+	switch conflictStyleOf(d) {
+	case conflictOnDuplicate:
+		return buildOnDuplicate(conflict, op, d, params)
+	case conflictUnsupported:
+		return "", nil, fmt.Errorf("conflict clauses are not supported by the %s dialect", d.Name())
+	}
+
+	// conflict target
+	query := "ON CONFLICT"
+	switch {
+	case conflict.Constraint != "":
+		query += " ON CONSTRAINT " + d.QuoteIdent(conflict.Constraint)
+	case len(conflict.Target) > 0:
+		// create target columns and expressions
+		target := make([]string, len(conflict.Target))
+		for i, field := range conflict.Target {
+			// column
+			if field.Expression == nil {
+				target[i] = getFieldName(&field, op, d)
+				continue
+			}
+
+			// index expression
+			expr, exprParams, err := buildExpression(field.Expression, op, d, params)
+			if err != nil {
+				return "", nil, err
+			}
+			target[i] = "(" + expr + ")"
+			params = exprParams
+		}
+		query += " (" + strings.Join(target, ", ") + ")"
+	}
+
+	// partial index predicate
+	if len(conflict.Where) > 0 {
+		// create conditions
+		conds, condsParams, err := buildConditions(conflict.Where, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add conditions
+		if conds != "" {
+			query += " WHERE " + conds
+		}
+		params = condsParams
+	}
+
+	// conflict action
+	switch conflict.Action {
+	case domain.ConflictDoUpdate:
+		// create update sets
+		sets, setsParams, err := buildConflictUpdates(conflict, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		query += " DO UPDATE SET " + sets
+		params = setsParams
+	case domain.ConflictDoNothing:
+		query += " DO NOTHING"
+	default:
+		return "", nil, fmt.Errorf("unsupported conflict action: %q", conflict.Action)
+	}
+
+	// return conflict clause
+	return query, params, nil
+}
+
+// buildOnDuplicate translates the conflict clause to an ON DUPLICATE KEY UPDATE clause, see
+// buildConflict. Conflict targets are ignored, and predicates of partial indexes and DO NOTHING
+// are rejected, since INSERT IGNORE skips other errors than conflicts too.
+func buildOnDuplicate(conflict *domain.Conflict, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check is partial index predicate not set
+	if len(conflict.Where) > 0 {
+		return "", nil, fmt.Errorf("conflict target predicates are not supported by the %s dialect", d.Name())
+	}
+
+	// conflict action
+	switch conflict.Action {
+	case domain.ConflictDoUpdate:
+		// create update sets
+		sets, setsParams, err := buildConflictUpdates(conflict, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		return "ON DUPLICATE KEY UPDATE " + sets, setsParams, nil
+	case domain.ConflictDoNothing:
+		return "", nil, fmt.Errorf("DO NOTHING is not supported by the %s dialect, use INSERT IGNORE instead", d.Name())
+	default:
+		return "", nil, fmt.Errorf("unsupported conflict action: %q", conflict.Action)
+	}
+}
+
+// buildConflictUpdates translates the updates of the DO UPDATE action of the conflict clause to
+// a SQL string and its params, for example "name = excluded.name, count = count + 1".
+func buildConflictUpdates(conflict *domain.Conflict, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check updates
+	if len(conflict.Updates) == 0 {
+		return "", nil, errors.New("conflict update without columns to update")
+	}
+
+	// create update sets
+	sets := make([]string, len(conflict.Updates))
+	for i, data := range conflict.Updates {
+		// create value
+		value, valueParams, err := buildDataValue(data, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add set
+		sets[i] = fmt.Sprintf("%s = %s", getFieldName(data.Field, op, d), value)
+		params = valueParams
+	}
+
+	// return update sets
+	return strings.Join(sets, ", "), params, nil
+}
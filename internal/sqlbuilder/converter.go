@@ -0,0 +1,88 @@
+package sqlbuilder
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Converter converts a value to a value that can be used in a SQL query.
+type Converter func(value any) (any, error)
+
+// converterRegistry contains the registered converters by the types of the converted values.
+type converterRegistry struct {
+	sync.RWMutex
+	converters map[reflect.Type]Converter
+}
+
+// converters contains the registered converters.
+var converters = &converterRegistry{converters: make(map[reflect.Type]Converter)}
+
+// RegisterConverter registers the converter of values of the given type, replacing the
+// converter registered before, if any. A nil converter removes the registered converter.
+func RegisterConverter(t reflect.Type, fn Converter) {
+	// register converter
+	converters.Lock()
+	defer converters.Unlock()
+	if fn == nil {
+		delete(converters.converters, t)
+		return
+	}
+	converters.converters[t] = fn
+}
+
+// lookup returns the converter registered for the type of the value, or for the type
+// pointed to if the value is a pointer.
+func (r *converterRegistry) lookup(value any) (Converter, bool) {
+	// value type
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return nil, false
+	}
+
+	// find converter
+	r.RLock()
+	defer r.RUnlock()
+	if fn, ok := r.converters[t]; ok {
+		return fn, true
+	}
+	if t.Kind() == reflect.Ptr {
+		if fn, ok := r.converters[t.Elem()]; ok {
+			return derefConverter(fn), true
+		}
+	}
+
+	// not found
+	return nil, false
+}
+
+// derefConverter returns a converter of pointers, which converts the values pointed to
+// with the given converter, nil pointers are converted to nil.
+func derefConverter(fn Converter) Converter {
+	return func(value any) (any, error) {
+		// check is nil
+		v := reflect.ValueOf(value)
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		// convert value
+		return fn(v.Elem().Interface())
+	}
+}
+
+// convertRegistered converts the value with the converter registered for its type, see
+// RegisterConverter.
+//
+// Returns the converted value and true if a converter is registered, or an error if the
+// value could not be converted.
+func convertRegistered(value any) (any, bool, error) {
+	// find converter
+	fn, ok := converters.lookup(value)
+	if !ok {
+		return nil, false, nil
+	}
+
+	// convert value
+	result, err := fn(value)
+	return result, true, err
+}
@@ -6,35 +6,83 @@ import (
 	"github.com/tyrenix/qbr/domain"
 )
 
-// CreateDeleteSql creates a SQL DELETE query from the Query's data. It returns the query string,
-// the parameters for the query, and an error if the query could not be built.
-func CreateDeleteSql(qb Query, table string, placeholder domain.SqlPlaceholder) (string, []any, error) {
-	var params []any
+// CreateDeleteSql creates a SQL DELETE query from the Query's common table expressions, joined
+// sources (see usingStyle) and conditions. The params of the query are appended to the given params, which are the params
+// of the enclosing statement and nil otherwise. It returns the query string, the parameters for
+// the query, and an error if the query could not be built.
+func CreateDeleteSql(qb Query, table string, d domain.Dialect, params []any) (string, []any, error) {
+	// create with clause
+	with, params, err := buildWith(qb.GetCTEs(), d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// create joined sources
+	sources, using := qb.GetUsing(), ""
+	if len(sources) > 0 {
+		using, params, err = buildUsing(sources, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+	}
 
-	// create base query
-	query := fmt.Sprintf("DELETE FROM %s", table)
+	// create base query, which names the table of the deleted rows before the FROM clause of the
+	// multi-table syntax
+	query := fmt.Sprintf("%sDELETE FROM %s", with, table)
+	if using != "" && usingStyleOf(d) != usingFrom {
+		query = fmt.Sprintf("%sDELETE %s", with, table)
+	}
+
+	// conditionals, without empty logical conditions
+	conds := domain.PruneConditions(qb.GetConditions())
+
+	// add output clause, which is set if conditions are set as the returning fields
+	if len(conds) > 0 {
+		output, err := buildOutput(qb.GetSelects(), qb.GetOperation(), d)
+		if err != nil {
+			return "", nil, err
+		}
+		if output != "" {
+			query += " " + output
+		}
+	}
 
-	// conditionals
-	conds := qb.GetConditions()
+	// add joined sources, as USING clause or FROM clause of multi-table syntax
+	if using != "" && usingStyleOf(d) == usingFrom {
+		query += " USING " + using
+	} else if using != "" {
+		query += " FROM " + table + ", " + using
+	}
 
 	// if exists conditions add to query
 	if len(conds) > 0 {
 		// create conditions
-		conds, condsParams, err := buildConditions(conds, placeholder, nil)
+		conds, condsParams, err := buildConditions(conds, qb.GetOperation(), d, params)
 		if err != nil {
 			return "", nil, err
 		}
 
 		// add conditions to query
-		query += " WHERE " + conds
+		if conds != "" {
+			query += " WHERE " + conds
+		}
 		// add condition params to params
-		params = append(params, condsParams...)
+		params = condsParams
 	}
 
 	// build returning fields
 	if len(conds) > 0 {
 		// create returning fields
-		query += " RETURNING " + buildSelects(qb.GetSelects())
+		returning, returningParams, err := buildReturning(qb.GetSelects(), qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add returning fields
+		if returning != "" {
+			query += " " + returning
+		}
+		params = returningParams
 	}
 
 	// return query, params and success
@@ -0,0 +1,361 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// dialect is a SQL dialect of the query builder, see domain.Dialect.
+type dialect struct {
+	name             string                            // Name of the dialect.
+	placeholder      domain.SqlPlaceholder             // Placeholder of the params.
+	open, close      string                            // Quote characters of identifiers.
+	reserved         map[string]bool                   // Reserved words, which are quoted.
+	limitOffset      func(limit, offset uint64) string // Limit and offset clause.
+	conflict         conflictStyle                     // Syntax of conflict clauses.
+	returning        returningStyle                    // Syntax of returned rows of writes.
+	using            usingStyle                        // Syntax of joined sources of writes.
+	timeout          timeoutStyle                      // Syntax of statement timeouts.
+	extreme          extremeStyle                      // Syntax of the greatest and least of values.
+	savepoint        savepointStyle                    // Syntax of savepoints of transactions.
+	indexHints       bool                              // Are index hints of tables supported.
+	noFullJoin       bool                              // Is FULL JOIN not supported.
+	noRecursive      bool                              // Is the RECURSIVE keyword of WITH clauses not supported.
+	noRowValues      bool                              // Are row value comparisons not supported.
+	noNulls          bool                              // Are NULLS FIRST and NULLS LAST of sorts not supported.
+	top              bool                              // Are limits without offset rendered as TOP.
+	orderedOffset    bool                              // Do OFFSET clauses require an ORDER BY clause.
+	distinctOn       bool                              // Is DISTINCT ON supported.
+	noLocks          bool                              // Are locking clauses not supported.
+	noShareLocks     bool                              // Is FOR SHARE not supported.
+	noLockOf         bool                              // Are locked tables of locking clauses not supported.
+	noLockWaits      bool                              // Are NOWAIT and SKIP LOCKED not supported.
+	noCompoundParens bool                              // Are the queries of set operations not parenthesized.
+	minus            bool                              // Is EXCEPT rendered as MINUS.
+
+	// Operators rendered by the syntax of the dialect, nil if not supported, see lookupOperator.
+	operators map[domain.OperatorType]OperatorFunc
+}
+
+// conflictStyle is the syntax of the conflict clauses of a dialect.
+type conflictStyle int
+
+// Conflict clause syntaxes.
+const (
+	conflictOnConflict  conflictStyle = iota // ON CONFLICT clauses, as in PostgreSQL and SQLite.
+	conflictOnDuplicate                      // ON DUPLICATE KEY UPDATE clauses without DO NOTHING, and INSERT IGNORE, as in MySQL.
+	conflictUnsupported                      // No conflict clauses.
+)
+
+// SQL dialects.
+var (
+	// Postgres is the dialect of PostgreSQL, which is the default dialect.
+	Postgres domain.Dialect = &dialect{
+		name:        "postgres",
+		placeholder: domain.SqlDollar,
+		open:        `"`,
+		close:       `"`,
+		reserved:    postgresReserved,
+		limitOffset: func(limit, offset uint64) string { return limitOffset(limit, offset, "") },
+		timeout:     timeoutSetLocal,
+		distinctOn:  true,
+	}
+
+	// MySQL is the dialect of MySQL and MariaDB.
+	MySQL domain.Dialect = &dialect{
+		name:        "mysql",
+		placeholder: domain.SqlQuestion,
+		open:        "`",
+		close:       "`",
+		reserved:    mysqlReserved,
+		using:       usingMultiTable,
+		conflict:    conflictOnDuplicate,
+		returning:   returningUnsupported,
+		timeout:     timeoutHint,
+		indexHints:  true,
+		noFullJoin:  true,
+		noNulls:     true,
+		operators: map[domain.OperatorType]OperatorFunc{
+			domain.OperatorILike:      lowerLikeOperator,
+			domain.OperatorRegexMatch: infixOperator("REGEXP"),
+		},
+		limitOffset: func(limit, offset uint64) string {
+			return limitOffset(limit, offset, fmt.Sprint(uint64(math.MaxUint64)))
+		},
+	}
+
+	// SQLite is the dialect of SQLite.
+	SQLite domain.Dialect = &dialect{
+		name:             "sqlite",
+		placeholder:      domain.SqlQuestion,
+		open:             `"`,
+		close:            `"`,
+		reserved:         sqliteReserved,
+		using:            usingUpdateFrom,
+		extreme:          extremeMinMax,
+		limitOffset:      func(limit, offset uint64) string { return limitOffset(limit, offset, "-1") },
+		noLocks:          true,
+		noCompoundParens: true,
+		operators: map[domain.OperatorType]OperatorFunc{
+			domain.OperatorILike:      lowerLikeOperator,
+			domain.OperatorRegexMatch: infixOperator("REGEXP"),
+		},
+	}
+
+	// SQLServer is the dialect of Microsoft SQL Server.
+	SQLServer domain.Dialect = &dialect{
+		name:          "sqlserver",
+		placeholder:   domain.SqlAtP,
+		open:          "[",
+		close:         "]",
+		reserved:      sqlServerReserved,
+		savepoint:     savepointTransaction,
+		using:         usingRepeatedTable,
+		extreme:       extremeValues,
+		conflict:      conflictUnsupported,
+		returning:     returningOutput,
+		limitOffset:   offsetFetch,
+		top:           true,
+		orderedOffset: true,
+		noRecursive:   true,
+		noRowValues:   true,
+		noNulls:       true,
+		noLocks:       true,
+		operators: map[domain.OperatorType]OperatorFunc{
+			domain.OperatorILike:      lowerLikeOperator,
+			domain.OperatorRegexMatch: nil,
+		},
+	}
+
+	// Oracle is the dialect of Oracle Database 12c and later.
+	Oracle domain.Dialect = &dialect{
+		name:         "oracle",
+		placeholder:  domain.SqlColon,
+		open:         `"`,
+		close:        `"`,
+		reserved:     oracleReserved,
+		savepoint:    savepointNoRelease,
+		using:        usingUnsupported,
+		limitOffset:  fetchFirst,
+		conflict:     conflictUnsupported,
+		returning:    returningUnsupported,
+		noRecursive:  true,
+		noRowValues:  true,
+		noLockOf:     true,
+		noShareLocks: true,
+		minus:        true,
+		operators: map[domain.OperatorType]OperatorFunc{
+			domain.OperatorILike:      lowerLikeOperator,
+			domain.OperatorRegexMatch: functionOperator("REGEXP_LIKE"),
+		},
+	}
+
+	// DB2 is the dialect of IBM Db2 11.1 and later.
+	DB2 domain.Dialect = &dialect{
+		name:         "db2",
+		placeholder:  domain.SqlQuestion,
+		open:         `"`,
+		close:        `"`,
+		reserved:     db2Reserved,
+		savepoint:    savepointRetainCursors,
+		using:        usingUnsupported,
+		limitOffset:  fetchFirst,
+		conflict:     conflictUnsupported,
+		returning:    returningUnsupported,
+		noRecursive:  true,
+		noRowValues:  true,
+		noLockOf:     true,
+		noLockWaits:  true,
+		noShareLocks: true,
+		operators: map[domain.OperatorType]OperatorFunc{
+			domain.OperatorILike:      lowerLikeOperator,
+			domain.OperatorRegexMatch: functionOperator("REGEXP_LIKE"),
+		},
+	}
+)
+
+// Name returns the name of the dialect.
+func (d *dialect) Name() string {
+	return d.name
+}
+
+// Placeholder returns the placeholder of the params of the dialect.
+func (d *dialect) Placeholder() domain.SqlPlaceholder {
+	return d.placeholder
+}
+
+// QuoteIdent quotes the segments of the optionally qualified identifier which need quoting, see
+// needsQuote.
+func (d *dialect) QuoteIdent(name string) string {
+	return quoteIdent(name, d.open, d.close, d.reserved)
+}
+
+// LimitOffset returns the limit and offset clause, or an empty string if both are zero.
+func (d *dialect) LimitOffset(limit, offset uint64) string {
+	return d.limitOffset(limit, offset)
+}
+
+// limitOffset creates a LIMIT and OFFSET clause from the given limit and offset values. Offsets
+// without limit are rendered with the given unlimited limit for dialects which require a limit
+// before the offset, if not empty.
+func limitOffset(limit, offset uint64, unlimited string) string {
+	// sql query
+	query := ""
+
+	// add limit
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	} else if offset > 0 && unlimited != "" {
+		query += " LIMIT " + unlimited
+	}
+
+	// add offset
+	if offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", offset)
+	}
+
+	// create limit and offset
+	return strings.TrimSpace(query)
+}
+
+// offsetFetch creates an OFFSET and FETCH clause from the given limit and offset values, which
+// requires an ORDER BY clause, see CreateSelectSql.
+func offsetFetch(limit, offset uint64) string {
+	// check is limited
+	if limit == 0 && offset == 0 {
+		return ""
+	}
+
+	// create offset
+	query := fmt.Sprintf("OFFSET %d ROWS", offset)
+
+	// add fetch
+	if limit > 0 {
+		query += fmt.Sprintf(" FETCH NEXT %d ROWS ONLY", limit)
+	}
+
+	// return offset and fetch
+	return query
+}
+
+// fetchFirst creates an OFFSET and FETCH FIRST clause from the given limit and offset values, as
+// in Oracle and Db2, for example "OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY" or "FETCH FIRST 10 ROWS
+// ONLY" without offset.
+func fetchFirst(limit, offset uint64) string {
+	// check is offset set
+	if offset == 0 {
+		if limit == 0 {
+			return ""
+		}
+		return fmt.Sprintf("FETCH FIRST %d ROWS ONLY", limit)
+	}
+
+	// create offset
+	query := fmt.Sprintf("OFFSET %d ROWS", offset)
+
+	// add fetch
+	if limit > 0 {
+		query += fmt.Sprintf(" FETCH NEXT %d ROWS ONLY", limit)
+	}
+
+	// return offset and fetch
+	return query
+}
+
+// conflictStyleOf returns the syntax of the conflict clauses of the dialect, which is the syntax
+// of ON CONFLICT clauses for custom dialects.
+func conflictStyleOf(d domain.Dialect) conflictStyle {
+	// check is dialect of query builder
+	if qd, ok := builtinDialect(d); ok {
+		return qd.conflict
+	}
+
+	// return default syntax
+	return conflictOnConflict
+}
+
+// usingStyleOf returns the syntax of the joined sources of writes of the dialect, which is the
+// syntax of UPDATE ... FROM and DELETE ... USING for custom dialects.
+func usingStyleOf(d domain.Dialect) usingStyle {
+	// check is dialect of query builder
+	if qd, ok := builtinDialect(d); ok {
+		return qd.using
+	}
+
+	// return default syntax
+	return usingFrom
+}
+
+// returningStyleOf returns the syntax of the returned rows of writes of the dialect, which is
+// the syntax of RETURNING clauses for custom dialects.
+func returningStyleOf(d domain.Dialect) returningStyle {
+	// check is dialect of query builder
+	if qd, ok := builtinDialect(d); ok {
+		return qd.returning
+	}
+
+	// return default syntax
+	return returningClause
+}
+
+// builtinDialect returns the dialect of the query builder of d, which may be wrapped, for
+// example with a placeholder (see WithPlaceholder), by dialects with an Unwrap method
+// returning the wrapped dialect. It returns false for custom dialects.
+func builtinDialect(d domain.Dialect) (*dialect, bool) {
+	// unwrap dialect
+	for {
+		wrapper, ok := d.(interface{ Unwrap() domain.Dialect })
+		if !ok {
+			break
+		}
+		d = wrapper.Unwrap()
+	}
+
+	// return dialect of query builder
+	qd, ok := d.(*dialect)
+	return qd, ok
+}
+
+// HasConflictTarget checks if the conflict clauses of the dialect have a conflict target, which
+// is not the case for MySQL, which checks all unique indexes.
+func HasConflictTarget(d domain.Dialect) bool {
+	return conflictStyleOf(d) == conflictOnConflict
+}
+
+// SupportsRowValues checks if the dialect supports row value comparisons, for example "(a, b)
+// > (1, 2)", which is not the case for SQL Server.
+func SupportsRowValues(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return !ok || !qd.noRowValues
+}
+
+// placeholderDialect is a dialect with another placeholder, see WithPlaceholder.
+type placeholderDialect struct {
+	domain.Dialect
+	placeholder domain.SqlPlaceholder
+}
+
+// Placeholder returns the placeholder of the params.
+func (d placeholderDialect) Placeholder() domain.SqlPlaceholder {
+	return d.placeholder
+}
+
+// Unwrap returns the dialect with its own placeholder.
+func (d placeholderDialect) Unwrap() domain.Dialect {
+	return d.Dialect
+}
+
+// WithPlaceholder returns the dialect with the given placeholder, or the dialect itself if it
+// uses the placeholder already.
+func WithPlaceholder(d domain.Dialect, placeholder domain.SqlPlaceholder) domain.Dialect {
+	// check is placeholder of dialect
+	if d.Placeholder() == placeholder {
+		return d
+	}
+
+	// return dialect with placeholder
+	return placeholderDialect{Dialect: d, placeholder: placeholder}
+}
@@ -0,0 +1,361 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// sqlIdentifier matches the function names and extract parts allowed in expressions.
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// sqlBinaryOperators contains the operators allowed in binary expressions.
+var sqlBinaryOperators = map[string]bool{
+	"+": true,
+	"-": true,
+	"*": true,
+	"/": true,
+}
+
+// buildExpression translates an expression to a SQL string and its params.
+// op is the operation of the enclosing statement used to resolve the field names,
+// d is the dialect of the placeholders and identifiers, and params is the parameter slice to append
+// the literals of the expression to. Nested binary expressions are wrapped in parentheses.
+// It returns the SQL string, the updated parameter slice, and an error if any.
+func buildExpression(expr *domain.Expression, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check is expression not nil
+	if expr == nil {
+		return "", nil, errors.New("nil expression")
+	}
+
+	switch expr.Kind {
+	case domain.ExpressionField:
+		// check is field not nil
+		if expr.Field == nil {
+			return "", nil, errors.New("nil field in expression")
+		}
+
+		// return field name
+		return getFieldName(expr.Field, op, d), params, nil
+	case domain.ExpressionExcluded:
+		// check is field not nil
+		if expr.Field == nil {
+			return "", nil, errors.New("nil field in excluded expression")
+		}
+
+		// return proposed column
+		if conflictStyleOf(d) == conflictOnDuplicate {
+			return "VALUES(" + getFieldName(expr.Field, op, d) + ")", params, nil
+		}
+		return "excluded." + getFieldName(expr.Field, op, d), params, nil
+	case domain.ExpressionSubquery:
+		// check is subquery not nil
+		if expr.Subquery == nil {
+			return "", nil, errors.New("nil subquery in expression")
+		}
+
+		// create subquery
+		sub, subParams, err := expr.Subquery.BuildSubquery(d, params)
+		if err != nil {
+			return "", nil, fmt.Errorf("subquery: %w", err)
+		}
+
+		// return subquery after operator
+		if expr.Name != "" {
+			return expr.Name + " (" + sub + ")", subParams, nil
+		}
+		return "(" + sub + ")", subParams, nil
+	case domain.ExpressionLiteral:
+		return buildValue(expr.Value, op, d, params)
+	case domain.ExpressionCase:
+		return buildCaseExpression(expr, op, d, params)
+	case domain.ExpressionRaw:
+		return buildRawExpression(expr, op, d, params)
+	}
+
+	// operands strings
+	operands := make([]string, len(expr.Operands))
+	for i, operand := range expr.Operands {
+		// create operand
+		str, operandParams, err := buildExpression(operand, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// wrap nested binary expression
+		if expr.Kind == domain.ExpressionBinary && operand.Kind == domain.ExpressionBinary {
+			str = "(" + str + ")"
+		}
+
+		// set operand
+		operands[i] = str
+		params = operandParams
+	}
+
+	switch expr.Kind {
+	case domain.ExpressionBinary:
+		// check operator
+		if !sqlBinaryOperators[expr.Name] || len(operands) != 2 {
+			return "", nil, fmt.Errorf("unsupported binary expression %q", expr.Name)
+		}
+
+		// return binary expression
+		return operands[0] + " " + expr.Name + " " + operands[1], params, nil
+	case domain.ExpressionFunction:
+		// check function name
+		if !sqlIdentifier.MatchString(expr.Name) {
+			return "", nil, fmt.Errorf("invalid function name %q", expr.Name)
+		}
+
+		// check is greatest or least emulated
+		if extreme, ok := buildExtreme(expr.Name, operands, d); ok {
+			return extreme, params, nil
+		}
+
+		// return function call
+		return expr.Name + "(" + strings.Join(operands, ", ") + ")", params, nil
+	case domain.ExpressionExtract:
+		// check part
+		if !sqlIdentifier.MatchString(expr.Name) || len(operands) != 1 {
+			return "", nil, fmt.Errorf("invalid extract part %q", expr.Name)
+		}
+
+		// return extract expression
+		return fmt.Sprintf("EXTRACT(%s FROM %s)", strings.ToUpper(expr.Name), operands[0]), params, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported expression kind: %d", expr.Kind)
+	}
+}
+
+// buildCaseExpression translates a case expression to a SQL string and its params, the params of
+// the conditions and results of the branches are appended in rendering order.
+// See buildExpression for the parameters.
+func buildCaseExpression(expr *domain.Expression, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check branches
+	if len(expr.Whens) == 0 {
+		return "", nil, errors.New("case expression without when branches")
+	}
+	if len(expr.Operands) < len(expr.Whens) || len(expr.Operands) > len(expr.Whens)+1 {
+		return "", nil, errors.New("invalid results of case expression")
+	}
+
+	// case string
+	var b strings.Builder
+	b.WriteString("CASE")
+
+	// create branches
+	for i, when := range expr.Whens {
+		// create condition
+		cond, condParams, err := buildConditions([]domain.Condition{when}, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// check is condition empty
+		if cond == "" {
+			return "", nil, errors.New("empty condition in case expression")
+		}
+
+		// create result
+		result, resultParams, err := buildExpression(expr.Operands[i], op, d, condParams)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add branch
+		fmt.Fprintf(&b, " WHEN %s THEN %s", cond, result)
+		params = resultParams
+	}
+
+	// create else result
+	if len(expr.Operands) > len(expr.Whens) {
+		result, resultParams, err := buildExpression(expr.Operands[len(expr.Whens)], op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add else
+		fmt.Fprintf(&b, " ELSE %s", result)
+		params = resultParams
+	}
+
+	// return case expression
+	b.WriteString(" END")
+	return b.String(), params, nil
+}
+
+// buildRawExpression translates a raw expression to a SQL string and its params, replacing
+// each "?" placeholder of its SQL by the next operand, "??" is a literal "?". The number
+// of placeholders must match the number of operands. See buildExpression for the parameters.
+func buildRawExpression(expr *domain.Expression, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// split sql by placeholders
+	parts := strings.Split(strings.ReplaceAll(expr.Name, "??", "\x00"), "?")
+
+	// check placeholders count
+	if len(parts)-1 != len(expr.Operands) {
+		return "", nil, fmt.Errorf("raw expression %q has %d placeholders for %d arguments", expr.Name, len(parts)-1, len(expr.Operands))
+	}
+
+	// raw string
+	var b strings.Builder
+
+	// create raw expression
+	for i, part := range parts {
+		// create operand
+		if i > 0 {
+			operand, operandParams, err := buildExpression(expr.Operands[i-1], op, d, params)
+			if err != nil {
+				return "", nil, err
+			}
+
+			// add operand
+			b.WriteString(operand)
+			params = operandParams
+		}
+
+		// add sql part
+		b.WriteString(strings.ReplaceAll(part, "\x00", "?"))
+	}
+
+	// return raw expression
+	return b.String(), params, nil
+}
+
+// buildFieldExpression translates a field to a SQL string and its params, which is the
+// expression of computed fields or the field name otherwise, wrapped in the aggregation
+// function of the field. See buildExpression for the parameters.
+func buildFieldExpression(field *domain.Field, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// aggregation format
+	format, ok := sqlAggregationFormats[field.Aggregation]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported aggregation: %d", field.Aggregation)
+	}
+
+	// check is computed field
+	if field.Expression == nil {
+		return fmt.Sprintf(format, getFieldName(field, op, d)), params, nil
+	}
+
+	// create expression
+	expr, params, err := buildExpression(field.Expression, op, d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return formatted expression
+	return fmt.Sprintf(format, expr), params, nil
+}
+
+// buildValue translates a value of the query data to a SQL string and its params, which
+// is the expression if the value is an expression, or a placeholder of the value otherwise.
+// See buildExpression for the parameters.
+func buildValue(value any, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check is expression
+	if expr, ok := value.(*domain.Expression); ok {
+		return buildExpression(expr, op, d, params)
+	}
+
+	// create database value
+	v, err := valueToDBValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return placeholder
+	return getPlaceholder(d, len(params)+1), append(params, v), nil
+}
+
+// valueExpression returns the expression of the value if it is an expression, a field, which
+// is the column of the field or the expression of computed fields, or a subquery.
+func valueExpression(value any) (*domain.Expression, bool) {
+	switch v := value.(type) {
+	case *domain.Expression:
+		return v, v != nil
+	case domain.Subquery:
+		return &domain.Expression{Kind: domain.ExpressionSubquery, Subquery: v}, v != nil
+	case *domain.Field:
+		if v == nil {
+			return nil, false
+		}
+		if v.Expression != nil {
+			return v.Expression, true
+		}
+		return &domain.Expression{Kind: domain.ExpressionField, Field: v}, true
+	default:
+		return nil, false
+	}
+}
+
+// buildDataValue translates the value of the data to a SQL string and its params, see
+// buildValue. Values of JSON fields are marshalled to JSON, see FieldValue.
+func buildDataValue(data domain.Data, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check is json field
+	if _, ok := data.Value.(*domain.Expression); ok || data.Field == nil || !data.Field.JSON {
+		return buildValue(data.Value, op, d, params)
+	}
+
+	// marshal value
+	v, err := FieldValue(data.Field, data.Value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return placeholder
+	return getPlaceholder(d, len(params)+1), append(params, v), nil
+}
+
+// extremeStyle is the syntax of the greatest and least of values of a dialect.
+type extremeStyle int
+
+// Greatest and least syntaxes.
+const (
+	extremeFunctions extremeStyle = iota // GREATEST and LEAST functions, as in PostgreSQL and MySQL.
+	extremeMinMax                        // MAX and MIN functions of several values, as in SQLite.
+	extremeValues                        // MAX and MIN of a VALUES table, as in SQL Server before 2022.
+)
+
+// buildExtreme translates the GREATEST and LEAST functions of the operands to the syntax of the
+// dialect, for example "(SELECT MAX(v) FROM (VALUES (a), (b)) AS qbr_values(v))" in SQL Server,
+// which like GREATEST in PostgreSQL ignores NULL values.
+// It returns the SQL string and true, or false if the function is not GREATEST or LEAST or is
+// supported by the dialect.
+func buildExtreme(name string, operands []string, d domain.Dialect) (string, bool) {
+	// aggregate function of the extreme
+	var aggregate string
+	switch strings.ToUpper(name) {
+	case "GREATEST":
+		aggregate = "MAX"
+	case "LEAST":
+		aggregate = "MIN"
+	default:
+		return "", false
+	}
+
+	// check syntax of dialect, the extreme of a single operand is the operand, which is not
+	// aggregated by MAX and MIN
+	switch style := extremeStyleOf(d); {
+	case style != extremeFunctions && len(operands) == 1:
+		return operands[0], true
+	case style == extremeMinMax:
+		return aggregate + "(" + strings.Join(operands, ", ") + ")", true
+	case style == extremeValues:
+		return "(SELECT " + aggregate + "(v) FROM (VALUES (" + strings.Join(operands, "), (") + ")) AS qbr_values(v))", true
+	default:
+		return "", false
+	}
+}
+
+// extremeStyleOf returns the syntax of the greatest and least of values of the dialect, which
+// is the syntax of GREATEST and LEAST for custom dialects.
+func extremeStyleOf(d domain.Dialect) extremeStyle {
+	// check is dialect of query builder
+	if qd, ok := builtinDialect(d); ok {
+		return qd.extreme
+	}
+
+	// return default syntax
+	return extremeFunctions
+}
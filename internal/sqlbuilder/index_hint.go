@@ -0,0 +1,43 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// buildIndexHints translates the index hints of a table to a SQL string, for example " FORCE
+// INDEX (idx_created_at)", which follows the table and its alias.
+// It returns the SQL string with a leading space or an empty string without hints, and an
+// error if the dialect does not support index hints, which are specific to MySQL.
+func buildIndexHints(hints []domain.IndexHint, d domain.Dialect) (string, error) {
+	// check is hints set
+	if len(hints) == 0 {
+		return "", nil
+	}
+
+	// check is supported
+	if !supportsIndexHints(d) {
+		return "", fmt.Errorf("%s is not supported by the %s dialect", hints[0].Type, d.Name())
+	}
+
+	// create hints
+	var query string
+	for _, hint := range hints {
+		indexes := make([]string, len(hint.Indexes))
+		for i, index := range hint.Indexes {
+			indexes[i] = d.QuoteIdent(index)
+		}
+		query += " " + string(hint.Type) + " (" + strings.Join(indexes, ", ") + ")"
+	}
+
+	// return hints
+	return query, nil
+}
+
+// supportsIndexHints checks if the dialect supports index hints, which is the case for MySQL.
+func supportsIndexHints(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return ok && qd.indexHints
+}
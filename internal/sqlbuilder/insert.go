@@ -1,56 +1,163 @@
 package sqlbuilder
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/tyrenix/qbr/domain"
 )
 
-// CreateInsertSql creates a SQL INSERT query from the Query's data. It returns the query string,
-// the parameters for the query, and an error if the query could not be built.
-func CreateInsertSql(qb Query, table string, placeholder domain.SqlPlaceholder) (string, []any, error) {
-	var columns []string
-	var values []string
-	var params []any
-
+// CreateInsertSql creates a SQL INSERT query from the Query's data, with a row of values for
+// every row of a multi-row insert (see Query.GetRows), or from the rows selected by its
+// subquery (see Query.GetInsertSelect). It returns the query string, the parameters for the
+// query, and an error if the query could not be built, for example if the columns of a row
+// differ from the columns of the first row.
+func CreateInsertSql(qb Query, table string, d domain.Dialect) (string, []any, error) {
 	// select fields
 	selects := qb.GetSelects()
-	// data
-	setData := qb.GetData()
 
-	// create main query
-	for _, data := range setData {
-		// add database column
-		columns = append(columns, getFieldName(data.Field))
+	// create columns and source of inserted rows
+	var columns []string
+	var source string
+	var params []any
+	var err error
+	if sel := qb.GetInsertSelect(); sel != nil {
+		columns, source, params, err = buildInsertSelect(sel, qb.GetOperation(), d)
+	} else {
+		columns, source, params, err = buildInsertValues(qb, d)
+	}
+	if err != nil {
+		return "", nil, err
+	}
 
-		// add value
-		values = append(values, getPlaceholder(placeholder, len(params)+1))
+	// create columns clause, which is omitted for selected rows into all columns
+	clause := ""
+	if len(columns) > 0 {
+		clause = " (" + strings.Join(columns, ", ") + ")"
+	}
 
-		// create database value
-		v, err := valueToDBValue(data.Value)
-		if err != nil {
-			return "", nil, err
-		}
+	// create output clause
+	output, err := buildOutput(selects, qb.GetOperation(), d)
+	if err != nil {
+		return "", nil, err
+	}
+	if output != "" {
+		output = " " + output
+	}
 
-		// add params
-		params = append(params, v)
+	// create insert keyword
+	keyword, err := insertKeyword(qb.GetIgnore(), d)
+	if err != nil {
+		return "", nil, err
 	}
 
 	// create query
 	query := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s)",
+		"%s INTO %s%s%s %s",
+		keyword,
 		table,
-		strings.Join(columns, ", "),
-		strings.Join(values, ", "),
+		clause,
+		output,
+		source,
 	)
 
+	// build conflict clause
+	if conflict := qb.GetConflict(); conflict != nil {
+		// create conflict clause
+		clause, conflictParams, err := buildConflict(conflict, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add conflict clause
+		query += " " + clause
+		params = conflictParams
+	}
+
 	// build returning fields
-	if len(selects) > 0 {
-		// create returning fields
-		query += " RETURNING " + buildSelects(selects)
+	returning, params, err := buildReturning(selects, qb.GetOperation(), d, params)
+	if err != nil {
+		return "", nil, err
+	}
+	if returning != "" {
+		query += " " + returning
 	}
 
 	// return query, params and success
 	return query, params, nil
 }
+
+// buildInsertValues translates the data of the query to the columns and the VALUES clause of an
+// insert, with a row of values for every row of a multi-row insert, see CreateInsertSql.
+// It returns the columns, the VALUES clause, the parameters of the values, and an error if the
+// columns of a row differ from the columns of the first row.
+func buildInsertValues(qb Query, d domain.Dialect) ([]string, string, []any, error) {
+	var columns []string
+	var values []string
+	var params []any
+
+	// rows of data
+	rows := qb.GetRows()
+	if len(rows) == 0 {
+		rows = [][]domain.Data{qb.GetData()}
+	}
+
+	// create columns of first row
+	for _, data := range rows[0] {
+		columns = append(columns, getFieldName(data.Field, qb.GetOperation(), d))
+	}
+
+	// create rows values
+	for i, row := range rows {
+		// check is count of columns of first row
+		if len(row) != len(columns) {
+			return nil, "", nil, fmt.Errorf("row %d: %d columns instead of the %d columns of the first row", i, len(row), len(columns))
+		}
+
+		// row values
+		rowValues := make([]string, len(row))
+		for j, data := range row {
+			// check is column of first row
+			if column := getFieldName(data.Field, qb.GetOperation(), d); column != columns[j] {
+				return nil, "", nil, &RowError{Row: i, Column: column, Err: errors.New("column differs from the first row")}
+			}
+
+			// create value
+			value, valueParams, err := buildDataValue(data, qb.GetOperation(), d, params)
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			// add value and params
+			rowValues[j] = value
+			params = valueParams
+		}
+
+		// add row
+		values = append(values, "("+strings.Join(rowValues, ", ")+")")
+	}
+
+	// return columns, values and params
+	return columns, "VALUES " + strings.Join(values, ", "), params, nil
+}
+
+// buildInsertSelect translates the selected rows of an insert to its columns and the subquery
+// selecting the rows, see CreateInsertSql.
+// It returns the columns, the subquery, its parameters, and an error if any.
+func buildInsertSelect(sel *domain.InsertSelect, op domain.OperationType, d domain.Dialect) ([]string, string, []any, error) {
+	// create columns
+	columns := make([]string, len(sel.Columns))
+	for i, field := range sel.Columns {
+		columns[i] = getFieldName(&field, op, d)
+	}
+
+	// create subquery
+	sub, params, err := sel.Subquery.BuildSubquery(d, nil)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("INSERT ... SELECT: %w", err)
+	}
+
+	// return columns, subquery and params
+	return columns, sub, params, nil
+}
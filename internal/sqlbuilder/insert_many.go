@@ -0,0 +1,105 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// CreateInsertManySql creates a SQL INSERT query inserting the given rows of values for the
+// columns, with the conflict clause if not nil and the returning fields if any. It returns the query string, the parameters for the query, and an error if the
+// query could not be built, which is a *RowError if a value of a row is not supported.
+func CreateInsertManySql(table string, columns []string, rows [][]any, conflict *domain.Conflict, returning []domain.Field, d domain.Dialect) (string, []any, error) {
+	// params
+	params := make([]any, 0, len(columns)*len(rows))
+
+	// rows values
+	values := make([]string, 0, len(rows))
+
+	// create rows
+	for i, row := range rows {
+		// row placeholders
+		placeholders := make([]string, len(row))
+		for j, value := range row {
+			// create database value
+			v, err := valueToDBValue(value)
+			if err != nil {
+				return "", nil, &RowError{Row: i, Column: columns[j], Err: err}
+			}
+
+			// add placeholder and param
+			params = append(params, v)
+			placeholders[j] = getPlaceholder(d, len(params))
+		}
+
+		// add row
+		values = append(values, "("+strings.Join(placeholders, ", ")+")")
+	}
+
+	// quote columns
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = d.QuoteIdent(column)
+	}
+
+	// create output clause
+	output, err := buildOutput(returning, domain.OperationCreate, d)
+	if err != nil {
+		return "", nil, err
+	}
+	if output != "" {
+		output = " " + output
+	}
+
+	// create query
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s)%s VALUES %s",
+		d.QuoteIdent(table),
+		strings.Join(quoted, ", "),
+		output,
+		strings.Join(values, ", "),
+	)
+
+	// build conflict clause, its params follow the params of the rows
+	if conflict != nil {
+		// create conflict clause
+		clause, conflictParams, err := buildConflict(conflict, domain.OperationCreate, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add conflict clause
+		query += " " + clause
+		params = conflictParams
+	}
+
+	// build returning fields
+	fields, params, err := buildReturning(returning, domain.OperationCreate, d, params)
+	if err != nil {
+		return "", nil, err
+	}
+	if fields != "" {
+		query += " " + fields
+	}
+
+	// return query, params and success
+	return query, params, nil
+}
+
+// RowError is an error of a value of a row.
+type RowError struct {
+	Row    int    // Index of the row.
+	Column string // Column of the value.
+	Err    error  // Error of the value.
+}
+
+// Error returns the error message.
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d, column %s: %v", e.Row, e.Column, e.Err)
+}
+
+// Unwrap returns the error of the value.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
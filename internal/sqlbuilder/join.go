@@ -0,0 +1,103 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// buildJoins translates the joins to a SQL string and its params, for example "LEFT JOIN
+// orders AS o ON o.user_id = users.id". The params of joined subqueries and of the join
+// conditions are appended in the order of the joins.
+// It returns the SQL string, the updated parameter slice, and an error if any.
+func buildJoins(joins []domain.Join, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// join clauses
+	clauses := make([]string, len(joins))
+
+	// create joins
+	for i, join := range joins {
+		// check is join type supported
+		if join.Type == domain.JoinFull && !supportsFullJoin(d) {
+			return "", nil, fmt.Errorf("%s is not supported by the %s dialect", join.Type, d.Name())
+		}
+
+		// create source
+		source, sourceParams, err := buildSource(join.Source, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		params = sourceParams
+		clause := string(join.Type) + " " + source
+
+		// cross join without conditions
+		if join.Type == domain.JoinCross {
+			clauses[i] = clause
+			continue
+		}
+
+		// create conditions
+		conds, condsParams, err := buildConditions(join.On, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		if conds == "" {
+			return "", nil, fmt.Errorf("%s %s without conditions", join.Type, source)
+		}
+		params = condsParams
+
+		// add join
+		clauses[i] = clause + " ON " + conds
+	}
+
+	// return joins
+	return strings.Join(clauses, " "), params, nil
+}
+
+// buildSource translates the table or subquery to a SQL string and its params, for example
+// "orders AS o" or "(SELECT ...) AS t", with the index hints of the table.
+// It returns the SQL string, the updated parameter slice, and an error if any.
+func buildSource(source domain.Source, d domain.Dialect, params []any) (string, []any, error) {
+	// create table or subquery
+	var query string
+	switch {
+	case source.Subquery != nil:
+		// check alias
+		if source.Alias == "" {
+			return "", nil, errors.New("subquery source without alias")
+		}
+
+		// create subquery
+		sub, subParams, err := source.Subquery.BuildSubquery(d, params)
+		if err != nil {
+			return "", nil, fmt.Errorf("subquery %s: %w", source.Alias, err)
+		}
+		query = "(" + sub + ")"
+		params = subParams
+	case source.Table != "":
+		query = d.QuoteIdent(source.Table)
+	default:
+		return "", nil, errors.New("source without table or subquery")
+	}
+
+	// add alias
+	if source.Alias != "" {
+		query += " AS " + d.QuoteIdent(source.Alias)
+	}
+
+	// add index hints
+	hints, err := buildIndexHints(source.IndexHints, d)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return source
+	return query + hints, params, nil
+}
+
+// supportsFullJoin checks if the dialect supports FULL JOIN, which is not the case for MySQL.
+func supportsFullJoin(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return !ok || !qd.noFullJoin
+}
@@ -0,0 +1,101 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// OperatorFunc renders a condition of an operator from the column, or the expression of
+// a computed field, and the placeholder of the value of the condition.
+type OperatorFunc func(column, arg string) string
+
+// operatorRegistry contains the rendering functions of the conditions by their operators.
+type operatorRegistry struct {
+	sync.RWMutex
+	operators map[domain.OperatorType]OperatorFunc
+}
+
+// operators contains the registered operators, including the built-in comparison operators.
+var operators = &operatorRegistry{operators: map[domain.OperatorType]OperatorFunc{
+	domain.OperatorEqual:              infixOperator("="),
+	domain.OperatorNotEqual:           infixOperator("!="),
+	domain.OperatorLessThan:           infixOperator("<"),
+	domain.OperatorGreaterThan:        infixOperator(">"),
+	domain.OperatorLessThanOrEqual:    infixOperator("<="),
+	domain.OperatorGreaterThanOrEqual: infixOperator(">="),
+	domain.OperatorIn:                 infixOperator("IN"),
+	domain.OperatorNotIn:              infixOperator("NOT IN"),
+	domain.OperatorBetween:            infixOperator("BETWEEN"),
+	domain.OperatorLike:               infixOperator("LIKE"),
+	domain.OperatorNotLike:            infixOperator("NOT LIKE"),
+	domain.OperatorILike:              infixOperator("ILIKE"),
+	domain.OperatorRegexMatch:         infixOperator("~"),
+}}
+
+// RegisterOperator registers the rendering function of the conditions of the operator,
+// replacing the function registered before, if any. The operators rendered by the syntax of
+// a built-in dialect, such as ILIKE in MySQL, are rendered by the dialect instead, see
+// lookupOperator.
+func RegisterOperator(op domain.OperatorType, fn OperatorFunc) {
+	operators.Lock()
+	defer operators.Unlock()
+	operators.operators[op] = fn
+}
+
+// lookup returns the rendering function registered for the operator.
+func (r *operatorRegistry) lookup(op domain.OperatorType) (OperatorFunc, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	fn, ok := r.operators[op]
+	return fn, ok
+}
+
+// infixOperator returns the rendering function of a binary infix operator, for example
+// "column = $1" for "=".
+func infixOperator(operator string) OperatorFunc {
+	return func(column, arg string) string {
+		return column + " " + operator + " " + arg
+	}
+}
+
+// lookupOperator returns the rendering function of the operator in the dialect, which is the
+// function of the syntax of the dialect if it has one, for example REGEXP for regex matches
+// in MySQL, or the registered function, see RegisterOperator.
+//
+// Returns an error if the operator is not registered or not supported by the dialect.
+func lookupOperator(d domain.Dialect, op domain.OperatorType) (OperatorFunc, error) {
+	// check is operator of dialect
+	if qd, ok := builtinDialect(d); ok {
+		if render, ok := qd.operators[op]; ok {
+			if render == nil {
+				return nil, fmt.Errorf("operator %s is not supported by the %s dialect", op, qd.name)
+			}
+			return render, nil
+		}
+	}
+
+	// get registered operator
+	render, ok := operators.lookup(op)
+	if !ok {
+		return nil, fmt.Errorf("unsupported operator: %d", op)
+	}
+
+	// return operator
+	return render, nil
+}
+
+// lowerLikeOperator renders case-insensitive LIKE conditions by comparing the lower case column
+// and pattern, for dialects without ILIKE.
+func lowerLikeOperator(column, arg string) string {
+	return "LOWER(" + column + ") LIKE LOWER(" + arg + ")"
+}
+
+// functionOperator returns the rendering function of an operator rendered as a function of the
+// column and the value, for example "REGEXP_LIKE(column, $1)".
+func functionOperator(name string) OperatorFunc {
+	return func(column, arg string) string {
+		return name + "(" + column + ", " + arg + ")"
+	}
+}
@@ -1,12 +1,33 @@
 package sqlbuilder
 
-import "github.com/tyrenix/qbr/domain"
+import (
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
 
 type Query interface {
 	GetSelects() []domain.Field
 	GetConditions() []domain.Condition
 	GetData() []domain.Data
+	GetRows() [][]domain.Data
 	GetSort() []domain.Sort
 	GetLimit() uint64
 	GetOffset() uint64
+	GetIndexHints() []domain.IndexHint
+	GetOperation() domain.OperationType
+	GetIgnore() bool
+	GetLock() *domain.Lock
+	GetConflict() *domain.Conflict
+	GetJoins() []domain.Join
+	GetFrom() *domain.Source
+	GetCTEs() []domain.CTE
+	GetGroupBy() []domain.Field
+	GetHaving() []domain.Condition
+	GetDistinct() bool
+	GetDistinctOn() []domain.Field
+	GetCompounds() []domain.Compound
+	GetInsertSelect() *domain.InsertSelect
+	GetUsing() []domain.Source
+	GetStatementTimeout() time.Duration
 }
@@ -0,0 +1,85 @@
+package sqlbuilder
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// forceQuoted contains the identifiers which are always quoted, see ForceQuote.
+var forceQuoted struct {
+	sync.RWMutex
+	names map[string]bool
+}
+
+// ForceQuote registers identifiers which are always quoted, in addition to the reserved words
+// and the identifiers with other characters than lower case letters, digits and underscores.
+func ForceQuote(names ...string) {
+	forceQuoted.Lock()
+	defer forceQuoted.Unlock()
+	if forceQuoted.names == nil {
+		forceQuoted.names = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		forceQuoted.names[name] = true
+	}
+}
+
+// quoteIdent quotes the segments of the optionally qualified identifier which need quoting with
+// the given quote characters, see needsQuote, for example `public."order"` for "public.order".
+// Quote characters in the segments are escaped by doubling, and the segments "*" of all columns
+// are not quoted.
+func quoteIdent(name, open, close string, reserved map[string]bool) string {
+	// split qualified identifier
+	segments := strings.Split(name, ".")
+
+	// quote segments
+	for i, segment := range segments {
+		if needsQuote(segment, reserved) {
+			segments[i] = open + strings.ReplaceAll(segment, close, close+close) + close
+		}
+	}
+
+	// return identifier
+	return strings.Join(segments, ".")
+}
+
+// needsQuote checks if the identifier segment needs quoting, which is the case for the given
+// reserved words, identifiers registered by ForceQuote, and identifiers which do not start with
+// a lower case letter or an underscore followed by lower case letters, digits and underscores.
+func needsQuote(segment string, reserved map[string]bool) bool {
+	// check is all columns or empty
+	if segment == "*" || segment == "" {
+		return false
+	}
+
+	// check characters
+	for i, r := range segment {
+		if !(r >= 'a' && r <= 'z' || r == '_' || i > 0 && r >= '0' && r <= '9') {
+			return true
+		}
+	}
+
+	// check is reserved word
+	if reserved[segment] {
+		return true
+	}
+
+	// check is force quoted
+	forceQuoted.RLock()
+	defer forceQuoted.RUnlock()
+	return forceQuoted.names[segment]
+}
+
+// quoteField returns the name of the field quoted by the dialect, see domain.Dialect.QuoteIdent.
+// Names of unsafe fields are returned as they are, see domain.Field.Unsafe.
+func quoteField(field *domain.Field, name string, d domain.Dialect) string {
+	// check is unsafe
+	if field.Unsafe {
+		return name
+	}
+
+	// return quoted name
+	return d.QuoteIdent(name)
+}
@@ -0,0 +1,208 @@
+package sqlbuilder
+
+// postgresReserved contains the keywords of PostgreSQL which cannot be used as column names
+// without quoting, which are the keywords documented as "reserved" and as "reserved (can be
+// function or type)" in the SQL Key Words appendix of the PostgreSQL documentation.
+var postgresReserved = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true,
+	"as": true, "asc": true, "asymmetric": true, "authorization": true, "binary": true,
+	"both": true, "case": true, "cast": true, "check": true, "collate": true, "collation": true,
+	"column": true, "concurrently": true, "constraint": true, "create": true, "cross": true,
+	"current_catalog": true, "current_date": true, "current_role": true, "current_schema": true,
+	"current_time": true, "current_timestamp": true, "current_user": true, "default": true,
+	"deferrable": true, "desc": true, "distinct": true, "do": true, "else": true, "end": true,
+	"except": true, "false": true, "fetch": true, "for": true, "foreign": true, "freeze": true,
+	"from": true, "full": true, "grant": true, "group": true, "having": true, "ilike": true,
+	"in": true, "initially": true, "inner": true, "intersect": true, "into": true, "is": true,
+	"isnull": true, "join": true, "lateral": true, "leading": true, "left": true, "like": true,
+	"limit": true, "localtime": true, "localtimestamp": true, "natural": true, "not": true,
+	"notnull": true, "null": true, "offset": true, "on": true, "only": true, "or": true,
+	"order": true, "outer": true, "overlaps": true, "placing": true, "primary": true,
+	"references": true, "returning": true, "right": true, "select": true, "session_user": true,
+	"similar": true, "some": true, "symmetric": true, "system_user": true, "table": true,
+	"tablesample": true, "then": true, "to": true, "trailing": true, "true": true, "union": true,
+	"unique": true, "user": true, "using": true, "variadic": true, "verbose": true, "when": true,
+	"where": true, "window": true, "with": true,
+}
+
+// mysqlReserved contains the keywords of MySQL which cannot be used as identifiers without
+// quoting, which are the keywords marked as reserved in the Keywords and Reserved Words
+// section of the MySQL 8.0 documentation.
+var mysqlReserved = map[string]bool{
+	"accessible": true, "add": true, "all": true, "alter": true, "analyze": true, "and": true,
+	"as": true, "asc": true, "asensitive": true, "before": true, "between": true, "bigint": true,
+	"binary": true, "blob": true, "both": true, "by": true, "call": true, "cascade": true,
+	"case": true, "change": true, "char": true, "character": true, "check": true, "collate": true,
+	"column": true, "condition": true, "constraint": true, "continue": true, "convert": true,
+	"create": true, "cross": true, "cube": true, "cume_dist": true, "current_date": true,
+	"current_time": true, "current_timestamp": true, "current_user": true, "cursor": true,
+	"database": true, "databases": true, "day_hour": true, "day_microsecond": true,
+	"day_minute": true, "day_second": true, "dec": true, "decimal": true, "declare": true,
+	"default": true, "delayed": true, "delete": true, "dense_rank": true, "desc": true,
+	"describe": true, "deterministic": true, "distinct": true, "distinctrow": true, "div": true,
+	"double": true, "drop": true, "dual": true, "each": true, "else": true, "elseif": true,
+	"empty": true, "enclosed": true, "escaped": true, "except": true, "exists": true, "exit": true,
+	"explain": true, "false": true, "fetch": true, "first_value": true, "float": true,
+	"float4": true, "float8": true, "for": true, "force": true, "foreign": true, "from": true,
+	"fulltext": true, "function": true, "generated": true, "get": true, "grant": true,
+	"group": true, "grouping": true, "groups": true, "having": true, "high_priority": true,
+	"hour_microsecond": true, "hour_minute": true, "hour_second": true, "if": true, "ignore": true,
+	"in": true, "index": true, "infile": true, "inner": true, "inout": true, "insensitive": true,
+	"insert": true, "int": true, "int1": true, "int2": true, "int3": true, "int4": true,
+	"int8": true, "integer": true, "intersect": true, "interval": true, "into": true,
+	"io_after_gtids": true, "io_before_gtids": true, "is": true, "iterate": true, "join": true,
+	"json_table": true, "key": true, "keys": true, "kill": true, "lag": true, "last_value": true,
+	"lateral": true, "lead": true, "leading": true, "leave": true, "left": true, "like": true,
+	"limit": true, "linear": true, "lines": true, "load": true, "localtime": true,
+	"localtimestamp": true, "lock": true, "long": true, "longblob": true, "longtext": true,
+	"loop": true, "low_priority": true, "master_bind": true, "master_ssl_verify_server_cert": true,
+	"match": true, "maxvalue": true, "mediumblob": true, "mediumint": true, "mediumtext": true,
+	"middleint": true, "minute_microsecond": true, "minute_second": true, "mod": true,
+	"modifies": true, "natural": true, "no_write_to_binlog": true, "not": true, "nth_value": true,
+	"ntile": true, "null": true, "numeric": true, "of": true, "on": true, "optimize": true,
+	"optimizer_costs": true, "option": true, "optionally": true, "or": true, "order": true,
+	"out": true, "outer": true, "outfile": true, "over": true, "partition": true,
+	"percent_rank": true, "precision": true, "primary": true, "procedure": true, "purge": true,
+	"range": true, "rank": true, "read": true, "read_write": true, "reads": true, "real": true,
+	"recursive": true, "references": true, "regexp": true, "release": true, "rename": true,
+	"repeat": true, "replace": true, "require": true, "resignal": true, "restrict": true,
+	"return": true, "revoke": true, "right": true, "rlike": true, "row": true, "row_number": true,
+	"rows": true, "schema": true, "schemas": true, "second_microsecond": true, "select": true,
+	"sensitive": true, "separator": true, "set": true, "show": true, "signal": true,
+	"smallint": true, "spatial": true, "specific": true, "sql": true, "sql_big_result": true,
+	"sql_calc_found_rows": true, "sql_small_result": true, "sqlexception": true, "sqlstate": true,
+	"sqlwarning": true, "ssl": true, "starting": true, "stored": true, "straight_join": true,
+	"system": true, "table": true, "terminated": true, "then": true, "tinyblob": true,
+	"tinyint": true, "tinytext": true, "to": true, "trailing": true, "trigger": true, "true": true,
+	"undo": true, "union": true, "unique": true, "unlock": true, "unsigned": true, "update": true,
+	"usage": true, "use": true, "using": true, "utc_date": true, "utc_time": true,
+	"utc_timestamp": true, "values": true, "varbinary": true, "varchar": true,
+	"varcharacter": true, "varying": true, "virtual": true, "when": true, "where": true,
+	"while": true, "window": true, "with": true, "write": true, "xor": true, "year_month": true,
+	"zerofill": true,
+}
+
+// sqliteReserved contains the keywords of SQLite, which are all quoted, since SQLite accepts
+// only some of them as identifiers depending on the context.
+var sqliteReserved = map[string]bool{
+	"abort": true, "action": true, "add": true, "after": true, "all": true, "alter": true,
+	"always": true, "analyze": true, "and": true, "as": true, "asc": true, "attach": true,
+	"autoincrement": true, "before": true, "begin": true, "between": true, "by": true,
+	"cascade": true, "case": true, "cast": true, "check": true, "collate": true, "column": true,
+	"commit": true, "conflict": true, "constraint": true, "create": true, "cross": true,
+	"current": true, "current_date": true, "current_time": true, "current_timestamp": true,
+	"database": true, "default": true, "deferrable": true, "deferred": true, "delete": true,
+	"desc": true, "detach": true, "distinct": true, "do": true, "drop": true, "each": true,
+	"else": true, "end": true, "escape": true, "except": true, "exclude": true, "exclusive": true,
+	"exists": true, "explain": true, "fail": true, "filter": true, "first": true,
+	"following": true, "for": true, "foreign": true, "from": true, "full": true, "generated": true,
+	"glob": true, "group": true, "groups": true, "having": true, "if": true, "ignore": true,
+	"immediate": true, "in": true, "index": true, "indexed": true, "initially": true,
+	"inner": true, "insert": true, "instead": true, "intersect": true, "into": true, "is": true,
+	"isnull": true, "join": true, "key": true, "last": true, "left": true, "like": true,
+	"limit": true, "match": true, "materialized": true, "natural": true, "no": true, "not": true,
+	"nothing": true, "notnull": true, "null": true, "nulls": true, "of": true, "offset": true,
+	"on": true, "or": true, "order": true, "others": true, "outer": true, "over": true,
+	"partition": true, "plan": true, "pragma": true, "preceding": true, "primary": true,
+	"query": true, "raise": true, "range": true, "recursive": true, "references": true,
+	"regexp": true, "reindex": true, "release": true, "rename": true, "replace": true,
+	"restrict": true, "returning": true, "right": true, "rollback": true, "row": true,
+	"rows": true, "savepoint": true, "select": true, "set": true, "table": true, "temp": true,
+	"temporary": true, "then": true, "ties": true, "to": true, "transaction": true,
+	"trigger": true, "unbounded": true, "union": true, "unique": true, "update": true,
+	"using": true, "vacuum": true, "values": true, "view": true, "virtual": true, "when": true,
+	"where": true, "window": true, "with": true, "without": true,
+}
+
+// sqlServerReserved contains the reserved keywords of Transact-SQL.
+var sqlServerReserved = map[string]bool{
+	"add": true, "all": true, "alter": true, "and": true, "any": true, "as": true, "asc": true,
+	"authorization": true, "backup": true, "begin": true, "between": true, "break": true,
+	"browse": true, "bulk": true, "by": true, "cascade": true, "case": true, "check": true,
+	"checkpoint": true, "close": true, "clustered": true, "coalesce": true, "collate": true,
+	"column": true, "commit": true, "compute": true, "constraint": true, "contains": true,
+	"containstable": true, "continue": true, "convert": true, "create": true, "cross": true,
+	"current": true, "current_date": true, "current_time": true, "current_timestamp": true,
+	"current_user": true, "cursor": true, "database": true, "dbcc": true, "deallocate": true,
+	"declare": true, "default": true, "delete": true, "deny": true, "desc": true, "disk": true,
+	"distinct": true, "distributed": true, "double": true, "drop": true, "dump": true,
+	"else": true, "end": true, "errlvl": true, "escape": true, "except": true, "exec": true,
+	"execute": true, "exists": true, "exit": true, "external": true, "fetch": true, "file": true,
+	"fillfactor": true, "for": true, "foreign": true, "freetext": true, "freetexttable": true,
+	"from": true, "full": true, "function": true, "goto": true, "grant": true, "group": true,
+	"having": true, "holdlock": true, "identity": true, "identity_insert": true,
+	"identitycol": true, "if": true, "in": true, "index": true, "inner": true, "insert": true,
+	"intersect": true, "into": true, "is": true, "join": true, "key": true, "kill": true,
+	"left": true, "like": true, "lineno": true, "load": true, "merge": true, "national": true,
+	"nocheck": true, "nonclustered": true, "not": true, "null": true, "nullif": true, "of": true,
+	"off": true, "offsets": true, "on": true, "open": true, "opendatasource": true,
+	"openquery": true, "openrowset": true, "openxml": true, "option": true, "or": true,
+	"order": true, "outer": true, "over": true, "percent": true, "pivot": true, "plan": true,
+	"precision": true, "primary": true, "print": true, "proc": true, "procedure": true,
+	"public": true, "raiserror": true, "read": true, "readtext": true, "reconfigure": true,
+	"references": true, "replication": true, "restore": true, "restrict": true, "return": true,
+	"revert": true, "revoke": true, "right": true, "rollback": true, "rowcount": true,
+	"rowguidcol": true, "rule": true, "save": true, "schema": true, "securityaudit": true,
+	"select": true, "semantickeyphrasetable": true, "semanticsimilaritydetailstable": true,
+	"semanticsimilaritytable": true, "session_user": true, "set": true, "setuser": true,
+	"shutdown": true, "some": true, "statistics": true, "system_user": true, "table": true,
+	"tablesample": true, "textsize": true, "then": true, "to": true, "top": true, "tran": true,
+	"transaction": true, "trigger": true, "truncate": true, "try_convert": true, "tsequal": true,
+	"union": true, "unique": true, "unpivot": true, "update": true, "updatetext": true,
+	"use": true, "user": true, "values": true, "varying": true, "view": true, "waitfor": true,
+	"when": true, "where": true, "while": true, "with": true, "writetext": true,
+}
+
+// oracleReserved contains the reserved words of Oracle Database, which are listed as reserved in
+// the V$RESERVED_WORDS view.
+var oracleReserved = map[string]bool{
+	"access": true, "add": true, "all": true, "alter": true, "and": true, "any": true, "as": true,
+	"asc": true, "audit": true, "between": true, "by": true, "char": true, "check": true,
+	"cluster": true, "column": true, "comment": true, "compress": true, "connect": true,
+	"create": true, "current": true, "date": true, "decimal": true, "default": true,
+	"delete": true, "desc": true, "distinct": true, "drop": true, "else": true, "exclusive": true,
+	"exists": true, "file": true, "float": true, "for": true, "from": true, "grant": true,
+	"group": true, "having": true, "identified": true, "immediate": true, "in": true,
+	"increment": true, "index": true, "initial": true, "insert": true, "integer": true,
+	"intersect": true, "into": true, "is": true, "level": true, "like": true, "lock": true,
+	"long": true, "maxextents": true, "minus": true, "mlslabel": true, "mode": true,
+	"modify": true, "noaudit": true, "nocompress": true, "not": true, "nowait": true,
+	"null": true, "number": true, "of": true, "offline": true, "on": true, "online": true,
+	"option": true, "or": true, "order": true, "pctfree": true, "prior": true, "public": true,
+	"raw": true, "rename": true, "resource": true, "revoke": true, "row": true, "rowid": true,
+	"rownum": true, "rows": true, "select": true, "session": true, "set": true, "share": true,
+	"size": true, "smallint": true, "start": true, "successful": true, "synonym": true,
+	"sysdate": true, "table": true, "then": true, "to": true, "trigger": true, "uid": true,
+	"union": true, "unique": true, "update": true, "user": true, "validate": true,
+	"values": true, "varchar": true, "varchar2": true, "view": true, "whenever": true,
+	"where": true, "with": true,
+}
+
+// db2Reserved contains the reserved words of Db2 for Linux, UNIX and Windows, which are the
+// words of the SQL standard reserved by Db2.
+var db2Reserved = map[string]bool{
+	"all": true, "allocate": true, "alter": true, "and": true, "any": true, "as": true,
+	"asensitive": true, "at": true, "begin": true, "between": true, "both": true, "by": true,
+	"call": true, "called": true, "cascaded": true, "case": true, "cast": true, "check": true,
+	"close": true, "collate": true, "column": true, "commit": true, "connect": true,
+	"constraint": true, "continue": true, "create": true, "cross": true, "current": true,
+	"current_date": true, "current_time": true, "current_timestamp": true, "current_user": true,
+	"cursor": true, "deallocate": true, "declare": true, "default": true, "delete": true,
+	"describe": true, "disconnect": true, "distinct": true, "do": true, "drop": true,
+	"else": true, "elseif": true, "end": true, "escape": true, "except": true, "execute": true,
+	"exists": true, "external": true, "fetch": true, "for": true, "foreign": true, "from": true,
+	"full": true, "function": true, "get": true, "grant": true, "group": true, "handler": true,
+	"having": true, "hold": true, "if": true, "immediate": true, "in": true, "inner": true,
+	"inout": true, "insensitive": true, "insert": true, "intersect": true, "into": true,
+	"is": true, "iterate": true, "join": true, "leave": true, "left": true, "like": true,
+	"loop": true, "merge": true, "not": true, "null": true, "of": true, "on": true, "open": true,
+	"or": true, "order": true, "out": true, "outer": true, "parameter": true, "prepare": true,
+	"primary": true, "procedure": true, "references": true, "release": true, "repeat": true,
+	"resignal": true, "return": true, "returns": true, "revoke": true, "right": true,
+	"rollback": true, "row": true, "rows": true, "savepoint": true, "select": true,
+	"sensitive": true, "session_user": true, "set": true, "signal": true, "some": true,
+	"specific": true, "sql": true, "start": true, "static": true, "table": true, "then": true,
+	"to": true, "trigger": true, "undo": true, "union": true, "unique": true, "until": true,
+	"update": true, "user": true, "using": true, "values": true, "when": true, "where": true,
+	"while": true, "with": true,
+}
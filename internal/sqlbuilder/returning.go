@@ -0,0 +1,97 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// returningStyle is the syntax of the returned rows of the writes of a dialect.
+type returningStyle int
+
+// Returned rows syntaxes.
+const (
+	returningClause      returningStyle = iota // RETURNING clauses at the end of writes, as in PostgreSQL and SQLite.
+	returningOutput                            // OUTPUT clauses of inserted or deleted columns, as in SQL Server.
+	returningUnsupported                       // No returned rows, as in MySQL.
+)
+
+// buildReturning translates the returned fields of a write to a RETURNING clause and its params,
+// for example "RETURNING id, created_at", which follows the other clauses of the statement.
+//
+// It returns an empty clause if no fields are returned, and for dialects with OUTPUT clauses,
+// see buildOutput. Dialects without returned rows, such as MySQL, skip the default selection of
+// all fields and reject other fields. It returns the clause, the updated parameter slice, and an
+// error if any.
+func buildReturning(fields []domain.Field, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check is returning fields
+	if len(fields) == 0 {
+		return "", params, nil
+	}
+
+	// returned rows syntax of dialect
+	switch returningStyleOf(d) {
+	case returningOutput:
+		return "", params, nil
+	case returningUnsupported:
+		if isAllFields(fields) {
+			return "", params, nil
+		}
+		return "", nil, fmt.Errorf("returned fields are not supported by the %s dialect", d.Name())
+	}
+
+	// create returning fields
+	returning, returningParams, err := buildSelects(fields, op, d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return clause
+	return "RETURNING " + returning, returningParams, nil
+}
+
+// buildOutput translates the returned fields of a write to an OUTPUT clause for dialects with
+// OUTPUT clauses, for example "OUTPUT INSERTED.id, INSERTED.created_at", which precedes the
+// values of inserts and the conditions of updates and deletes. The columns of deletes are
+// returned from the DELETED table, the columns of other writes from the INSERTED table.
+//
+// It returns an empty clause if no fields are returned and for other dialects, see
+// buildReturning, and an error if a field is computed, since the clause has no params.
+func buildOutput(fields []domain.Field, op domain.OperationType, d domain.Dialect) (string, error) {
+	// check is output clause
+	if len(fields) == 0 || returningStyleOf(d) != returningOutput {
+		return "", nil
+	}
+
+	// table of returned columns
+	prefix := "INSERTED."
+	if op == domain.OperationDelete {
+		prefix = "DELETED."
+	}
+
+	// create output columns
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		// check is column
+		if field.Expression != nil || field.Aggregation != domain.AggregationNone {
+			return "", errors.New("computed fields in OUTPUT clauses are not supported")
+		}
+
+		// add column
+		if field.DB == "*" {
+			columns[i] = prefix + "*"
+		} else {
+			columns[i] = prefix + getFieldName(&field, op, d)
+		}
+	}
+
+	// return clause
+	return "OUTPUT " + strings.Join(columns, ", "), nil
+}
+
+// isAllFields checks if the fields are the selection of all fields.
+func isAllFields(fields []domain.Field) bool {
+	return len(fields) == 1 && fields[0].DB == "*" && fields[0].Expression == nil
+}
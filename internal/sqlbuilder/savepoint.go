@@ -0,0 +1,43 @@
+package sqlbuilder
+
+import "github.com/tyrenix/qbr/domain"
+
+// savepointStyle is the syntax of the savepoints of transactions of a dialect.
+type savepointStyle int
+
+// Savepoint syntaxes.
+const (
+	savepointStandard      savepointStyle = iota // SAVEPOINT, ROLLBACK TO SAVEPOINT and RELEASE SAVEPOINT, as in PostgreSQL.
+	savepointNoRelease                           // SAVEPOINT and ROLLBACK TO SAVEPOINT without RELEASE SAVEPOINT, as in Oracle.
+	savepointRetainCursors                       // SAVEPOINT ... ON ROLLBACK RETAIN CURSORS, as in DB2.
+	savepointTransaction                         // SAVE TRANSACTION and ROLLBACK TRANSACTION without release, as in SQL Server.
+)
+
+// SavepointSql returns the statements creating the savepoint of the given name, rolling back
+// to it and releasing it in the dialect, for example "SAVEPOINT sp", "ROLLBACK TO SAVEPOINT
+// sp" and "RELEASE SAVEPOINT sp". The release statement is empty if the dialect does not
+// release savepoints, which is the case for Oracle and SQL Server.
+func SavepointSql(d domain.Dialect, name string) (create, rollback, release string) {
+	switch savepointStyleOf(d) {
+	case savepointNoRelease:
+		return "SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name, ""
+	case savepointRetainCursors:
+		return "SAVEPOINT " + name + " ON ROLLBACK RETAIN CURSORS", "ROLLBACK TO SAVEPOINT " + name, "RELEASE SAVEPOINT " + name
+	case savepointTransaction:
+		return "SAVE TRANSACTION " + name, "ROLLBACK TRANSACTION " + name, ""
+	default:
+		return "SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name, "RELEASE SAVEPOINT " + name
+	}
+}
+
+// savepointStyleOf returns the syntax of the savepoints of the dialect, which is the standard
+// syntax for custom dialects.
+func savepointStyleOf(d domain.Dialect) savepointStyle {
+	// check is dialect of query builder
+	if qd, ok := builtinDialect(d); ok {
+		return qd.savepoint
+	}
+
+	// return default syntax
+	return savepointStandard
+}
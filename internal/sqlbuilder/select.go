@@ -1,73 +1,360 @@
 package sqlbuilder
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/tyrenix/qbr/domain"
 )
 
-// CreateSelectSql creates a SQL SELECT query from the Query's select list, conditions,
-// sort, limit, and offset. It returns the query string, the parameters for the query,
+// CreateSelectSql creates a SQL SELECT query from the Query's common table expressions, select list, FROM source or table,
+// joins, conditions, groups, set operations, sort, limit, offset and locking clause in the given dialect. The params of the query are
+// appended to the given params, which are the params of the enclosing statement of subqueries
+// and nil otherwise. It returns the query string, the parameters for the query,
 // and an error if the query could not be built.
-func CreateSelectSql(qb Query, table string, placeholder domain.SqlPlaceholder) (string, []any, error) {
+func CreateSelectSql(qb Query, table string, d domain.Dialect, params []any) (string, []any, error) {
+	// create with clause
+	with, params, err := buildWith(qb.GetCTEs(), d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// create distinct clause
+	distinct, params, err := buildDistinct(qb, d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// create select query
+	selects, params, err := buildSelects(qb.GetSelects(), qb.GetOperation(), d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// create source, or add the index hints of the table
+	if from := qb.GetFrom(); from != nil {
+		table, params, err = buildSource(*from, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+	} else {
+		hints, err := buildIndexHints(qb.GetIndexHints(), d)
+		if err != nil {
+			return "", nil, err
+		}
+		table += hints
+	}
+
+	// create limit and offset, limits without offset are rendered as TOP in SQL Server, except
+	// for the limits of set operations
+	top, limitOffset := "", d.LimitOffset(qb.GetLimit(), qb.GetOffset())
+	if qb.GetLimit() > 0 && qb.GetOffset() == 0 && len(qb.GetCompounds()) == 0 && limitsByTop(d) {
+		top, limitOffset = fmt.Sprintf("TOP (%d) ", qb.GetLimit()), ""
+	}
+
 	// create main query
-	query := fmt.Sprintf(
-		"SELECT %s FROM %s",
-		buildSelects(qb.GetSelects()), // create select query
-		table,
-	)
+	query := fmt.Sprintf("%sSELECT %s%s%s%s FROM %s", with, buildTimeoutHint(qb, d), distinct, top, selects, table)
 
-	// create params
-	var params []any
+	// add joins
+	if joins := qb.GetJoins(); len(joins) > 0 {
+		// create joins
+		clause, joinParams, err := buildJoins(joins, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add joins and params
+		query += " " + clause
+		params = joinParams
+	}
 
 	// conditionals
 	conds := qb.GetConditions()
 	// sorts
 	sorts := qb.GetSort()
-	// limit
-	limit := qb.GetLimit()
-	// offset
-	offset := qb.GetOffset()
 
 	// is conditions exists add conditions and params
 	if len(conds) > 0 {
 		// create conditions
-		cond, condParams, err := buildConditions(conds, placeholder, nil)
+		cond, condParams, err := buildConditions(conds, qb.GetOperation(), d, params)
 		if err != nil {
 			return "", nil, err
 		}
 
 		// add conditions
-		query += " WHERE " + cond
-		params = append(params, condParams...)
+		if cond != "" {
+			query += " WHERE " + cond
+		}
+		params = condParams
+	}
+
+	// add groups
+	if groups := qb.GetGroupBy(); len(groups) > 0 {
+		// create groups
+		group, groupParams, err := buildGroupBy(groups, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add groups and params
+		query += " GROUP BY " + group
+		params = groupParams
+	}
+
+	// add having conditions
+	if having := qb.GetHaving(); len(having) > 0 {
+		// create conditions
+		cond, condParams, err := buildConditions(having, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add conditions
+		if cond != "" {
+			query += " HAVING " + cond
+		}
+		params = condParams
+	}
+
+	// add set operations
+	if compounds := qb.GetCompounds(); len(compounds) > 0 {
+		// create set operations
+		clause, compoundParams, err := buildCompounds(compounds, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add set operations and params
+		query += " " + clause
+		params = compoundParams
 	}
 
 	// add sort
 	if len(sorts) > 0 {
-		// order by query string
-		orderByStr := " ORDER BY"
-
 		// create order by
-		sortClauses := make([]string, len(sorts))
-		for i, sort := range sorts {
-			sortClauses[i] = fmt.Sprintf(
-				"%s %s",
-				getFieldName(sort.Field),
-				sort.Type,
-			)
+		orderBy, sortParams, err := buildSorts(sorts, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
 		}
 
-		// add order by
-		query += orderByStr + " " + strings.Join(sortClauses, ", ")
+		// add order by and params
+		query += " ORDER BY " + orderBy
+		params = sortParams
 	}
 
 	// add limit and offset
-	if v := buildLimitAndOffset(limit, offset); v != "" {
+	if limitOffset != "" {
+		// check is order set for OFFSET clauses, which require an order in SQL Server
+		if len(sorts) == 0 && OffsetsOrdered(d) {
+			return "", nil, fmt.Errorf("%w: OFFSET and FETCH clauses require ORDER BY in the %s dialect", ErrOffsetWithoutOrder, d.Name())
+		}
+
 		// add limit and offset
-		query += " " + v
+		query += " " + limitOffset
+	}
+
+	// add locking clause
+	if lock := qb.GetLock(); lock != nil && lock.Strength != "" {
+		// check is locking clause supported
+		if err := CheckLock(lock, d); err != nil {
+			return "", nil, err
+		}
+
+		// add lock
+		query += " FOR " + string(lock.Strength)
+		if len(lock.Of) > 0 {
+			of := make([]string, len(lock.Of))
+			for i, table := range lock.Of {
+				of[i] = d.QuoteIdent(table)
+			}
+			query += " OF " + strings.Join(of, ", ")
+		}
+		if lock.Wait != domain.LockWaitDefault {
+			query += " " + string(lock.Wait)
+		}
 	}
 
 	// return query, params and success
 	return query, params, nil
 }
+
+// buildGroupBy translates the fields of a GROUP BY clause to a SQL string and its params, which
+// are the field names or the expressions of computed fields.
+// It returns the SQL string, the updated parameter slice, and an error if any.
+func buildGroupBy(fields []domain.Field, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// groups
+	groups := make([]string, len(fields))
+
+	// create groups
+	for i, field := range fields {
+		group, groupParams, err := buildFieldExpression(&field, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		groups[i] = group
+		params = groupParams
+	}
+
+	// return groups
+	return strings.Join(groups, ", "), params, nil
+}
+
+// buildDistinct translates the DISTINCT or DISTINCT ON clause of the query to SQL followed by a
+// space and its params, or an empty string if the query has no distinct clause.
+//
+// Returns an error if the query has a DISTINCT ON clause and the dialect does not support it.
+func buildDistinct(qb Query, d domain.Dialect, params []any) (string, []any, error) {
+	// check is distinct on
+	fields := qb.GetDistinctOn()
+	if len(fields) == 0 {
+		if qb.GetDistinct() {
+			return "DISTINCT ", params, nil
+		}
+		return "", params, nil
+	}
+
+	// check is distinct on supported
+	if !supportsDistinctOn(d) {
+		return "", nil, fmt.Errorf("DISTINCT ON is not supported by the %s dialect", d.Name())
+	}
+
+	// create fields, which are rendered as groups
+	on, params, err := buildGroupBy(fields, qb.GetOperation(), d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return distinct on
+	return "DISTINCT ON (" + on + ") ", params, nil
+}
+
+// supportsDistinctOn checks if the dialect supports DISTINCT ON, which is the case for PostgreSQL
+// and custom dialects only.
+func supportsDistinctOn(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return !ok || qd.distinctOn
+}
+
+// buildSorts translates the sorts to the list of the ORDER BY clause and its params, with the
+// expression of computed fields in place of their names. The order of NULL values is rendered
+// as NULLS FIRST or NULLS LAST, or in dialects without them by a preceding sort by a CASE
+// expression which is 0 for the values sorted first, for example "CASE WHEN name IS NULL THEN 1
+// ELSE 0 END, name ASC" for NULLS LAST.
+//
+// The function returns the sort list, the updated parameter slice, and an error if any.
+func buildSorts(sorts []domain.Sort, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// sort clauses
+	clauses := make([]string, 0, len(sorts))
+	for _, sort := range sorts {
+		// add sort by null values in dialects without order of null values, the expression of
+		// computed fields is created for both sorts, since params are not shared by placeholders
+		if sort.Nulls != domain.NullsDefault && !supportsNullsOrder(d) {
+			// create sort field
+			name, sortParams, err := buildSortField(sort.Field, op, d, params)
+			if err != nil {
+				return "", nil, err
+			}
+			params = sortParams
+
+			// add sort by null values, which is 0 for the values sorted first
+			first, rest := 1, 0
+			if sort.Nulls == domain.NullsFirst {
+				first, rest = 0, 1
+			}
+			clauses = append(clauses, fmt.Sprintf("CASE WHEN %s IS NULL THEN %d ELSE %d END", name, first, rest))
+		}
+
+		// create sort field
+		name, sortParams, err := buildSortField(sort.Field, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		params = sortParams
+
+		// sort clause with order of null values
+		clause := fmt.Sprintf("%s %s", name, sort.Type)
+		if sort.Nulls != domain.NullsDefault && supportsNullsOrder(d) {
+			clause += " NULLS " + string(sort.Nulls)
+		}
+
+		// add sort clause
+		clauses = append(clauses, clause)
+	}
+
+	// return sort list and params
+	return strings.Join(clauses, ", "), params, nil
+}
+
+// buildSortField translates the field of a sort to its name, or to the expression of computed
+// fields, and the params of the expression.
+func buildSortField(field *domain.Field, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check is computed field
+	if field.Expression == nil {
+		return getFieldName(field, op, d), params, nil
+	}
+
+	// create computed field expression
+	return buildFieldExpression(field, op, d, params)
+}
+
+// supportsNullsOrder checks if the dialect supports NULLS FIRST and NULLS LAST in sorts, which is
+// not the case for MySQL and SQL Server.
+func supportsNullsOrder(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return !ok || !qd.noNulls
+}
+
+// supportsLock checks if the locking clause of the strength is supported by the dialect,
+// SQLite and SQL Server have no locking clauses, and Oracle and DB2 have no FOR SHARE.
+func supportsLock(d domain.Dialect, strength domain.LockStrength) bool {
+	qd, ok := builtinDialect(d)
+	return !ok || !qd.noLocks && (strength != domain.LockShare || !qd.noShareLocks)
+}
+
+// ErrInvalidLock is returned for locking clauses which are invalid or not supported by the
+// dialect, see CheckLock.
+var ErrInvalidLock = errors.New("invalid locking clause")
+
+// CheckLock checks if the dialect supports the strength, the locked tables and the wait policy
+// of the locking clause, see supportsLock. Oracle and DB2 lock the rows of columns instead of
+// tables by OF, including aliased tables, and DB2 has neither NOWAIT nor SKIP LOCKED.
+//
+// Returns nil, or an error wrapping ErrInvalidLock if the locking clause is not supported.
+func CheckLock(lock *domain.Lock, d domain.Dialect) error {
+	// check is lock strength supported
+	if !supportsLock(d, lock.Strength) {
+		return fmt.Errorf("%w: FOR %s is not supported by the %s dialect", ErrInvalidLock, lock.Strength, d.Name())
+	}
+
+	// check are locked tables and wait policy supported
+	qd, ok := builtinDialect(d)
+	switch {
+	case !ok:
+		return nil
+	case len(lock.Of) > 0 && qd.noLockOf:
+		return fmt.Errorf("%w: FOR %s OF tables is not supported by the %s dialect", ErrInvalidLock, lock.Strength, d.Name())
+	case lock.Wait != domain.LockWaitDefault && qd.noLockWaits:
+		return fmt.Errorf("%w: %s is not supported by the %s dialect", ErrInvalidLock, lock.Wait, d.Name())
+	default:
+		return nil
+	}
+}
+
+// limitsByTop checks if the limits of select queries without offset are rendered as TOP, which
+// is the case for SQL Server.
+func limitsByTop(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return ok && qd.top
+}
+
+// ErrOffsetWithoutOrder is returned for offsets of queries without sort parameters in
+// dialects whose OFFSET clauses require an ORDER BY clause, see OffsetsOrdered.
+var ErrOffsetWithoutOrder = errors.New("offset without order")
+
+// OffsetsOrdered checks if the OFFSET clauses of the dialect require an ORDER BY clause, which
+// is the case for SQL Server.
+func OffsetsOrdered(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return ok && qd.orderedOffset
+}
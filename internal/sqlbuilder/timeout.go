@@ -0,0 +1,63 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// timeoutStyle is the syntax of the statement timeouts of a dialect.
+type timeoutStyle int
+
+// Statement timeout syntaxes.
+const (
+	timeoutUnsupported timeoutStyle = iota // No statement timeouts.
+	timeoutSetLocal                        // SET LOCAL statement_timeout in transactions, as in PostgreSQL.
+	timeoutHint                            // MAX_EXECUTION_TIME optimizer hints of SELECT statements, as in MySQL.
+)
+
+// StatementTimeoutSql returns the statement setting the timeout as the statement timeout of the
+// transaction, for example "SET LOCAL statement_timeout = 500", or an empty string if the
+// dialect has no such statement, which is the case for other dialects than PostgreSQL.
+func StatementTimeoutSql(d domain.Dialect, timeout time.Duration) string {
+	// check is supported
+	if timeoutStyleOf(d) != timeoutSetLocal || timeout <= 0 {
+		return ""
+	}
+
+	// return statement
+	return fmt.Sprintf("SET LOCAL statement_timeout = %d", timeoutMillis(timeout))
+}
+
+// buildTimeoutHint returns the optimizer hint of the statement timeout of the query, for
+// example "/*+ MAX_EXECUTION_TIME(500) */ ", which follows the SELECT keyword, or an empty
+// string if the query has no statement timeout or the dialect has no such hint.
+func buildTimeoutHint(qb Query, d domain.Dialect) string {
+	// check is supported
+	timeout := qb.GetStatementTimeout()
+	if timeoutStyleOf(d) != timeoutHint || timeout <= 0 {
+		return ""
+	}
+
+	// return hint
+	return fmt.Sprintf("/*+ MAX_EXECUTION_TIME(%d) */ ", timeoutMillis(timeout))
+}
+
+// timeoutMillis returns the timeout in milliseconds, rounded up, so timeouts below a
+// millisecond do not disable the statement timeout.
+func timeoutMillis(timeout time.Duration) int64 {
+	return int64((timeout + time.Millisecond - 1) / time.Millisecond)
+}
+
+// timeoutStyleOf returns the syntax of the statement timeouts of the dialect, which has no
+// statement timeouts for custom dialects.
+func timeoutStyleOf(d domain.Dialect) timeoutStyle {
+	// check is dialect of query builder
+	if qd, ok := builtinDialect(d); ok {
+		return qd.timeout
+	}
+
+	// return default syntax
+	return timeoutUnsupported
+}
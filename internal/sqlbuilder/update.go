@@ -7,64 +7,113 @@ import (
 	"github.com/tyrenix/qbr/domain"
 )
 
-// CreateUpdateSql creates a SQL UPDATE query from the Query's data. It returns the query string,
-// the parameters for the query, and an error if the query could not be built.
-func CreateUpdateSql(qb Query, table string, placeholder domain.SqlPlaceholder) (string, []any, error) {
+// CreateUpdateSql creates a SQL UPDATE query from the Query's common table expressions, data and
+// joined sources, see usingStyle. The params of the query are appended to the given params, which are the params of the
+// enclosing statement and nil otherwise. It returns the query string, the parameters for the
+// query, and an error if the query could not be built.
+func CreateUpdateSql(qb Query, table string, d domain.Dialect, params []any) (string, []any, error) {
 	var sets []string
-	var params []any
+
+	// create with clause
+	with, params, err := buildWith(qb.GetCTEs(), d, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// joined sources
+	sources := qb.GetUsing()
+	style := usingStyleOf(d)
+
+	// create joined sources of multi-table syntax, which precede the set data
+	tables := table
+	if len(sources) > 0 && style == usingMultiTable {
+		using, usingParams, err := buildUsing(sources, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		tables += ", " + using
+		params = usingParams
+	}
 
 	// create base query
-	query := fmt.Sprintf("UPDATE %s SET ", table)
+	query := fmt.Sprintf("%sUPDATE %s SET ", with, tables)
 
 	// select fields
 	selects := qb.GetSelects()
-	// conditionals
-	conds := qb.GetConditions()
+	// conditionals, without empty logical conditions
+	conds := domain.PruneConditions(qb.GetConditions())
 	// data
 	setData := qb.GetData()
 
 	// create add update params
 	for _, data := range setData {
+		// create value
+		value, valueParams, err := buildDataValue(data, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
 		// add data to sets
 		sets = append(
 			sets,
-			fmt.Sprintf("%s = %s",
-				getFieldName(data.Field),
-				getPlaceholder(placeholder, len(params)+1),
-			),
+			fmt.Sprintf("%s = %s", getFieldName(data.Field, qb.GetOperation(), d), value),
 		)
 
-		// create database value
-		v, err := valueToDBValue(data.Value)
-		if err != nil {
-			return "", nil, err
-		}
-
 		// add params
-		params = append(params, v)
+		params = valueParams
 	}
 
 	// add to query set data
 	query += strings.Join(sets, ", ")
 
+	// add output clause
+	output, err := buildOutput(selects, qb.GetOperation(), d)
+	if err != nil {
+		return "", nil, err
+	}
+	if output != "" {
+		query += " " + output
+	}
+
+	// add joined sources as FROM clause, which repeats the table in SQL Server
+	if len(sources) > 0 && style != usingMultiTable {
+		// create joined sources
+		using, usingParams, err := buildUsing(sources, qb.GetOperation(), d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		if style == usingRepeatedTable {
+			using = table + ", " + using
+		}
+
+		// add joined sources and params
+		query += " FROM " + using
+		params = usingParams
+	}
+
 	// if exists conditions add to query
 	if len(conds) > 0 {
 		// create conditions
-		conds, condsParams, err := buildConditions(conds, placeholder, params)
+		conds, condsParams, err := buildConditions(conds, qb.GetOperation(), d, params)
 		if err != nil {
 			return "", nil, err
 		}
 
 		// add conditions to query
-		query += " WHERE " + conds
+		if conds != "" {
+			query += " WHERE " + conds
+		}
 		// add condition params to params
 		params = condsParams
 	}
 
 	// build returning fields
-	if len(selects) > 0 {
-		// create returning fields
-		query += " RETURNING " + buildSelects(selects)
+	returning, params, err := buildReturning(selects, qb.GetOperation(), d, params)
+	if err != nil {
+		return "", nil, err
+	}
+	if returning != "" {
+		query += " " + returning
 	}
 
 	// return query, params and success
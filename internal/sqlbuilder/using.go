@@ -0,0 +1,48 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// usingStyle is the syntax of the sources joined into the updates and deletes of a dialect.
+type usingStyle int
+
+// Joined sources syntaxes.
+const (
+	usingFrom          usingStyle = iota // UPDATE ... FROM and DELETE ... USING, as in PostgreSQL.
+	usingUpdateFrom                      // UPDATE ... FROM without DELETE ... USING, as in SQLite.
+	usingMultiTable                      // UPDATE t, s SET and DELETE t FROM t, s, as in MySQL.
+	usingRepeatedTable                   // UPDATE t SET ... FROM t, s and DELETE t FROM t, s, as in SQL Server.
+	usingUnsupported                     // No joined sources.
+)
+
+// buildUsing translates the sources joined into an update or delete to a SQL string and its
+// params, for example "users, (SELECT ...) AS t", after checking that the dialect supports them
+// for the operation. The params of subqueries are appended in their order.
+// It returns the SQL string, the updated parameter slice, and an error if any.
+func buildUsing(sources []domain.Source, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
+	// check is supported
+	switch style := usingStyleOf(d); {
+	case style == usingUnsupported && op == domain.OperationUpdate:
+		return "", nil, fmt.Errorf("UPDATE ... FROM is not supported by the %s dialect", d.Name())
+	case (style == usingUnsupported || style == usingUpdateFrom) && op == domain.OperationDelete:
+		return "", nil, fmt.Errorf("DELETE ... USING is not supported by the %s dialect", d.Name())
+	}
+
+	// create sources
+	clauses := make([]string, len(sources))
+	for i, source := range sources {
+		clause, sourceParams, err := buildSource(source, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+		clauses[i] = clause
+		params = sourceParams
+	}
+
+	// return sources
+	return strings.Join(clauses, ", "), params, nil
+}
@@ -1,45 +1,38 @@
 package sqlbuilder
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/array"
+	"github.com/tyrenix/qbr/internal/uuid"
 )
 
-// getSqlOperator returns the SQL operator associated with the given OperatorType.
-// If the operator is not supported, it returns an empty string.
-func getSqlOperator(op domain.OperatorType) string {
-	// get operator
-	v, ok := sqlOperators[op]
-	if !ok {
-		return ""
-	}
-
-	// return operator
-	return v
-}
-
 // getFieldName takes a Field object and returns the string value of its DB
-// field. This is the field name in the database that the field corresponds to.
-func getFieldName(field *domain.Field) string {
-	return string(field.DB)
+// field for the given operation. This is the field name in the database that
+// the field corresponds to, overridden by the field's column for the operation
+// if one is set, quoted by the dialect if needed, see domain.Dialect.QuoteIdent.
+func getFieldName(field *domain.Field, op domain.OperationType, d domain.Dialect) string {
+	return quoteField(field, field.Column(op), d)
 }
 
-// getPlaceholder generates a SQL placeholder string based on the specified
-// placeholder type and index. If the placeholder type is ToSqlDollar, it returns
-// a parameterized string using the dollar sign format (e.g., $1, $2). Otherwise,
-// it returns the placeholder type as a string.
-func getPlaceholder(plc domain.SqlPlaceholder, index int) string {
-	// dollar placeholder
-	if plc == domain.SqlDollar {
-		return fmt.Sprintf("$%d", index)
+// getPlaceholder generates a SQL placeholder string based on the placeholder type
+// of the dialect and index. If the placeholder type is SqlDollar, SqlAtP or SqlColon, it
+// returns a numbered string (e.g., $1, $2, @p1, @p2 or :1, :2). Otherwise, it returns the
+// placeholder type as a string.
+func getPlaceholder(d domain.Dialect, index int) string {
+	// numbered placeholder
+	switch plc := d.Placeholder(); plc {
+	case domain.SqlDollar, domain.SqlAtP, domain.SqlColon:
+		return fmt.Sprintf("%s%d", plc, index)
+	default:
+		return string(plc)
 	}
-
-	// return default placeholder
-	return string(plc)
 }
 
 // valueToDBValue takes a value and returns a value that can be used in a SQL query
@@ -48,12 +41,29 @@ func getPlaceholder(plc domain.SqlPlaceholder, index int) string {
 // If the value is of type ValueType, it returns the string "NULL" if the value
 // is ValueNull, otherwise it returns an error.
 //
-// If the value is a struct or a pointer to a struct, it converts the value to
-// a JSON string and returns it.
+// If a converter is registered for the type of the value, or the type pointed to,
+// it returns the converted value, see RegisterConverter.
 //
-// If the value is not a struct or a pointer to a struct, it returns the original
-// value.
+// If the value is a pointer to a struct other than time.Time which does not
+// implement driver.Valuer, it converts the value to a JSON string and returns it.
+//
+// If the value is a slice other than a byte slice, it returns the slice bound as an
+// array, see array.Array. UUIDs stored in 16 byte arrays which do not implement
+// driver.Valuer are bound in the text format, see uuid.UUID.
+//
+// Otherwise, it returns the original value.
 func valueToDBValue(value any) (any, error) {
+	// check is array without registered converter
+	if _, ok := converters.lookup(value); !ok && array.IsArray(value) {
+		return array.Array{V: value}, nil
+	}
+
+	// convert value
+	return convertValue(value)
+}
+
+// convertValue converts the value as valueToDBValue, but returns slices as they are.
+func convertValue(value any) (any, error) {
 	// is value is ValueType
 	if v, ok := value.(domain.ValueType); ok {
 		// is null value return null
@@ -65,12 +75,25 @@ func valueToDBValue(value any) (any, error) {
 		return nil, fmt.Errorf("unsupported value type: %d", v)
 	}
 
+	// convert value with registered converter
+	if result, ok, err := convertRegistered(value); ok {
+		return result, err
+	}
+
 	// get reflect value
 	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return nil, nil
+	}
 
-	// check if the value is a struct or a pointer to a struct
-	if v.Kind() == reflect.Ptr &&
-		v.Elem().Kind() == reflect.Struct {
+	// check if the value is a UUID or a pointer to a UUID without own binding
+	if _, ok := value.(driver.Valuer); !ok && (uuid.IsUUID(v.Type()) || v.Kind() == reflect.Ptr && uuid.IsUUID(v.Type().Elem())) {
+		return uuid.UUID{V: value}, nil
+	}
+
+	// check if the value is a struct or a pointer to a struct without own binding
+	if _, ok := value.(driver.Valuer); !ok && v.Kind() == reflect.Ptr &&
+		v.Elem().Kind() == reflect.Struct && v.Elem().Type() != reflect.TypeFor[time.Time]() {
 		// convert struct to JSON
 		j, err := json.Marshal(v.Elem().Interface())
 		if err != nil {
@@ -85,34 +108,88 @@ func valueToDBValue(value any) (any, error) {
 	return value, nil
 }
 
-// buildSelectFields formats a slice of Field objects into a SQL select statement string.
+// buildSelects formats a slice of Field objects into a SQL select statement string.
 // It iterates over the provided fields, and for each field, it checks if there is an
-// associated SQL format in the sqlFieldFormats map based on the field's type. If a format
-// exists, it retrieves the database field name and applies the format, adding the result
-// to the list of select fields. The function returns a comma-separated string of the
-// formatted select fields.
-func buildSelects(fields []domain.Field) string {
+// associated SQL format in the sqlAggregationFormats map based on the field's aggregation.
+// If a format exists, it applies the format to the database field name, or to the
+// expression of computed fields followed by their alias, adding the result to the list
+// of select fields. The field names are resolved for the given operation, and the
+// literals of expressions are appended to params using the placeholder of the dialect d.
+// The function returns a comma-separated string of the formatted select fields, the
+// updated parameter slice, and an error if any.
+func buildSelects(fields []domain.Field, op domain.OperationType, d domain.Dialect, params []any) (string, []any, error) {
 	// fields
 	var result []string
 
 	// iterate over the slice of fields
 	for _, field := range fields {
 		// check is contains in map
-		format, ok := sqlAggregationFormats[field.Aggregation]
-		if !ok {
+		if _, ok := sqlAggregationFormats[field.Aggregation]; !ok {
 			continue
 		}
 
+		// create field
+		str, fieldParams, err := buildFieldExpression(&field, op, d, params)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// add alias of computed field
+		if field.Expression != nil {
+			str += " AS " + quoteField(&field, field.DB, d)
+		}
+
 		// append the formatted field to the result slice
-		result = append(
-			result,
-			fmt.Sprintf(
-				format,
-				getFieldName(&field), // get database field name
-			), // create field name
-		)
+		result = append(result, str)
+		params = fieldParams
 	}
 
 	// return the fields as a comma-separated string
-	return strings.Join(result, ", ")
+	return strings.Join(result, ", "), params, nil
+}
+
+// DBValue converts the value to a value that can be used in a SQL query, see valueToDBValue.
+// Slices are returned as they are, for drivers binding them natively.
+func DBValue(value any) (any, error) {
+	return convertValue(value)
+}
+
+// FieldValue converts the value of the field to a value that can be used in a SQL query,
+// see DBValue. Values of JSON fields are marshalled to JSON strings, nil values are NULL.
+func FieldValue(field *domain.Field, value any) (any, error) {
+	// check is json field
+	if field == nil || !field.JSON {
+		return DBValue(value)
+	}
+
+	// check is nil
+	if v, ok := value.(domain.ValueType); ok && v == domain.ValueNull || isNilValue(value) {
+		return nil, nil
+	}
+
+	// marshal value
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("marshal field %s: %w", field.DB, err)
+	}
+
+	// return json string
+	return string(data), nil
+}
+
+// isNilValue checks if the value is nil, including nil pointers, maps and slices.
+func isNilValue(value any) bool {
+	// check is nil
+	if value == nil {
+		return true
+	}
+
+	// check is nil reference
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
 }
@@ -0,0 +1,55 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// buildWith translates the common table expressions to the WITH clause of a statement and its
+// params, for example "WITH recent AS (SELECT ...) ". The clause is "WITH RECURSIVE" if any of
+// the expressions is recursive, except in SQL Server, which has no RECURSIVE keyword. The
+// params of the expressions are appended in their order.
+// It returns the clause with a trailing space or an empty string without expressions, the
+// updated parameter slice, and an error if any.
+func buildWith(ctes []domain.CTE, d domain.Dialect, params []any) (string, []any, error) {
+	// check is expressions set
+	if len(ctes) == 0 {
+		return "", params, nil
+	}
+
+	// expressions
+	exprs := make([]string, len(ctes))
+	recursive := false
+
+	// create expressions
+	for i, cte := range ctes {
+		// create subquery
+		sub, subParams, err := cte.Subquery.BuildSubquery(d, params)
+		if err != nil {
+			return "", nil, fmt.Errorf("common table expression %s: %w", cte.Name, err)
+		}
+		params = subParams
+
+		// add expression
+		exprs[i] = d.QuoteIdent(cte.Name) + " AS (" + sub + ")"
+		recursive = recursive || cte.Recursive
+	}
+
+	// create clause
+	clause := "WITH "
+	if recursive && supportsRecursiveKeyword(d) {
+		clause = "WITH RECURSIVE "
+	}
+
+	// return clause
+	return clause + strings.Join(exprs, ", ") + " ", params, nil
+}
+
+// supportsRecursiveKeyword checks if the dialect marks recursive common table expressions with
+// the RECURSIVE keyword, which is not the case for SQL Server.
+func supportsRecursiveKeyword(d domain.Dialect) bool {
+	qd, ok := builtinDialect(d)
+	return !ok || !qd.noRecursive
+}
@@ -0,0 +1,155 @@
+// Package uuid generates, binds and scans UUIDs stored in 16 byte arrays, such as the
+// UUID type of github.com/google/uuid, without depending on a UUID package.
+package uuid
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+// UUID is a UUID bound in the text format, and scanned from the text or the binary format.
+type UUID struct {
+	V any // UUID for binding, or pointer to UUID or to pointer to UUID for scanning.
+}
+
+// IsUUID checks if the type is a 16 byte array, which is bound and scanned as a UUID.
+func IsUUID(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
+
+// New returns a random UUID of version 4.
+func New() ([16]byte, error) {
+	// random bytes
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return b, fmt.Errorf("generate uuid: %w", err)
+	}
+
+	// set version and variant
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	// return uuid
+	return b, nil
+}
+
+// Format returns the UUID in the text format, for example "6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+func Format(b [16]byte) string {
+	// encode groups
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+
+	// return uuid string
+	return string(buf)
+}
+
+// Parse parses the UUID in the text format, with or without hyphens.
+func Parse(s string) ([16]byte, error) {
+	// uuid bytes
+	var b [16]byte
+
+	// remove hyphens
+	if len(s) == 36 {
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return b, fmt.Errorf("invalid uuid: %q", s)
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	}
+
+	// decode bytes
+	if len(s) != 32 {
+		return b, fmt.Errorf("invalid uuid: %q", s)
+	}
+	if _, err := hex.Decode(b[:], []byte(s)); err != nil {
+		return b, fmt.Errorf("invalid uuid: %q", s)
+	}
+
+	// return uuid
+	return b, nil
+}
+
+// Value returns the UUID in the text format, or nil for a nil pointer.
+func (u UUID) Value() (driver.Value, error) {
+	// uuid value
+	v := reflect.ValueOf(u.V)
+
+	// dereference pointer
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	// check is uuid
+	if !IsUUID(v.Type()) {
+		return nil, fmt.Errorf("unsupported uuid type: %T", u.V)
+	}
+
+	// return uuid string
+	var b [16]byte
+	reflect.Copy(reflect.ValueOf(b[:]), v)
+	return Format(b), nil
+}
+
+// Scan scans the UUID from the text format or the 16 bytes of the binary format, NULL
+// values set the zero value.
+func (u *UUID) Scan(src any) error {
+	// destination value
+	dest := reflect.ValueOf(u.V)
+	if dest.Kind() != reflect.Ptr || dest.IsNil() {
+		return fmt.Errorf("unsupported uuid destination: %T", u.V)
+	}
+	dest = dest.Elem()
+
+	// check is null
+	if src == nil {
+		dest.SetZero()
+		return nil
+	}
+
+	// parse source
+	var b [16]byte
+	var err error
+	switch src := src.(type) {
+	case []byte:
+		if len(src) == 16 {
+			copy(b[:], src)
+		} else {
+			b, err = Parse(string(src))
+		}
+	case string:
+		b, err = Parse(src)
+	default:
+		return fmt.Errorf("unsupported uuid source: %T", src)
+	}
+	if err != nil {
+		return err
+	}
+
+	// allocate pointer destination
+	if dest.Kind() == reflect.Ptr {
+		dest.Set(reflect.New(dest.Type().Elem()))
+		dest = dest.Elem()
+	}
+
+	// check is uuid
+	if !IsUUID(dest.Type()) {
+		return fmt.Errorf("unsupported uuid destination: %T", u.V)
+	}
+
+	// set uuid
+	reflect.Copy(dest, reflect.ValueOf(b[:]))
+	return nil
+}
@@ -0,0 +1,279 @@
+package qbr
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// aliasPattern matches the aliases of tables and subqueries.
+var aliasPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// As returns the source of the table or subquery with the given alias, for joining a table more
+// than once or joining a subquery, which requires an alias, for example:
+//
+//	qb.LeftJoin(As("users", "manager"), Eq(NewField(WithDB("manager.id")), managerID))
+//
+// The source is a table name or a subquery, such as a read *Query or *TypedQuery.
+func As(source any, alias string) domain.Source {
+	// check is table
+	if table, ok := source.(string); ok {
+		return domain.Source{Table: table, Alias: alias}
+	}
+
+	// check is subquery
+	if sub, ok := source.(domain.Subquery); ok {
+		return domain.Source{Subquery: sub, Alias: alias}
+	}
+
+	// return source without table
+	return domain.Source{Alias: alias}
+}
+
+// Qualify returns a copy of the field whose columns are qualified with the given table or
+// alias, for example "o.total", for columns of joined tables which share their names:
+//
+//	qb.Join(As("orders", "o"), Eq(Qualify("o", FieldOf[Order]("user_id")), Qualify("users", id)))
+//
+// Computed fields are returned unchanged.
+func Qualify(table string, field *domain.Field) *domain.Field {
+	// check is column
+	if field == nil || field.Expression != nil {
+		return field
+	}
+
+	// qualify columns
+	qualified := *field
+	qualified.DB = table + "." + field.DB
+	if field.Columns != nil {
+		qualified.Columns = make(map[domain.OperationType]string, len(field.Columns))
+		for op, column := range field.Columns {
+			qualified.Columns[op] = table + "." + column
+		}
+	}
+
+	// return qualified field
+	return &qualified
+}
+
+// Alias sets the alias of the table of the read query, for example "FROM users AS u", so its
+// columns can be qualified with the alias in joins, see Qualify. Aliases on other than read
+// queries are rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Alias(alias string) *Query {
+	// check alias
+	if !aliasPattern.MatchString(alias) {
+		return qb.addError(fmt.Errorf("invalid table alias %q", alias))
+	}
+
+	// set alias
+	qb.alias = alias
+
+	// return query
+	return qb
+}
+
+// Join adds an INNER JOIN of the source to the read query with the given conditions joined by
+// AND, for example:
+//
+//	qb.Join("orders", Eq(NewField(WithDB("orders.user_id")), NewField(WithDB("users.id"))))
+//
+// The source is a table name, a subquery such as a read *Query or *TypedQuery, or a table or
+// subquery with an alias, see As. The table prefix (see WithTablePrefix) is added to joined tables, and the
+// params of joined subqueries are bound in the order of the joins. Joined tables are not scoped
+// to the tenant (see TenantProvider), join a subquery of a tenant scoped model instead. Joins
+// on other than read queries are rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Join(source any, on ...domain.Condition) *Query {
+	return qb.addJoin(domain.JoinInner, source, on)
+}
+
+// LeftJoin adds a LEFT JOIN of the source to the read query, see Join.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) LeftJoin(source any, on ...domain.Condition) *Query {
+	return qb.addJoin(domain.JoinLeft, source, on)
+}
+
+// RightJoin adds a RIGHT JOIN of the source to the read query, see Join.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) RightJoin(source any, on ...domain.Condition) *Query {
+	return qb.addJoin(domain.JoinRight, source, on)
+}
+
+// FullJoin adds a FULL JOIN of the source to the read query, see Join. MySQL does not support
+// FULL JOIN, so the query fails to build there.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) FullJoin(source any, on ...domain.Condition) *Query {
+	return qb.addJoin(domain.JoinFull, source, on)
+}
+
+// CrossJoin adds a CROSS JOIN of the source to the read query, which has no conditions, see Join.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) CrossJoin(source any) *Query {
+	return qb.addJoin(domain.JoinCross, source, nil)
+}
+
+// From sets the source of the read query instead of its table, for example a subquery with an
+// alias:
+//
+//	recent := NewQuery[Order]("read").Where(Gt(FieldOf[Order]("created_at"), since))
+//	qb := New("read").Model(Order{}).From(As(recent, "recent")).Where(Eq(FieldOf[Order]("status"), "paid"))
+//
+// The source is a table name, a subquery such as a read *Query or *TypedQuery, which requires
+// an alias, or a table or subquery with an alias, see As. The params of the subquery are bound
+// before the params of the joins and conditions of the query. The table prefix (see
+// WithTablePrefix) is added to tables, and the table of the query is not resolved, so the
+// function set by TableFunc is not called. FROM sources on other than read queries are
+// rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) From(source any) *Query {
+	// create source
+	src, err := qb.newSource("FROM", source)
+	if err != nil {
+		return qb.addError(err)
+	}
+
+	// set source
+	qb.from = &src
+
+	// return query
+	return qb
+}
+
+// Using adds the sources joined into the UPDATE or DELETE query, whose rows are matched with
+// the rows of the table by the conditions of the query, for example for deleting the orders
+// of banned users:
+//
+//	qb := New("delete").Model(Order{}).Using("users").
+//		Where(Eq(Qualify("orders", userID), NewField(WithDB("users.id"))), Eq(Qualify("users", status), "banned"))
+//
+// The sources are table names or subqueries with an alias, see As, and the table prefix (see
+// WithTablePrefix) is added to tables. The sources are rendered as "UPDATE ... FROM" and
+// "DELETE ... USING" in PostgreSQL, as "UPDATE ... FROM" in SQLite, which has no DELETE ...
+// USING, and with the multi-table syntax of MySQL, "UPDATE orders, users SET ..." and "DELETE
+// orders FROM orders, users", and SQL Server, "UPDATE orders SET ... FROM orders, users". The
+// Oracle and DB2 dialects do not support joined sources of writes. Columns of the sources
+// are qualified in the conditions, and the set columns are not, except in MySQL, where set
+// columns which are columns of the sources too must be qualified. Sources on other than
+// update and delete queries are rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Using(sources ...any) *Query {
+	// add sources
+	for _, source := range sources {
+		// create source
+		src, err := qb.newSource("USING", source)
+		if err != nil {
+			return qb.addError(err)
+		}
+
+		// add source
+		qb.using = append(qb.using, src)
+	}
+
+	// return query
+	return qb
+}
+
+// GetUsing returns the sources joined into the UPDATE or DELETE query, or nil if no sources are
+// set, see Using.
+func (qb *Query) GetUsing() []domain.Source {
+	return qb.using
+}
+
+// GetFrom returns the FROM source of the query, or nil if the table of the query is used.
+func (qb *Query) GetFrom() *domain.Source {
+	return qb.from
+}
+
+// GetJoins returns the joins of the query, or nil if no joins are set.
+func (qb *Query) GetJoins() []domain.Join {
+	return qb.joins
+}
+
+// addJoin adds the join of the given type of the source with the conditions, see Join.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) addJoin(t domain.JoinType, source any, on []domain.Condition) *Query {
+	// create source
+	src, err := qb.newSource(string(t), source)
+	if err != nil {
+		return qb.addError(err)
+	}
+
+	// add join
+	qb.joins = append(qb.joins, domain.Join{Type: t, Source: src, On: on})
+
+	// return query
+	return qb
+}
+
+// newSource returns the checked source of the table or subquery with the table prefix, see
+// As. Common table expressions of the query are not prefixed, see With. The clause of the source, such as "LEFT JOIN", is named in the errors.
+func (qb *Query) newSource(clause string, source any) (domain.Source, error) {
+	// source with alias
+	src, ok := source.(domain.Source)
+	if !ok {
+		src = As(source, "")
+	}
+
+	// check source
+	switch {
+	case src.Subquery == nil && src.Table == "":
+		return src, fmt.Errorf("unsupported %s source: %T", clause, source)
+	case src.Subquery == nil && !tableNamePattern.MatchString(src.Table):
+		return src, fmt.Errorf("%w: %s table %q", ErrUnsafeIdentifier, clause, src.Table)
+	case src.Subquery != nil && src.Alias == "":
+		return src, fmt.Errorf("%s of subquery without alias", clause)
+	case src.Alias != "" && !aliasPattern.MatchString(src.Alias):
+		return src, fmt.Errorf("invalid %s alias %q", clause, src.Alias)
+	case src.Subquery != nil && len(src.IndexHints) > 0:
+		return src, fmt.Errorf("%s of subquery with index hints", clause)
+	}
+
+	// check index hints
+	for _, hint := range src.IndexHints {
+		if err := checkIndexHint(hint); err != nil {
+			return src, err
+		}
+	}
+
+	// add table prefix, except to common table expressions
+	if src.Subquery == nil && !qb.isCTE(src.Table) {
+		src.Table = qb.prefixTable(src.Table)
+	}
+
+	// return source
+	return src, nil
+}
+
+// checkJoins checks the joins, the FROM source, the table alias, the index hints and the joined
+// sources of writes of the query.
+func (qb *Query) checkJoins() error {
+	switch {
+	case qb.from != nil && qb.operation != domain.OperationRead:
+		return fmt.Errorf("%w: FROM source on %v query", ErrInvalidJoin, qb.operation)
+	case qb.from != nil && qb.alias != "":
+		return fmt.Errorf("%w: table alias with FROM source, set the alias of the source instead", ErrInvalidJoin)
+	case len(qb.joins) > 0 && qb.operation != domain.OperationRead:
+		return fmt.Errorf("%w: JOIN on %v query", ErrInvalidJoin, qb.operation)
+	case qb.alias != "" && qb.operation != domain.OperationRead:
+		return fmt.Errorf("%w: table alias on %v query", ErrInvalidJoin, qb.operation)
+	case len(qb.indexHints) > 0 && qb.operation != domain.OperationRead:
+		return fmt.Errorf("%w: %s on %v query", ErrInvalidJoin, qb.indexHints[0].Type, qb.operation)
+	case len(qb.indexHints) > 0 && qb.from != nil:
+		return fmt.Errorf("%w: %s with FROM source, set the index hints of the source instead", ErrInvalidJoin, qb.indexHints[0].Type)
+	case len(qb.using) > 0 && qb.operation != domain.OperationUpdate && qb.operation != domain.OperationDelete:
+		return fmt.Errorf("%w: USING on %v query", ErrInvalidJoin, qb.operation)
+	default:
+		return nil
+	}
+}
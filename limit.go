@@ -1,6 +1,7 @@
 package qbr
 
-// Limit set limit.
+// Limit set limit. The limit is rendered in the syntax of the dialect of the query, for example
+// LIMIT in PostgreSQL, FETCH FIRST in Oracle and TOP in SQL Server, see WithDialect.
 func (qb *Query) Limit(limit uint64) *Query {
 	// set limit
 	qb.limit = limit
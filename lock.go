@@ -0,0 +1,183 @@
+package qbr
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// ForUpdate adds a FOR UPDATE locking clause to the SELECT query, which locks the selected
+// rows until the end of the transaction. If tables are given, only the rows of these tables
+// are locked, for example:
+//
+//	qb.ForUpdate("orders") // FOR UPDATE OF orders
+//
+// The tables get the table prefix of the query, see WithTablePrefix. Tables which are aliased
+// in the query, by Alias or As, are locked by their aliases, as required by PostgreSQL, and
+// aliases can be given instead of tables:
+//
+//	qb.Alias("u").Join(As("orders", "o"), on).ForUpdate("users") // FOR UPDATE OF u
+//
+// Locking clauses on other than SELECT queries are rejected by Validate, and locking clauses
+// are not supported by the SQLite and SQL Server dialects. Locked tables are not supported by
+// the Oracle and DB2 dialects, whose OF clauses list columns.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) ForUpdate(of ...string) *Query {
+	// set lock strength
+	return qb.setLock(domain.LockUpdate, of)
+}
+
+// ForShare adds a FOR SHARE locking clause to the SELECT query, which locks the selected rows
+// against updates and deletes by other transactions until the end of the transaction, but
+// allows other shared locks. The tables are locked as by ForUpdate.
+//
+// FOR SHARE is not supported by the Oracle and DB2 dialects, which is reported by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) ForShare(of ...string) *Query {
+	// set lock strength
+	return qb.setLock(domain.LockShare, of)
+}
+
+// NoWait makes the locking clause of the query fail immediately if a selected row is locked
+// by another transaction, instead of waiting for the lock, see ForUpdate. NOWAIT is not
+// supported by the DB2 dialect.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) NoWait() *Query {
+	return qb.setLockWait(domain.LockNoWait)
+}
+
+// SkipLocked makes the locking clause of the query skip the selected rows which are locked
+// by another transaction, for example for queues processed by several workers, see ForUpdate.
+// SKIP LOCKED is not supported by the DB2 dialect.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) SkipLocked() *Query {
+	return qb.setLockWait(domain.LockSkipLocked)
+}
+
+// GetLock returns the locking clause of the query, with the table prefix added to its tables
+// and aliased tables replaced by their aliases, or nil if no locking clause is set.
+func (qb *Query) GetLock() *domain.Lock {
+	// check is locked
+	if qb.lock == nil {
+		return nil
+	}
+
+	// copy lock
+	lock := *qb.lock
+	lock.Of = nil
+	for _, table := range qb.lock.Of {
+		lock.Of = append(lock.Of, qb.lockTargets(table)...)
+	}
+
+	// return lock
+	return &lock
+}
+
+// lockTargets returns the names of the locked table in the locking clause, which are the
+// aliases of the table in the query if it is aliased, or the table with the table prefix.
+func (qb *Query) lockTargets(table string) []string {
+	// sources of the query
+	var sources []domain.Source
+	if qb.from != nil {
+		sources = append(sources, *qb.from)
+	}
+	for _, join := range qb.joins {
+		sources = append(sources, join.Source)
+	}
+
+	// check is alias
+	if table == qb.alias {
+		return []string{table}
+	}
+	for _, src := range sources {
+		if src.Alias == table {
+			return []string{table}
+		}
+	}
+
+	// table with prefix
+	if !qb.isCTE(table) {
+		table = qb.prefixTable(table)
+	}
+
+	// aliases of table
+	var aliases []string
+	if qb.alias != "" && table == qb.prefixTable(qb.GetTable()) {
+		aliases = append(aliases, qb.alias)
+	}
+	for _, src := range sources {
+		if src.Alias != "" && src.Subquery == nil && src.Table == table {
+			aliases = append(aliases, src.Alias)
+		}
+	}
+
+	// check is aliased
+	if len(aliases) > 0 {
+		return aliases
+	}
+
+	// return table
+	return []string{table}
+}
+
+// setLock sets the strength and the tables of the locking clause of the query.
+func (qb *Query) setLock(strength domain.LockStrength, of []string) *Query {
+	// check tables
+	for _, table := range of {
+		if !tableNamePattern.MatchString(table) {
+			return qb.addError(fmt.Errorf("invalid lock table %q", table))
+		}
+	}
+
+	// init lock
+	if qb.lock == nil {
+		qb.lock = &domain.Lock{}
+	}
+
+	// set lock
+	qb.lock.Strength = strength
+	qb.lock.Of = slices.Clone(of)
+
+	// return query
+	return qb
+}
+
+// setLockWait sets the wait policy of the locking clause of the query.
+func (qb *Query) setLockWait(wait domain.LockWait) *Query {
+	// init lock
+	if qb.lock == nil {
+		qb.lock = &domain.Lock{}
+	}
+
+	// check is conflicting
+	if qb.lock.Wait != domain.LockWaitDefault && qb.lock.Wait != wait {
+		return qb.addError(fmt.Errorf("conflicting lock wait policies: %s and %s", qb.lock.Wait, wait))
+	}
+
+	// set wait policy
+	qb.lock.Wait = wait
+
+	// return query
+	return qb
+}
+
+// checkLock checks the locking clause of the query, and if the dialect of the query supports
+// it, see sqlbuilder.CheckLock.
+func (qb *Query) checkLock() error {
+	switch {
+	case qb.lock == nil:
+		return nil
+	case qb.lock.Strength == "":
+		return fmt.Errorf("%w: %s without FOR UPDATE or FOR SHARE", ErrInvalidLock, qb.lock.Wait)
+	case qb.operation != domain.OperationRead:
+		return fmt.Errorf("%w: FOR %s on %v query", ErrInvalidLock, qb.lock.Strength, qb.operation)
+	default:
+		return sqlbuilder.CheckLock(qb.lock, qb.options.Dialect)
+	}
+}
@@ -0,0 +1,91 @@
+package qbr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestLock(t *testing.T) {
+	// read of users in dialect
+	read := func(d domain.Dialect) *qbr.Query {
+		return qbr.NewRead(qbr.WithDialect(d)).Model(user{}).Where(qbr.Eq(qbr.FieldOf[user]("status"), "queued"))
+	}
+
+	t.Run("postgres", func(t *testing.T) {
+		qbrtest.Golden(t, read(qbr.Postgres).ForUpdate("users").SkipLocked())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		qbrtest.Golden(t, read(qbr.MySQL).ForUpdate("users").NoWait())
+	})
+
+	t.Run("oracle", func(t *testing.T) {
+		qbrtest.Golden(t, read(qbr.Oracle).ForUpdate().SkipLocked())
+	})
+
+	t.Run("db2", func(t *testing.T) {
+		qbrtest.Golden(t, read(qbr.DB2).ForUpdate())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			qb   *qbr.Query
+			want string
+		}{
+			{"sqlite", read(qbr.SQLite).ForUpdate(), "FOR UPDATE is not supported by the sqlite dialect"},
+			{"sqlserver", read(qbr.SQLServer).ForUpdate(), "FOR UPDATE is not supported by the sqlserver dialect"},
+			{"oracle of", read(qbr.Oracle).ForUpdate("users"), "FOR UPDATE OF tables is not supported by the oracle dialect"},
+			{"db2 of", read(qbr.DB2).ForUpdate("users"), "FOR UPDATE OF tables is not supported by the db2 dialect"},
+			{"db2 nowait", read(qbr.DB2).ForUpdate().NoWait(), "NOWAIT is not supported by the db2 dialect"},
+			{"db2 skip locked", read(qbr.DB2).ForUpdate().SkipLocked(), "SKIP LOCKED is not supported by the db2 dialect"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.qb.Validate()
+				if !errors.Is(err, qbr.ErrInvalidLock) {
+					t.Fatalf("got error %v, want %v", err, qbr.ErrInvalidLock)
+				}
+				wantError(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("update query", func(t *testing.T) {
+		err := qbr.NewDelete().Table("users").Where(qbr.Eq(qbr.FieldOf[user]("id"), 1)).ForUpdate().Validate()
+		if !errors.Is(err, qbr.ErrInvalidLock) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrInvalidLock)
+		}
+	})
+}
+
+func TestForShare(t *testing.T) {
+	// read of users joined with orders in dialect
+	read := func(d domain.Dialect) *qbr.Query {
+		on := qbr.Eq(qbr.NewField(qbr.WithDB("o.user_id")), qbr.NewField(qbr.WithDB("u.id")))
+		return qbr.NewRead(qbr.WithDialect(d)).Model(user{}).Alias("u").Join(qbr.As("orders", "o"), on)
+	}
+
+	t.Run("aliased tables", func(t *testing.T) {
+		// aliased tables are locked by their aliases
+		qbrtest.Golden(t, read(qbr.Postgres).ForShare("users", "orders"))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for _, d := range []domain.Dialect{qbr.Oracle, qbr.DB2, qbr.SQLite, qbr.SQLServer} {
+			err := read(d).ForShare().Validate()
+			if !errors.Is(err, qbr.ErrInvalidLock) {
+				t.Fatalf("got error %v in the %s dialect, want %v", err, d.Name(), qbr.ErrInvalidLock)
+			}
+			wantError(t, err, "FOR SHARE is not supported by the "+d.Name()+" dialect")
+		}
+	})
+
+	t.Run("aliased tables unsupported", func(t *testing.T) {
+		err := read(qbr.Oracle).ForUpdate("users").Validate()
+		wantError(t, err, "FOR UPDATE OF tables is not supported by the oracle dialect")
+	})
+}
@@ -0,0 +1,69 @@
+package qbr
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Logger logs the statements executed by the execution helpers of the query builder.
+type Logger interface {
+	LogQuery(ctx context.Context, stmt *Statement, err error)
+}
+
+// slogLogger is a Logger writing to a slog.Logger.
+type slogLogger struct {
+	logger *slog.Logger
+	debug  bool
+}
+
+// NewSlogLogger creates a Logger writing the statements to the given slog.Logger.
+//
+// Statements are logged on the debug level, statements without tenant scope
+// (see Query.WithoutTenantScope) on the warning level, failed statements on
+// the error level, with the fingerprint of their query (see Query.Fingerprint).
+// If debug is true, the statements are logged with inlined params (see
+// Statement.DebugSQL) instead of the SQL and params.
+//
+// Returns created logger.
+func NewSlogLogger(logger *slog.Logger, debug bool) Logger {
+	return &slogLogger{
+		logger: logger,
+		debug:  debug,
+	}
+}
+
+// LogQuery logs the statement and the error of its execution.
+func (l *slogLogger) LogQuery(ctx context.Context, stmt *Statement, err error) {
+	// statement attributes
+	var attrs []slog.Attr
+	if l.debug {
+		attrs = append(attrs, slog.String("sql", stmt.DebugSQL()))
+	} else {
+		attrs = append(attrs, slog.String("sql", stmt.SQL), slog.Any("params", stmt.Params))
+	}
+
+	// add fingerprint of statement
+	if stmt.Fingerprint != "" {
+		attrs = append(attrs, slog.String("fingerprint", stmt.Fingerprint))
+	}
+
+	// mark statement without tenant scope
+	if stmt.TenantUnscoped {
+		attrs = append(attrs, slog.Bool("tenant_unscoped", true))
+	}
+
+	// log failed statement
+	if err != nil {
+		l.logger.LogAttrs(ctx, slog.LevelError, "qbr query failed", append(attrs, slog.Any("error", err))...)
+		return
+	}
+
+	// log statement without tenant scope
+	if stmt.TenantUnscoped {
+		l.logger.LogAttrs(ctx, slog.LevelWarn, "qbr query without tenant scope", attrs...)
+		return
+	}
+
+	// log statement
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "qbr query", attrs...)
+}
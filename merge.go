@@ -0,0 +1,109 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// Merge merges the conditions and joins of the other query into the query, combined with
+// AND, for example restrictions built separately by access control logic.
+//
+// The merged query should only contain conditions and joins: its sort parameters, limit
+// and offset are rejected, and a table or placeholder which differs from the table or
+// placeholder of the query is a conflict. Joins which are already joined by the query are
+// not joined again, other joins of a table or alias joined by the query are a conflict. Errors of the other query and merge errors
+// are stored as errors of the query. If the query has no model, the model of the
+// other query is used. The method returns the modified QueryBuilder instance for
+// method chaining.
+func (qb *Query) Merge(other *Query) *Query {
+	// check is query not nil
+	if other == nil {
+		return qb.addError(errors.New("nil query in merge"))
+	}
+
+	// merge errors
+	var errs []error
+
+	// check table
+	if table, otherTable := qb.GetTable(), other.GetTable(); table != "" && otherTable != "" && table != otherTable {
+		errs = append(errs, fmt.Errorf("conflicting tables in merge: %q and %q", table, otherTable))
+	}
+
+	// check dialect
+	if qb.options.dialect() != other.options.dialect() {
+		errs = append(errs, fmt.Errorf("conflicting dialects in merge: %q and %q",
+			qb.options.dialect().Name(), other.options.dialect().Name()))
+	}
+
+	// check placeholder
+	if qb.options.Placeholder != other.options.Placeholder {
+		errs = append(errs, fmt.Errorf("conflicting placeholders in merge: %q and %q",
+			qb.options.Placeholder, other.options.Placeholder))
+	}
+
+	// check sort and pagination
+	if len(other.sort) > 0 {
+		errs = append(errs, errors.New("cannot merge query with sort parameters"))
+	}
+	if other.limit > 0 || other.offset > 0 {
+		errs = append(errs, errors.New("cannot merge query with limit or offset"))
+	}
+
+	// check joins
+	var joins []domain.Join
+	for _, join := range other.joins {
+		// find join of same table or alias
+		i := slices.IndexFunc(qb.joins, func(j domain.Join) bool {
+			return joinName(j.Source) == joinName(join.Source)
+		})
+		switch {
+		case i < 0:
+			joins = append(joins, join)
+		case !reflect.DeepEqual(qb.joins[i], join):
+			errs = append(errs, fmt.Errorf("%w: conflicting joins of %q in merge", ErrInvalidJoin, joinName(join.Source)))
+		}
+	}
+
+	// check errors
+	if err := errors.Join(errs...); err != nil {
+		return qb.addError(err)
+	}
+
+	// merge errors of other query
+	if other.err != nil {
+		qb.addError(other.err)
+	}
+
+	// merge model
+	if qb.model == nil {
+		qb.model = other.model
+	}
+
+	// merge joins
+	for _, join := range joins {
+		join.On = cloneConditions(join.On)
+		qb.joins = append(qb.joins, join)
+	}
+
+	// merge conditions
+	qb.conditions = append(qb.conditions, cloneConditions(other.conditions)...)
+
+	// return query
+	return qb
+}
+
+// joinName returns the name of the joined source, which is its alias, or its table if it has
+// no alias.
+func joinName(src domain.Source) string {
+	// check is aliased
+	if src.Alias != "" {
+		return src.Alias
+	}
+
+	// return table
+	return src.Table
+}
@@ -0,0 +1,37 @@
+package qbr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestMerge(t *testing.T) {
+	// fields of users and their orders
+	id, status := qbr.FieldOf[user]("id"), qbr.FieldOf[user]("status")
+	userID := qbr.NewField(qbr.WithDB("orders.user_id"))
+	ownerID := qbr.NewField(qbr.WithDB("orders.owner_id"))
+
+	// restriction of users to the owner of their orders
+	restriction := func() *qbr.Query {
+		return qbr.NewRead().Model(user{}).Join("orders", qbr.Eq(userID, qbr.Qualify("users", id))).Where(qbr.Eq(ownerID, 7))
+	}
+
+	t.Run("joins", func(t *testing.T) {
+		qbrtest.Golden(t, qbr.NewRead().Model(user{}).Where(qbr.Eq(status, "active")).Merge(restriction()))
+	})
+
+	t.Run("same join", func(t *testing.T) {
+		// the join of the query is not joined again
+		qbrtest.Golden(t, restriction().Where(qbr.Eq(status, "active")).Merge(restriction()))
+	})
+
+	t.Run("conflicting join", func(t *testing.T) {
+		qb := qbr.NewRead().Model(user{}).LeftJoin("orders", qbr.Eq(userID, qbr.Qualify("users", id))).Merge(restriction())
+		if _, err := qb.Build(); !errors.Is(err, qbr.ErrInvalidJoin) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrInvalidJoin)
+		}
+	})
+}
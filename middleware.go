@@ -0,0 +1,75 @@
+package qbr
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// BuildFunc builds the statement of the query, see Query.BuildContext.
+type BuildFunc func(ctx context.Context, qb *Query) (*Statement, error)
+
+// Middleware wraps the build of queries by Build and BuildContext, which are used by Exec and
+// the methods of TypedQuery executing queries, but not by ToSql. For example to inspect or rewrite every query
+// before it is rendered, or the built statement after it is rendered:
+//
+//	qbr.Use(func(next qbr.BuildFunc) qbr.BuildFunc {
+//		return func(ctx context.Context, qb *qbr.Query) (*qbr.Statement, error) {
+//			if qb.GetOperation() == domain.OperationRead && qb.GetLimit() == 0 {
+//				return nil, errors.New("select without limit")
+//			}
+//			return next(ctx, qb)
+//		}
+//	})
+//
+// The query passed to next is rendered, so a middleware can mutate it, for example add
+// conditions, and can reject it by returning an error without calling next.
+type Middleware func(next BuildFunc) BuildFunc
+
+// buildMiddlewares contains the registered package-global middlewares.
+var buildMiddlewares struct {
+	sync.RWMutex
+	list []Middleware
+}
+
+// Use registers package-global middlewares wrapping the build of all queries, see Middleware.
+//
+// Middlewares are called in registration order, the package-global middlewares before the
+// middlewares of the query, see Query.Use, so the first registered middleware is the outermost.
+// Registration is safe for concurrent use.
+func Use(mw ...Middleware) {
+	buildMiddlewares.Lock()
+	defer buildMiddlewares.Unlock()
+	buildMiddlewares.list = append(buildMiddlewares.list, mw...)
+}
+
+// Use adds middlewares wrapping the build of the query, which are called after the
+// package-global middlewares in the order they are added, see qbr.Use. The queries derived
+// by the query, such as the count query of FindAndCount, are built with the middlewares too.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Use(mw ...Middleware) *Query {
+	// add middlewares
+	qb.middlewares = append(qb.middlewares, mw...)
+
+	// return query
+	return qb
+}
+
+// buildChain returns the build function of the query wrapped in the package-global
+// middlewares and the middlewares of the query, and whether any middleware wraps it.
+func (qb *Query) buildChain() (BuildFunc, bool) {
+	// get middlewares
+	buildMiddlewares.RLock()
+	mws := slices.Concat(buildMiddlewares.list, qb.middlewares)
+	buildMiddlewares.RUnlock()
+
+	// wrap build, the first middleware is the outermost
+	build := BuildFunc(buildStatement)
+	for i := len(mws) - 1; i >= 0; i-- {
+		build = mws[i](build)
+	}
+
+	// return build
+	return build, len(mws) > 0
+}
@@ -0,0 +1,55 @@
+package qbr
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WithSnakeCaseColumns derives the columns of exported struct fields without a DB field name
+// tag from their names converted to snake_case, see SnakeCase, so a field UserID is mapped to
+// the column "user_id" without a `db:"user_id"` tag.
+//
+// Tagged fields always use the name of their tag, and fields tagged with "-" are skipped.
+// Embedded fields are never derived.
+func WithSnakeCaseColumns() Option {
+	return func(o *Options) error {
+		// enable derivation
+		o.SnakeCaseColumns = true
+		return nil
+	}
+}
+
+// SnakeCase converts the given CamelCase name to snake_case, as used for the table names
+// derived from struct names and for columns in the snake_case column mode.
+//
+// Initialisms are kept together, so "UserID" is converted to "user_id", "HTTPStatus" to
+// "http_status" and "APIKey" to "api_key".
+func SnakeCase(name string) string {
+	// name runes
+	runes := []rune(name)
+
+	// result name
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+
+	// convert runes
+	for i, r := range runes {
+		// add separator before word start
+		if i > 0 && unicode.IsUpper(r) {
+			// previous and next runes
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			// word starts after lower case or digit, or at the end of initialism
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && next) {
+				b.WriteByte('_')
+			}
+		}
+
+		// add rune
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	// return name
+	return b.String()
+}
@@ -1,6 +1,7 @@
 package qbr
 
-// Offset set offset.
+// Offset set offset. The offset is rendered in the syntax of the dialect of the query, for
+// example OFFSET in PostgreSQL and OFFSET ROWS in Oracle and SQL Server, see WithDialect.
 func (qb *Query) Offset(offset uint64) *Query {
 	// set offset
 	qb.offset = offset
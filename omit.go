@@ -0,0 +1,103 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// Omit excludes the given fields from the query: they are dropped from the select
+// list and from the data of INSERT and UPDATE queries, without annotating the model.
+//
+// Omitted fields are removed when the query is built, after all the data has been
+// set, so no other feature can add them back. If all fields are selected, the select
+// list is expanded to the fields of the model of the query (see Model). Omitting a
+// field which is explicitly selected is an error. Nil fields are stored as an error
+// of the query. The method returns the QueryBuilder instance to support method chaining.
+func (qb *Query) Omit(fields ...*domain.Field) *Query {
+	// add omitted fields
+	for _, field := range fields {
+		// check is field not nil
+		if field == nil {
+			qb.addError(errors.New("nil field in omit"))
+			continue
+		}
+
+		// add omitted column
+		if !slices.Contains(qb.omits, field.DB) {
+			qb.omits = append(qb.omits, field.DB)
+		}
+	}
+
+	// return query
+	return qb
+}
+
+// isOmitted checks if the field is omitted from the query.
+func (qb *Query) isOmitted(field *domain.Field) bool {
+	return slices.Contains(qb.omits, field.DB)
+}
+
+// checkOmits checks that no omitted field is explicitly selected, and that the model
+// fields are known if the omitted fields must be removed from the selection of all fields.
+//
+// Returns the joined errors, or nil if the omitted fields are valid.
+func (qb *Query) checkOmits() error {
+	// check is fields omitted
+	if len(qb.omits) == 0 {
+		return nil
+	}
+
+	// omit errors
+	var errs []error
+
+	// check selected fields
+	for _, field := range qb.selects {
+		// check is all fields selected without model
+		if field.DB == "*" {
+			if structTypeOf(qb.model) == nil {
+				errs = append(errs, errors.New("cannot omit fields from selection of all fields without a struct model"))
+			}
+			continue
+		}
+
+		// check is omitted field selected
+		if qb.isOmitted(&field) {
+			errs = append(errs, fmt.Errorf("column %q is both selected and omitted", field.DB))
+		}
+	}
+
+	// return errors
+	return errors.Join(errs...)
+}
+
+// expandAllFields returns the fields of the model of the query which are not ignored
+// for the query operation and not omitted, to replace the selection of all fields.
+//
+// Returns nil if the model is not a struct or its fields could not be extracted.
+func (qb *Query) expandAllFields() []domain.Field {
+	// model type
+	t := structTypeOf(qb.model)
+	if t == nil {
+		return nil
+	}
+
+	// extract fields
+	fields, err := extractModelFields(t, qb.options)
+	if err != nil {
+		return nil
+	}
+
+	// fields of model
+	var result []domain.Field
+	for _, field := range fields {
+		if field != nil && !isFieldIgnored(field, qb.operation) && !qb.isOmitted(field) {
+			result = append(result, *field)
+		}
+	}
+
+	// return fields
+	return result
+}
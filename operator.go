@@ -0,0 +1,56 @@
+package qbr
+
+import (
+	"errors"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// OperatorFunc renders a condition of a custom operator from the column, or the expression of
+// a computed field, and the placeholder of the value of the condition, see RegisterOperator.
+type OperatorFunc = sqlbuilder.OperatorFunc
+
+// RegisterOperator registers a custom operator with the given name and rendering function,
+// for example the trigram similarity operator of PostgreSQL:
+//
+//	similar, err := qbr.RegisterOperator("similar", func(column, arg string) string {
+//		return column + " % " + arg
+//	})
+//
+//	qb.Where(qbr.Op(similar, name, "jon"))
+//
+// Conditions of custom operators are built like the conditions of the built-in operators,
+// which are registered the same way: their values are converted and bound as params with
+// the placeholder of the query, and they can be composed with And and Or. The name is the
+// string representation of the operator in domain.Condition.String. Registering a name again
+// replaces its rendering function and returns the same operator. Registration is safe for
+// concurrent use.
+//
+// Returns the operator, or an error if the name is empty or the function is nil.
+func RegisterOperator(name string, fn OperatorFunc) (domain.OperatorType, error) {
+	// check operator
+	if name == "" {
+		return 0, errors.New("empty operator name")
+	}
+	if fn == nil {
+		return 0, errors.New("nil operator function")
+	}
+
+	// register operator
+	op := domain.NewOperator(name)
+	sqlbuilder.RegisterOperator(op, fn)
+
+	// return operator
+	return op, nil
+}
+
+// Op returns a condition of the given operator, for example of a custom operator registered
+// by RegisterOperator, on the given field and value.
+func Op(op domain.OperatorType, field *domain.Field, val any) domain.Condition {
+	return domain.Condition{
+		Field:    field,
+		Operator: op,
+		Value:    val,
+	}
+}
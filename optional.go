@@ -0,0 +1,162 @@
+package qbr
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// optionalState is the state of an Optional.
+type optionalState int
+
+// Optional states.
+const (
+	optionalUnset optionalState = iota
+	optionalNull
+	optionalSet
+)
+
+// Optional is a value of T which is either unset, NULL or set, so data and conditions can
+// distinguish a value which is not given from a NULL value and from the zero value of T.
+//
+// The zero value is unset. Unset values are skipped in data and conditions, NULL values set
+// columns to NULL and are compared with IS NULL, and set values are used regardless of the
+// zero value policy, even if they are the zero value of T:
+//
+//	type UserPatch struct {
+//		Name  qbr.Optional[string] `db:"name"`
+//		Score qbr.Optional[int]    `db:"score"`
+//	}
+//
+//	qbr.NewUpdate().SetStruct(UserPatch{Score: qbr.Some(0)}) // SET score = 0
+//
+// Scanned NULL values are NULL optionals, and other values are set.
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// Some returns an optional set to the given value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, state: optionalSet}
+}
+
+// Null returns a NULL optional.
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// Get returns the value and true if the optional is set, or the zero value of T and false
+// otherwise.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalSet
+}
+
+// IsSet checks if the optional is set to a value.
+func (o Optional[T]) IsSet() bool {
+	return o.state == optionalSet
+}
+
+// IsNull checks if the optional is NULL.
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// IsUnset checks if the optional is unset.
+func (o Optional[T]) IsUnset() bool {
+	return o.state == optionalUnset
+}
+
+// optional returns the value and the state of the optional.
+func (o Optional[T]) optional() (any, optionalState) {
+	return o.value, o.state
+}
+
+// Value returns the driver value of set optionals, or nil otherwise, so optionals can be
+// bound by database/sql directly.
+func (o Optional[T]) Value() (driver.Value, error) {
+	// check is set
+	if o.state != optionalSet {
+		return nil, nil
+	}
+
+	// convert value
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// Scan scans the source into the optional, NULL values set a NULL optional.
+func (o *Optional[T]) Scan(src any) error {
+	// scan value
+	var v sql.Null[T]
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+
+	// set optional
+	if v.Valid {
+		*o = Some(v.V)
+	} else {
+		*o = Null[T]()
+	}
+	return nil
+}
+
+// MarshalJSON marshals set optionals as their value, and other optionals as null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	// check is set
+	if o.state != optionalSet {
+		return []byte("null"), nil
+	}
+
+	// marshal value
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON unmarshals null as a NULL optional, and other values as set optionals.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	// check is null
+	if string(data) == "null" {
+		*o = Null[T]()
+		return nil
+	}
+
+	// unmarshal value
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	// set optional
+	*o = Some(v)
+	return nil
+}
+
+// optionalValue is implemented by Optional.
+type optionalValue interface {
+	optional() (any, optionalState)
+}
+
+// resolveOptional resolves the value if it is an Optional: the value of set optionals,
+// domain.ValueNull for NULL optionals, and nil for unset optionals.
+//
+// Returns the resolved value, the state of the optional, and true if the value is an Optional.
+func resolveOptional(value any) (any, optionalState, bool) {
+	// check is optional
+	o, ok := value.(optionalValue)
+	if !ok {
+		return value, 0, false
+	}
+
+	// resolve value
+	v, state := o.optional()
+	switch state {
+	case optionalSet:
+		return v, state, true
+	case optionalNull:
+		return domain.ValueNull, state, true
+	default:
+		return nil, state, true
+	}
+}
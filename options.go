@@ -0,0 +1,227 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// ZeroValuePolicy defines how the query builder handles zero values of data and conditions.
+type ZeroValuePolicy int
+
+// Zero value policies.
+const (
+	// ZeroValueSkip skips data and conditions with nil or zero values, it is the default policy.
+	ZeroValueSkip ZeroValuePolicy = iota + 1
+	// ZeroValueKeep keeps data and conditions with zero values, only nil values are skipped.
+	ZeroValueKeep
+)
+
+// Options contains the configuration of a query builder.
+type Options struct {
+	Placeholder          domain.SqlPlaceholder // Placeholder used by Build and ToSQL.
+	Logger               Logger                // Logger of the executed statements.
+	ZeroValuePolicy      ZeroValuePolicy       // Handling of zero values.
+	TagName              string                // Name of the struct tag with DB field names.
+	WindowCount          bool                  // Counting of FindAndCount with a window function.
+	Cache                Cache                 // Cache of the results of cached queries.
+	Codec                Codec                 // Serialization of cached results.
+	Tenant               TenantProvider        // Provider of the tenant of tenant scoped queries.
+	TablePrefix          string                // Prefix of the rendered table names.
+	DurationFormat       DurationFormat        // Format of bound time.Duration params.
+	TimeZone             *time.Location        // Time zone of bound and scanned times, if set.
+	PointerUpdates       bool                  // Pointer semantics of update structs.
+	StatementTimeout     bool                  // Setting of query timeouts as statement timeouts.
+	NoArgCopy            bool                  // Disabled copying of mutable params.
+	SkipConflicts        bool                  // Skipping of conflicting rows by InsertManyChunked.
+	SnakeCaseColumns     bool                  // Derivation of the columns of untagged fields.
+	StrictZeroConditions bool                  // Rejection of conditions dropped because of zero values.
+	Dialect              domain.Dialect        // SQL dialect of the generated SQL.
+}
+
+// Option is a function that configures the options of a query builder.
+//
+// It returns an error if the option conflicts with an option applied before.
+type Option func(*Options) error
+
+// WithPlaceholder sets the placeholder used by Build and ToSQL, the default is the placeholder of
+// the dialect, see WithDialect.
+func WithPlaceholder(placeholder domain.SqlPlaceholder) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.Placeholder != "" && o.Placeholder != placeholder {
+			return fmt.Errorf("conflicting placeholder options: %q and %q", o.Placeholder, placeholder)
+		}
+
+		// set placeholder
+		o.Placeholder = placeholder
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the statements executed by the query builder.
+func WithLogger(logger Logger) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.Logger != nil && o.Logger != logger {
+			return fmt.Errorf("conflicting logger options")
+		}
+
+		// set logger
+		o.Logger = logger
+		return nil
+	}
+}
+
+// WithZeroValuePolicy sets the handling of zero values, the default is ZeroValueSkip.
+func WithZeroValuePolicy(policy ZeroValuePolicy) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.ZeroValuePolicy != 0 && o.ZeroValuePolicy != policy {
+			return fmt.Errorf("conflicting zero value policy options: %d and %d", o.ZeroValuePolicy, policy)
+		}
+
+		// set policy
+		o.ZeroValuePolicy = policy
+		return nil
+	}
+}
+
+// WithZeroValues keeps the zero values of the data and conditions, so zero numbers, empty
+// strings and zero times are set and compared, and only nil values are skipped. It is the same
+// as the ZeroValueKeep policy, see WithZeroValuePolicy. Zero values of single fields are kept by
+// the "keep_zero" annotation instead, for example `db:"balance" qbr:"keep_zero"`.
+func WithZeroValues() Option {
+	return WithZeroValuePolicy(ZeroValueKeep)
+}
+
+// WithTagName sets the name of the struct tag containing the DB field names, the default is "db".
+func WithTagName(name string) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.TagName != "" && o.TagName != name {
+			return fmt.Errorf("conflicting tag name options: %q and %q", o.TagName, name)
+		}
+
+		// set tag name
+		o.TagName = name
+		return nil
+	}
+}
+
+// WithWindowCount makes FindAndCount count the total rows with the window function
+// COUNT(*) OVER () in the page query, so the total is read in one round trip. The
+// database must support window functions, for example PostgreSQL.
+func WithWindowCount() Option {
+	return func(o *Options) error {
+		// set window count
+		o.WindowCount = true
+		return nil
+	}
+}
+
+// WithCache sets the cache of the results of queries enabled by Query.Cached.
+func WithCache(cache Cache) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.Cache != nil && o.Cache != cache {
+			return fmt.Errorf("conflicting cache options")
+		}
+
+		// set cache
+		o.Cache = cache
+		return nil
+	}
+}
+
+// WithCodec sets the serialization of cached results, the default is JSONCodec.
+func WithCodec(codec Codec) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.Codec != nil && o.Codec != codec {
+			return fmt.Errorf("conflicting codec options")
+		}
+
+		// set codec
+		o.Codec = codec
+		return nil
+	}
+}
+
+// WithTenant sets the provider of the tenant of queries on models with a "tenant" annotated
+// column, see TenantProvider.
+func WithTenant(provider TenantProvider) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.Tenant != nil {
+			return fmt.Errorf("conflicting tenant options")
+		}
+
+		// set tenant provider
+		o.Tenant = provider
+		return nil
+	}
+}
+
+// WithTablePrefix sets the prefix added to the table names rendered by the query builder,
+// including the tables resolved from models. For tables qualified by a schema, the prefix
+// is only added to the table segment, so "app1_" renders "public.users" as "public.app1_users".
+func WithTablePrefix(prefix string) Option {
+	return func(o *Options) error {
+		// check is conflicting
+		if o.TablePrefix != "" && o.TablePrefix != prefix {
+			return fmt.Errorf("conflicting table prefix options: %q and %q", o.TablePrefix, prefix)
+		}
+
+		// set table prefix
+		o.TablePrefix = prefix
+		return nil
+	}
+}
+
+// newOptions applies the given options and sets the defaults of options which are not set.
+//
+// Returns the options, and the joined errors of the conflicting options.
+func newOptions(opts ...Option) (Options, error) {
+	// options
+	var o Options
+	// options errors
+	var errs []error
+
+	// apply options
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// set defaults
+	if o.Dialect == nil {
+		o.Dialect = Postgres
+	}
+	if o.Placeholder == "" {
+		o.Placeholder = o.Dialect.Placeholder()
+	}
+	if o.ZeroValuePolicy == 0 {
+		o.ZeroValuePolicy = ZeroValueSkip
+	}
+	if o.TagName == "" {
+		o.TagName = string(domain.QueryDB)
+	}
+	if o.Codec == nil {
+		o.Codec = JSONCodec{}
+	}
+	if o.DurationFormat == 0 {
+		o.DurationFormat = DurationInterval
+	}
+
+	// return options
+	return o, errors.Join(errs...)
+}
+
+// Options returns the options of the query builder.
+func (qb *Query) Options() Options {
+	return qb.options
+}
@@ -0,0 +1,198 @@
+package qbr
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// Paginate sorts the read query by the given sort keys and limits it to a page of the given
+// size, for keyset pagination with After, for example:
+//
+//	qb.Paginate(20, NewSortDesc(FieldOf[Post]("created_at")), NewSortDesc(FieldOf[Post]("id"))).
+//		After(lastCreatedAt, lastID)
+//
+// The sort keys must identify a row, so the last key is usually the primary key, and must not
+// be NULL, otherwise rows are skipped or repeated between pages.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Paginate(limit uint64, sorts ...*domain.Sort) *Query {
+	return qb.Sort(sorts...).Limit(limit)
+}
+
+// After restricts the read query to the rows following the row with the given values of the
+// sort keys of the query, which are the values of the last row of the previous page, see
+// Paginate. The values are in the order of the sort keys, and no values select the first page.
+//
+// If all sort keys have the same direction, the rows are selected by a row value comparison,
+// for example "(created_at, id) < ($1, $2)", which can use a composite index. Otherwise, and in
+// SQL Server, which has no row value comparisons, the comparison is expanded to "created_at <
+// $1 OR (created_at = $1 AND id > $2)". Zero values are kept. A count of values other than the
+// count of sort keys, or values on other than read queries, are rejected by Validate.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) After(values ...any) *Query {
+	// set values
+	qb.after = values
+
+	// return query
+	return qb
+}
+
+// EncodeCursor returns an opaque cursor of the values of the sort keys of the last row of a
+// page, for returning it to clients of web APIs, see After and DecodeCursor. The values are
+// encoded as JSON, so they must be JSON serializable.
+func EncodeCursor(values ...any) (string, error) {
+	// encode values
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+
+	// return cursor
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes the values of the cursor created by EncodeCursor into the values pointed
+// to by dest, which are in the order of the encoded values, for example:
+//
+//	var createdAt time.Time
+//	var id int64
+//	if err := DecodeCursor(cursor, &createdAt, &id); err != nil {
+//		return err
+//	}
+//	qb.After(createdAt, id)
+//
+// Returns an error wrapping ErrInvalidCursor if the cursor is malformed or the count of its
+// values is not the count of dest.
+func DecodeCursor(cursor string, dest ...any) error {
+	// decode cursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	// decode values
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if len(values) != len(dest) {
+		return fmt.Errorf("%w: %d values, expected %d", ErrInvalidCursor, len(values), len(dest))
+	}
+
+	// unmarshal values
+	for i, value := range values {
+		if err := json.Unmarshal(value, dest[i]); err != nil {
+			return fmt.Errorf("%w: value %d: %v", ErrInvalidCursor, i+1, err)
+		}
+	}
+
+	// values decoded
+	return nil
+}
+
+// withKeyset returns the query with the condition selecting the rows after the values of After
+// in the dialect d, see After. The query is cloned before it is modified, unless it is a clone
+// already, which is the case if clone is false.
+func (qb *Query) withKeyset(d domain.Dialect, clone bool) *Query {
+	// check is values set
+	if len(qb.after) == 0 {
+		return qb
+	}
+
+	// clone query
+	if clone {
+		qb = qb.Clone()
+	}
+
+	// add keyset condition
+	qb.conditions = append(qb.conditions, keysetCondition(qb.sort, qb.after, d))
+
+	// return query
+	return qb
+}
+
+// keysetCondition returns the condition selecting the rows after the given values of the sort
+// keys in the dialect d, see After.
+func keysetCondition(sorts []domain.Sort, values []any, d domain.Dialect) domain.Condition {
+	// check is direction shared
+	shared := true
+	for _, sort := range sorts {
+		shared = shared && sort.Type == sorts[0].Type
+	}
+
+	// create row value comparison
+	if shared && sqlbuilder.SupportsRowValues(d) {
+		// fields and values
+		args := make([]any, 0, len(sorts)*2)
+		for _, sort := range sorts {
+			args = append(args, sort.Field)
+		}
+		args = append(args, values...)
+
+		// return comparison
+		tuple := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(sorts)), ", ") + ")"
+		return Cond(Expr(tuple+" "+keysetOperator(sorts[0].Type)+" "+tuple, args...))
+	}
+
+	// create expanded comparison
+	branches := make([]domain.Condition, len(sorts))
+	for i, sort := range sorts {
+		// equal preceding keys
+		conds := make([]domain.Condition, 0, i+1)
+		for j := range i {
+			conds = append(conds, Eq(sorts[j].Field, values[j]))
+		}
+
+		// following key
+		conds = append(conds, domain.Condition{
+			Field:    sort.Field,
+			Operator: keysetComparison(sort.Type),
+			Value:    values[i],
+		})
+		branches[i] = And(conds...)
+	}
+
+	// return comparison
+	return Or(branches...)
+}
+
+// keysetOperator returns the operator of the keys following a value in the sort direction.
+func keysetOperator(t domain.SortType) string {
+	if t == domain.SortDesc {
+		return "<"
+	}
+	return ">"
+}
+
+// keysetComparison returns the condition operator of the keys following a value in the sort
+// direction.
+func keysetComparison(t domain.SortType) domain.OperatorType {
+	if t == domain.SortDesc {
+		return domain.OperatorLessThan
+	}
+	return domain.OperatorGreaterThan
+}
+
+// checkKeyset checks the values of the keyset pagination of the query, see After.
+func (qb *Query) checkKeyset() error {
+	switch {
+	case len(qb.after) == 0:
+		return nil
+	case qb.operation != domain.OperationRead:
+		return fmt.Errorf("keyset pagination of %v query", qb.operation)
+	case len(qb.after) != len(qb.sort):
+		return fmt.Errorf("keyset pagination with %d values for %d sort keys", len(qb.after), len(qb.sort))
+	case slices.ContainsFunc(qb.sort, func(s domain.Sort) bool { return s.Nulls != domain.NullsDefault }):
+		return errors.New("keyset pagination with an order of null values, NULL sort keys cannot be compared")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package qbr
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/tyrenix/qbr/internal/array"
+)
+
+// WithoutArgCopy disables the defensive copying of the mutable params of built statements,
+// see bindParams, for hot paths which guarantee that the values passed to the query are not
+// modified until the statement is executed.
+func WithoutArgCopy() Option {
+	return func(o *Options) error {
+		// disable copying
+		o.NoArgCopy = true
+		return nil
+	}
+}
+
+// bindParams converts the params of a built statement for binding with the given options,
+// see WithDurationFormat and WithTimeZone.
+//
+// Unless disabled by WithoutArgCopy, the mutable params are copied, so the built statement
+// is not changed by modifications of the values passed to the query: byte slices and the
+// slices of arrays are copied, and times are copied without their monotonic clock reading.
+// Copying allocates once per byte slice or array param and is linear in its length; other
+// params are not copied.
+//
+// Returns the params.
+func bindParams(params []any, o Options) []any {
+	for i, param := range params {
+		// copy mutable param
+		if !o.NoArgCopy {
+			param = copyParam(param)
+		}
+
+		// convert param
+		switch v := param.(type) {
+		case time.Duration:
+			params[i] = formatDuration(v, o.DurationFormat)
+		case *time.Duration:
+			if v != nil {
+				params[i] = formatDuration(*v, o.DurationFormat)
+			} else {
+				params[i] = v
+			}
+		default:
+			params[i] = inTimeZone(param, o.TimeZone)
+		}
+	}
+
+	// return params
+	return params
+}
+
+// copyParam returns a copy of the param if it is mutable, see bindParams, or the param itself.
+func copyParam(param any) any {
+	switch v := param.(type) {
+	case []byte:
+		return cloneSlice(v)
+	case time.Time:
+		return v.Round(0)
+	case *time.Time:
+		if v == nil {
+			return v
+		}
+		t := v.Round(0)
+		return &t
+	case array.Array:
+		return array.Array{V: cloneSlice(v.V)}
+	}
+
+	// copy named byte slices
+	if t := reflect.TypeOf(param); t != nil && t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return cloneSlice(param)
+	}
+
+	// return param
+	return param
+}
+
+// cloneSlice returns a shallow copy of the given slice, nil slices are returned as they are.
+func cloneSlice(s any) any {
+	// check is non nil slice
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Slice || v.IsNil() {
+		return s
+	}
+
+	// copy slice
+	c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(c, v)
+	return c.Interface()
+}
@@ -0,0 +1,109 @@
+package qbr
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// WithPointerUpdateSemantics makes SetStruct interpret the pointer fields of update structs
+// with three-valued semantics:
+//
+//   - a nil pointer leaves the column untouched,
+//   - a pointer to a value, including the zero value, sets the column to the value,
+//   - the pointer returned by NullPtr, or a non-nil **T pointing to a nil *T, sets the column to NULL.
+//
+// So a patch struct composed of pointer fields updates exactly the columns it points to:
+//
+//	type UserPatch struct {
+//		Name  *string    `db:"name"`
+//		Score *int       `db:"score"`
+//		Seen  *time.Time `db:"seen_at"`
+//	}
+//
+//	qbr.NewUpdate(qbr.WithPointerUpdateSemantics()).SetStruct(UserPatch{Score: &zero, Seen: qbr.NullPtr[time.Time]()})
+//
+// Fields which are not pointers follow the zero value policy, and ignored fields (for example
+// "ignore_on=update") are never set.
+func WithPointerUpdateSemantics() Option {
+	return func(o *Options) error {
+		// set pointer semantics
+		o.PointerUpdates = true
+		return nil
+	}
+}
+
+// nullPointers contains the NULL sentinel pointers by their types, see NullPtr.
+var nullPointers sync.Map
+
+// NullPtr returns the NULL sentinel pointer of the type T, which sets a column to NULL in
+// updates with pointer semantics, see WithPointerUpdateSemantics.
+//
+// The same pointer is returned for every call with the same T, the value it points to must
+// not be modified.
+func NullPtr[T any]() *T {
+	// load sentinel
+	t := reflect.TypeFor[T]()
+	if p, ok := nullPointers.Load(t); ok {
+		return p.(*T)
+	}
+
+	// store sentinel
+	p, _ := nullPointers.LoadOrStore(t, new(T))
+	return p.(*T)
+}
+
+// isNullPointer checks if the value is the NULL sentinel pointer of its type, see NullPtr.
+func isNullPointer(v reflect.Value) bool {
+	p, ok := nullPointers.Load(v.Type().Elem())
+	return ok && reflect.ValueOf(p).Pointer() == v.Pointer()
+}
+
+// setValue is a value which is set regardless of the zero value policy.
+type setValue struct {
+	v any
+}
+
+// optional returns the value as a set optional, see Optional.
+func (s setValue) optional() (any, optionalState) {
+	return s.v, optionalSet
+}
+
+// setPointers applies the pointer semantics of updates to the given data, if enabled
+// (see WithPointerUpdateSemantics): pointers to values are dereferenced and set even if
+// the value is zero, and NULL pointers are set to NULL. The version column is not changed.
+//
+// Returns the data.
+func (qb *Query) setPointers(data []*domain.Data) []*domain.Data {
+	// check is pointer semantics of update
+	if !qb.options.PointerUpdates || qb.operation != domain.OperationUpdate {
+		return data
+	}
+
+	// apply pointer semantics
+	for i, d := range data {
+		// check is non nil pointer
+		v := reflect.ValueOf(d.Value)
+		if d.Field.Version || v.Kind() != reflect.Ptr || v.IsNil() {
+			continue
+		}
+
+		// check is null pointer
+		if isNullPointer(v) || v.Elem().Kind() == reflect.Ptr && v.Elem().IsNil() {
+			data[i] = NewData(d.Field, domain.ValueNull)
+			continue
+		}
+
+		// dereference pointers
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+
+		// set value
+		data[i] = NewData(d.Field, setValue{v: v.Interface()})
+	}
+
+	// return data
+	return data
+}
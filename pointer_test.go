@@ -0,0 +1,70 @@
+package qbr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+// userPatch is an update struct of pointer fields.
+type userPatch struct {
+	Name      *string     `db:"name"`
+	Email     *string     `db:"email" qbr:"ignore_on=update"`
+	Age       *int        `db:"age"`
+	Active    *bool       `db:"active"`
+	SeenAt    *time.Time  `db:"seen_at"`
+	DeletedAt **time.Time `db:"deleted_at"`
+}
+
+func TestPointerUpdateSemantics(t *testing.T) {
+	// update of the patch of user 1
+	update := func(patch userPatch, opts ...qbr.Option) *qbr.Query {
+		return qbr.NewUpdate(opts...).Table("users").SetStruct(patch).Where(qbr.Eq(qbr.FieldOf[user]("id"), 1))
+	}
+	pointers := qbr.WithPointerUpdateSemantics()
+
+	// values of the patches
+	name, age, active := "ann", 41, true
+	seen := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	empty, zero, inactive, never := "", 0, false, time.Time{}
+
+	t.Run("values", func(t *testing.T) {
+		qbrtest.Golden(t, update(userPatch{Name: &name, Age: &age, Active: &active, SeenAt: &seen}, pointers))
+	})
+
+	t.Run("zero values", func(t *testing.T) {
+		// pointers to zero values set the zero values
+		qbrtest.Golden(t, update(userPatch{Name: &empty, Age: &zero, Active: &inactive, SeenAt: &never}, pointers))
+	})
+
+	t.Run("null", func(t *testing.T) {
+		// sentinel pointers and nil inner pointers set NULL
+		var deleted *time.Time
+		qbrtest.Golden(t, update(userPatch{Name: qbr.NullPtr[string](), Age: qbr.NullPtr[int](), Active: qbr.NullPtr[bool](),
+			SeenAt: qbr.NullPtr[time.Time](), DeletedAt: &deleted}, pointers))
+	})
+
+	t.Run("untouched", func(t *testing.T) {
+		// nil pointers leave the columns untouched
+		qbrtest.Golden(t, update(userPatch{Age: &age}, pointers))
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		// fields ignored on update are never set
+		email := "ann@example.com"
+		qbrtest.Golden(t, update(userPatch{Email: &email, Name: &name}, pointers))
+	})
+
+	t.Run("without option", func(t *testing.T) {
+		// non-nil pointers are set without pointer semantics, nil pointers are skipped
+		query, params, err := update(userPatch{Name: &empty, Age: &age}).ToSQL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "UPDATE users SET name = $1, age = $2 WHERE id = $3 RETURNING *"; query != want || len(params) != 3 {
+			t.Fatalf("got %q %v, want %q", query, params, want)
+		}
+	})
+}
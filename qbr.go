@@ -1,6 +1,11 @@
 package qbr
 
-import "github.com/tyrenix/qbr/domain"
+import (
+	"errors"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
 
 // Query model.
 type Query struct {
@@ -8,46 +13,103 @@ type Query struct {
 	conditions []domain.Condition
 	sort       []domain.Sort
 	data       []domain.Data
+	rows       [][]domain.Data
 	limit      uint64
 	offset     uint64
 	operation  domain.OperationType
+	table      string
+	model      any
+	options    Options
+	err        error
+
+	allowWithoutWhere bool
+	mapColumns        []string
+	omits             []string
+	indexHints        []domain.IndexHint
+	cacheTTL          time.Duration
+	version           *domain.Data
+	skipVersion       bool
+	ignore            bool
+	tenantUnscoped    bool
+	unscoped          bool
+	tableFunc         TableFunc
+	hints             []string
+	lock              *domain.Lock
+	conflict          *domain.Conflict
+	usePrimary        bool
+	timeout           time.Duration
+	middlewares       []Middleware
+	unsafeTable       bool
+	joins             []domain.Join
+	alias             string
+	from              *domain.Source
+	ctes              []domain.CTE
+	groupBy           []domain.Field
+	having            []domain.Condition
+	after             []any
+	distinct          bool
+	distinctOn        []domain.Field
+	compounds         []domain.Compound
+	insertSelect      *domain.InsertSelect
+	using             []domain.Source
 }
 
-// New creates new query builder with given query type.
+// New creates new query builder with given query type and options.
+//
+// Conflicting options are stored as an error of the query builder.
 //
 // Returns created query builder.
-func New(t domain.OperationType) *Query {
+func New(t domain.OperationType, opts ...Option) *Query {
+	// create options
+	options, err := newOptions(opts...)
+
 	// create and return query builder
 	return &Query{
 		operation: t,
 		selects:   []domain.Field{*NewAllField()},
+		options:   options,
+		err:       err,
 	}
 }
 
-// NewCreate creates a new query builder with OperationCreate type.
+// NewCreate creates a new query builder with OperationCreate type and given options.
 //
 // Returns the created query builder.
-func NewCreate() *Query {
-	return New(domain.OperationCreate)
+func NewCreate(opts ...Option) *Query {
+	return New(domain.OperationCreate, opts...)
 }
 
-// NewRead creates a new query builder with OperationRead type.
+// NewRead creates a new query builder with OperationRead type and given options.
 //
 // Returns the created query builder.
-func NewRead() *Query {
-	return New(domain.OperationRead)
+func NewRead(opts ...Option) *Query {
+	return New(domain.OperationRead, opts...)
 }
 
-// NewUpdate creates new query builder with OperationUpdate type.
+// NewUpdate creates new query builder with OperationUpdate type and given options.
 //
 // Returns created query builder.
-func NewUpdate() *Query {
-	return New(domain.OperationUpdate)
+func NewUpdate(opts ...Option) *Query {
+	return New(domain.OperationUpdate, opts...)
 }
 
-// NewDelete creates new query builder with OperationDelete type.
+// NewDelete creates new query builder with OperationDelete type and given options.
 //
 // Returns created query builder.
-func NewDelete() *Query {
-	return New(domain.OperationDelete)
+func NewDelete(opts ...Option) *Query {
+	return New(domain.OperationDelete, opts...)
+}
+
+// addError adds the error to the errors of the query builder.
+//
+// The errors are accumulated and returned joined by ToSql and Build, so all
+// problems of the query are reported at once.
+//
+// Returns the query builder for method chaining.
+func (qb *Query) addError(err error) *Query {
+	// join errors
+	qb.err = errors.Join(qb.err, err)
+
+	// return query
+	return qb
 }
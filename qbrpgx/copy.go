@@ -0,0 +1,75 @@
+// Package qbrpgx integrates the query builder with the pgx PostgreSQL driver.
+package qbrpgx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tyrenix/qbr"
+)
+
+// CopyFromer copies rows into a table with the PostgreSQL COPY protocol.
+//
+// It is implemented by *pgx.Conn, pgx.Tx and *pgxpool.Pool.
+type CopyFromer interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// CopyFrom copies the given models of the struct type T into the table of T with the
+// PostgreSQL COPY protocol, which is much faster than multi-row INSERT statements for
+// bulk loading.
+//
+// The fields of T are mapped to the columns as by qbr.NewInsertRows, so fields ignored
+// for create operations, such as generated columns, are not copied.
+//
+// Returns the number of copied rows, or an error if the rows could not be copied, which
+// is a *qbr.RowError naming the row and the column if a value of a row could not be
+// converted, or names the row being copied if the copy failed.
+func CopyFrom[T any](ctx context.Context, conn CopyFromer, models []T, opts ...qbr.Option) (int64, error) {
+	// create rows
+	rows, err := qbr.NewInsertRows(ctx, models, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	// copy source
+	src := &copySource{rows: rows, index: -1}
+
+	// copy rows
+	n, err := conn.CopyFrom(ctx, pgx.Identifier(strings.Split(rows.Table, ".")), rows.Columns, src)
+	if err != nil {
+		// check is row failed
+		if src.index >= 0 && src.index < len(rows.Values) {
+			return n, fmt.Errorf("copy row %d: %w", src.index, err)
+		}
+		return n, err
+	}
+
+	// return copied rows
+	return n, nil
+}
+
+// copySource is a pgx.CopyFromSource of the rows to insert.
+type copySource struct {
+	rows  *qbr.InsertRows
+	index int
+}
+
+// Next advances to the next row.
+func (s *copySource) Next() bool {
+	s.index++
+	return s.index < len(s.rows.Values)
+}
+
+// Values returns the values of the current row.
+func (s *copySource) Values() ([]any, error) {
+	return s.rows.Values[s.index], nil
+}
+
+// Err returns the error of the source, which is always nil since the values are
+// converted before copying.
+func (s *copySource) Err() error {
+	return nil
+}
@@ -0,0 +1,150 @@
+package qbrpgx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tyrenix/qbr"
+)
+
+// Querier executes statements with pgx.
+//
+// It is implemented by *pgx.Conn, pgx.Tx and *pgxpool.Pool.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+// Builder builds a SQL statement for a context, it is implemented by *qbr.Query and
+// *qbr.TypedQuery.
+type Builder interface {
+	BuildContext(ctx context.Context) (*qbr.Statement, error)
+}
+
+// Exec builds the query for the context and executes it with pgx, without returning any rows.
+//
+// The params of the statement are passed to pgx as is, so values of pgx types, such as
+// pgtype.Numeric, are encoded by pgx. The execution helpers of the query, such as its
+// logger, timeout and hooks, are not applied, see qbr.Query.Exec.
+//
+// Returns the command tag of the statement, or an error if the query could not be built or
+// executed. The query is not executed if it has any errors.
+func Exec(ctx context.Context, db Querier, q Builder) (pgconn.CommandTag, error) {
+	// build query
+	stmt, err := q.BuildContext(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	// execute query
+	return db.Exec(ctx, stmt.SQL, stmt.Params...)
+}
+
+// Query builds the query for the context and executes it with pgx, returning the rows, which
+// must be closed by the caller, see Exec.
+//
+// Returns the rows, or an error if the query could not be built or executed.
+func Query(ctx context.Context, db Querier, q Builder) (pgx.Rows, error) {
+	// build query
+	stmt, err := q.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// execute query
+	return db.Query(ctx, stmt.SQL, stmt.Params...)
+}
+
+// Find builds the query for the context, executes it with pgx and scans the resulting rows
+// into a slice of the struct type T, see Exec.
+//
+// The columns are scanned by pgx into the fields of T named by their "db" tags, or by their
+// names if untagged, so fields of pgx types and the native PostgreSQL types supported by pgx,
+// such as arrays, are scanned directly. Columns without a field are rejected by pgx, fields
+// without a column are left unchanged.
+//
+// Returns the scanned slice, or an error if the query could not be built, executed or scanned.
+func Find[T any](ctx context.Context, db Querier, q Builder) ([]T, error) {
+	// execute query
+	rows, err := Query(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	// scan rows
+	return pgx.CollectRows(rows, pgx.RowToStructByNameLax[T])
+}
+
+// Get builds the query for the context, executes it with pgx and scans the first resulting row
+// into a value of the struct type T, see Find.
+//
+// Returns the scanned value, pgx.ErrNoRows if the query returned no rows, or an error if the
+// query could not be built, executed or scanned.
+func Get[T any](ctx context.Context, db Querier, q Builder) (T, error) {
+	// execute query
+	rows, err := Query(ctx, db, q)
+	if err != nil {
+		return *new(T), err
+	}
+
+	// scan row
+	return pgx.CollectOneRow(rows, pgx.RowToStructByNameLax[T])
+}
+
+// NewBatch builds the queries for the context and queues their statements in a pgx.Batch, so
+// they are sent to the database in a single round trip with SendBatch, see Exec.
+//
+// Returns the batch, or an error naming the index of the query if a query could not be built.
+// No statement is queued if any query could not be built.
+func NewBatch(ctx context.Context, queries ...Builder) (*pgx.Batch, error) {
+	// batch
+	batch := &pgx.Batch{}
+
+	// queue statements
+	for i, q := range queries {
+		// build query
+		stmt, err := q.BuildContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("batch query %d: %w", i, err)
+		}
+
+		// queue statement
+		batch.Queue(stmt.SQL, stmt.Params...)
+	}
+
+	// return batch
+	return batch, nil
+}
+
+// ExecBatch builds the queries for the context and executes them in a single batch with pgx,
+// without returning any rows, see NewBatch. Unless the batch is executed in a transaction, the
+// statements are executed in an implicit transaction, so a failed statement rolls back the
+// previous statements of the batch.
+//
+// Returns the command tags of the statements in the order of the queries, or an error naming
+// the index of the query if a query could not be built or executed.
+func ExecBatch(ctx context.Context, db Querier, queries ...Builder) ([]pgconn.CommandTag, error) {
+	// create batch
+	batch, err := NewBatch(ctx, queries...)
+	if err != nil {
+		return nil, err
+	}
+
+	// send batch
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	// read results
+	tags := make([]pgconn.CommandTag, len(queries))
+	for i := range queries {
+		if tags[i], err = results.Exec(); err != nil {
+			return tags[:i], fmt.Errorf("batch query %d: %w", i, err)
+		}
+	}
+
+	// close batch results
+	return tags, results.Close()
+}
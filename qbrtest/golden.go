@@ -0,0 +1,158 @@
+// Package qbrtest contains helpers for testing the SQL generated by the query builder.
+package qbrtest
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyrenix/qbr"
+)
+
+// update rewrites the golden files instead of comparing them, set by running the tests with
+// the flag, for example "go test ./... -qbrtest.update".
+var update = flag.Bool("qbrtest.update", false, "rewrite the qbrtest golden files")
+
+// Builder builds a SQL statement, it is implemented by *qbr.Query and *qbr.TypedQuery.
+type Builder interface {
+	Build() (*qbr.Statement, error)
+}
+
+// Golden builds the query and compares its SQL, params and debug SQL (see Statement.DebugSQL)
+// with the golden file of the test, which is "testdata/<test name>.golden" in the directory of
+// the test package. The test fails if the query could not be built or the output differs.
+//
+// The whitespace of the SQL is normalized, and the params are formatted deterministically with
+// their types, so the golden files only change with the generated statements. If the tests run
+// with the "-qbrtest.update" flag, the golden files are rewritten instead, which is how they are
+// created. Each test or subtest has one golden file, so Golden is called once per test.
+func Golden(t testing.TB, query Builder) {
+	t.Helper()
+
+	// build query
+	stmt, err := query.Build()
+	if err != nil {
+		t.Fatalf("qbrtest: build query: %v", err)
+	}
+
+	// golden file path
+	path := filepath.Join("testdata", goldenName(t.Name())+".golden")
+	got := render(stmt)
+
+	// rewrite golden file
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("qbrtest: create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("qbrtest: write golden file: %v", err)
+		}
+		return
+	}
+
+	// read golden file
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("qbrtest: read golden file, run the tests with -qbrtest.update to create it: %v", err)
+	}
+
+	// compare output
+	if got != string(want) {
+		t.Errorf("qbrtest: %s mismatch, run the tests with -qbrtest.update to rewrite it\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+// deterministicRuns is the count of the queries built by Deterministic.
+const deterministicRuns = 20
+
+// Deterministic creates and builds the query returned by fn repeatedly and fails the test if
+// the SQL or params of the statements differ, for example because the entries of a map were
+// processed in the random iteration order of Go maps. The query is created anew for every
+// build, so the order of map inputs such as the values of Query.SetMap is covered.
+func Deterministic(t testing.TB, fn func() Builder) {
+	t.Helper()
+
+	// first statement
+	var want string
+	for i := range deterministicRuns {
+		// build query
+		stmt, err := fn().Build()
+		if err != nil {
+			t.Fatalf("qbrtest: build query: %v", err)
+		}
+
+		// compare statement
+		got := render(stmt)
+		if i == 0 {
+			want = got
+		} else if got != want {
+			t.Fatalf("qbrtest: build %d differs from the first build\n--- first\n%s\n--- got\n%s", i+1, want, got)
+		}
+	}
+}
+
+// render returns the golden file contents of the statement.
+func render(stmt *qbr.Statement) string {
+	// golden file contents
+	var b strings.Builder
+
+	// write sql
+	b.WriteString("-- sql --\n")
+	b.WriteString(normalize(stmt.SQL))
+	b.WriteString("\n")
+
+	// write params
+	b.WriteString("-- params --\n")
+	for i, param := range stmt.Params {
+		fmt.Fprintf(&b, "%d: %s\n", i+1, formatParam(param))
+	}
+
+	// write debug sql
+	b.WriteString("-- debug --\n")
+	b.WriteString(normalize(stmt.DebugSQL()))
+	b.WriteString("\n")
+
+	// return contents
+	return b.String()
+}
+
+// normalize collapses the whitespace of the SQL to single spaces.
+func normalize(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// formatParam formats the param with its type, times in RFC3339 format in UTC and byte slices
+// as hex, so the output does not depend on the local time zone. Maps are formatted with sorted
+// keys by the fmt package.
+func formatParam(param any) string {
+	switch v := param.(type) {
+	case nil:
+		return "NULL"
+	case time.Time:
+		return fmt.Sprintf("time.Time(%s)", v.UTC().Format(time.RFC3339Nano))
+	case []byte:
+		return fmt.Sprintf("[]byte(%s)", hex.EncodeToString(v))
+	case string:
+		return fmt.Sprintf("string(%q)", v)
+	default:
+		return fmt.Sprintf("%T(%v)", v, v)
+	}
+}
+
+// goldenName returns the file name of the golden file of the test name, with the separators
+// of subtests and other characters which are not valid in file names replaced.
+func goldenName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		default:
+			return r
+		}
+	}, name)
+}
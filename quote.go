@@ -0,0 +1,16 @@
+package qbr
+
+import "github.com/tyrenix/qbr/internal/sqlbuilder"
+
+// ForceQuote registers identifiers which are always quoted in the generated SQL.
+//
+// Identifiers are quoted only if needed, since quoting makes them case sensitive: segments of
+// table and column names which are reserved words of the dialect (see WithDialect), such as
+// "order" or "user" in PostgreSQL, or which contain other characters than lower case letters,
+// digits and underscores, are quoted, for example `"order"` or `users."createdAt"`. Other identifiers are quoted if they
+// are registered by ForceQuote, for example keywords of newer database versions. Names set by
+// UnsafeIdent and UnsafeTable, and the SQL of raw expressions (see Expr), are never quoted.
+// Registration is safe for concurrent use.
+func ForceQuote(names ...string) {
+	sqlbuilder.ForceQuote(names...)
+}
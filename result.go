@@ -0,0 +1,64 @@
+package qbr
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Result is the result of a statement executed by Exec, with the number of affected rows
+// fetched from the driver.
+type Result struct {
+	rowsAffected int64
+	lastInsertID int64
+	hasInsertID  bool
+	result       sql.Result
+}
+
+// newResult fetches the affected rows and the last insert ID from the result of the driver.
+//
+// Returns the result, or an error if the driver could not report the affected rows.
+func newResult(result sql.Result) (*Result, error) {
+	// rows affected
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	// last insert id, not supported by all drivers, for example by PostgreSQL drivers
+	id, err := result.LastInsertId()
+
+	// return result
+	return &Result{rowsAffected: n, lastInsertID: id, hasInsertID: err == nil, result: result}, nil
+}
+
+// RowsAffected returns the number of rows affected by the statement.
+func (r *Result) RowsAffected() int64 {
+	return r.rowsAffected
+}
+
+// MustAffectOne checks that the statement affected exactly one row, for example an update of
+// a row by its ID.
+//
+// Returns ErrNotFound if no row was affected, or ErrTooManyRows if more rows were affected.
+func (r *Result) MustAffectOne() error {
+	switch {
+	case r.rowsAffected == 0:
+		return ErrNotFound
+	case r.rowsAffected > 1:
+		return fmt.Errorf("%w: %d rows affected", ErrTooManyRows, r.rowsAffected)
+	default:
+		return nil
+	}
+}
+
+// LastInsertID returns the ID generated for an inserted row by the database, and whether the
+// driver reports it: MySQL and SQLite drivers do, PostgreSQL drivers do not, use a RETURNING
+// clause there, see InsertReturningInto.
+func (r *Result) LastInsertID() (int64, bool) {
+	return r.lastInsertID, r.hasInsertID
+}
+
+// SQLResult returns the result of the driver.
+func (r *Result) SQLResult() sql.Result {
+	return r.result
+}
@@ -0,0 +1,199 @@
+package qbr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// Returning sets the fields returned by the insert, update or delete query, for example the
+// generated ID and timestamps of an insert, so they are read in the same round trip with Query
+// or Find of TypedQuery. Writes return all columns by default; the fields replace them like
+// Select does, and without fields no columns are returned.
+//
+// The fields are returned by a RETURNING clause in PostgreSQL and SQLite, and by an OUTPUT
+// clause of the INSERTED or DELETED columns in SQL Server, which supports plain columns only.
+// MySQL does not return the rows of writes, so fields other than the default are rejected
+// there when the query is built. Returning on read queries is stored as an error of the query.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Returning(fields ...*domain.Field) *Query {
+	// check is write
+	if qb.operation == domain.OperationRead {
+		return qb.addError(errors.New("Returning on read query"))
+	}
+
+	// set returned fields
+	return qb.Select(fields...)
+}
+
+// InsertReturningInto inserts the struct pointed to by model into the table of T, as an insert
+// query with SetStruct, and scans the returned columns back into the struct, so columns set by
+// the database, such as generated IDs and timestamps of defaults or triggers, are set in the
+// struct. All columns are returned, and fields without a returned column keep their values.
+// The write hooks are called as by Exec.
+//
+// Returns sql.ErrNoRows if no row was inserted, for example if it was skipped due to a conflict,
+// or an error if the query could not be built, executed or scanned.
+func InsertReturningInto[T any](ctx context.Context, exec Executor, model *T, opts ...Option) error {
+	// check is model not nil
+	if model == nil {
+		return errors.New("nil model in InsertReturningInto")
+	}
+
+	// call before write hooks
+	q, err := NewCreate(opts...).SetStruct(model).runBeforeWrite(ctx)
+	if err != nil {
+		return err
+	}
+
+	// build query
+	stmt, err := q.BuildContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// execute query
+	rows, err := q.queryContext(ctx, exec, stmt)
+	if err != nil {
+		return err
+	}
+
+	// scan returned row
+	n, err := scanInto(rows, q.options, []*T{model})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	// call after write hooks
+	return q.runAfterWrite(ctx, q.afterWriteHooks(), stmt, int64(n))
+}
+
+// InsertManyReturningInto inserts the given models into the table of T with multi-row INSERT
+// statements, see InsertManyChunked, and scans the returned rows back into the models, matched
+// by their position. The chunks are executed using the given executor, pass a *sql.Tx to insert
+// all chunks in a single transaction.
+//
+// The returned rows are matched by position since PostgreSQL returns the rows of a multi-row
+// INSERT in the order of its values; skipping conflicting rows (see WithSkipConflicts) is not
+// supported, since the positions would not match then.
+//
+// Returns a *ChunkError if a chunk could not be built, executed or scanned, or did not return
+// a row for every model; the following chunks are not executed then.
+func InsertManyReturningInto[T any](ctx context.Context, exec Executor, models []T, opts ...Option) error {
+	// options
+	o, err := newOptions(opts...)
+	if err != nil {
+		return err
+	}
+
+	// check is conflict skipping
+	if o.SkipConflicts {
+		return errors.New("InsertManyReturningInto does not support WithSkipConflicts")
+	}
+
+	// compute chunk size
+	chunkSize := defaultChunkSize[T](0, o)
+
+	// create rows
+	insert, err := NewInsertRows(ctx, models, opts...)
+	if err != nil {
+		// check is row failed
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			return &ChunkError{Chunk: rowErr.Row / chunkSize, Row: rowErr.Row, Err: err}
+		}
+		return err
+	}
+
+	// returned fields
+	returning := []domain.Field{*NewAllField()}
+
+	// insert chunks
+	for chunk, start := 0, 0; start < len(insert.Values); chunk, start = chunk+1, start+chunkSize {
+		// chunk rows
+		end := min(start+chunkSize, len(insert.Values))
+
+		// build statement
+		query, params, err := sqlbuilder.CreateInsertManySql(insert.Table, insert.Columns, insert.Values[start:end], nil, returning, o.sqlDialect(o.Placeholder))
+		if err != nil {
+			return &ChunkError{Chunk: chunk, Row: start, Err: err}
+		}
+
+		// statement
+		stmt := &Statement{SQL: query, Params: bindParams(params, o), Placeholder: o.Placeholder}
+
+		// execute statement
+		rows, err := exec.QueryContext(ctx, stmt.SQL, stmt.Params...)
+
+		// log statement
+		if o.Logger != nil {
+			o.Logger.LogQuery(ctx, stmt, err)
+		}
+
+		// check is statement failed
+		if err != nil {
+			return &ChunkError{Chunk: chunk, Row: start, Err: err}
+		}
+
+		// models of chunk
+		dest := make([]*T, end-start)
+		for i := range dest {
+			dest[i] = &models[start+i]
+		}
+
+		// scan returned rows
+		n, err := scanInto(rows, o, dest)
+		if err != nil {
+			return &ChunkError{Chunk: chunk, Row: start, Err: err}
+		}
+		if n != len(dest) {
+			return &ChunkError{Chunk: chunk, Row: start, Err: fmt.Errorf("insert returned %d rows for %d models", n, len(dest))}
+		}
+	}
+
+	// return success
+	return nil
+}
+
+// scanInto scans the rows into the structs pointed to by dest in order and closes the rows,
+// see scanAll. Fields without a returned column keep their values.
+//
+// Returns the number of scanned rows, or an error if the rows could not be scanned or there
+// are more rows than structs.
+func scanInto[T any](rows *sql.Rows, o Options, dest []*T) (int, error) {
+	// close rows
+	defer rows.Close()
+
+	// map columns to struct fields
+	columns, targets, err := scanColumns(rows, reflect.TypeFor[T](), o)
+	if err != nil {
+		return 0, err
+	}
+
+	// scan rows
+	n := 0
+	for rows.Next() {
+		// check is struct left
+		if n == len(dest) {
+			return n, fmt.Errorf("more returned rows than %d structs", len(dest))
+		}
+
+		// scan row
+		if err := rows.Scan(scanDests(reflect.ValueOf(dest[n]).Elem(), columns, targets, o, nil)...); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	// return scanned rows
+	return n, rows.Err()
+}
@@ -0,0 +1,94 @@
+package qbr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync/atomic"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// routeContextKey is the context key of the route of a statement, see Router.
+type routeContextKey struct{}
+
+// route is the route of a statement executed by the query builder.
+type route struct {
+	operation domain.OperationType // Operation of the query.
+	primary   bool                 // Is the query forced to the writer, see Query.UsePrimary.
+}
+
+// Router is an Executor splitting reads and writes between a writer and read replicas.
+//
+// SELECT queries executed by the query builder are sent to the readers, chosen round-robin,
+// and all other statements are sent to the writer: queries of other operations, queries
+// forced to the writer by Query.UsePrimary, statements executed in a transaction of
+// Transact, and statements executed directly on the router. Transactions begun by the
+// router are transactions of the writer, so all their statements go to the writer.
+type Router struct {
+	writer  Executor
+	readers []Executor
+	next    atomic.Uint64
+}
+
+// NewRouter creates a Router sending writes to the writer and reads to the readers. If no
+// readers are given, all statements are sent to the writer.
+//
+// Returns created router.
+func NewRouter(writer Executor, readers ...Executor) *Router {
+	return &Router{
+		writer:  writer,
+		readers: readers,
+	}
+}
+
+// ExecContext executes the statement on the executor of its route.
+func (r *Router) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return r.executor(ctx).ExecContext(ctx, query, args...)
+}
+
+// QueryContext executes the statement on the executor of its route.
+func (r *Router) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return r.executor(ctx).QueryContext(ctx, query, args...)
+}
+
+// BeginTx begins a transaction of the writer, which must implement TxBeginner.
+func (r *Router) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	// check is beginner
+	db, ok := r.writer.(TxBeginner)
+	if !ok {
+		return nil, errors.New("router writer does not begin transactions")
+	}
+
+	// begin transaction
+	return db.BeginTx(ctx, opts)
+}
+
+// executor returns the executor of the route of the context.
+func (r *Router) executor(ctx context.Context) Executor {
+	// check is read outside of transaction
+	rt, ok := ctx.Value(routeContextKey{}).(route)
+	if !ok || rt.primary || rt.operation != domain.OperationRead || len(r.readers) == 0 || ctx.Value(txContextKey{}) != nil {
+		return r.writer
+	}
+
+	// return next reader
+	return r.readers[(r.next.Add(1)-1)%uint64(len(r.readers))]
+}
+
+// UsePrimary forces the query to the writer of a Router, for example to read the rows
+// written before, which may not be replicated to the readers yet.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) UsePrimary() *Query {
+	// use primary
+	qb.usePrimary = true
+
+	// return query
+	return qb
+}
+
+// routeContext returns the context with the route of the query, see Router.
+func (qb *Query) routeContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routeContextKey{}, route{operation: qb.operation, primary: qb.usePrimary})
+}
@@ -0,0 +1,245 @@
+package qbr
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/array"
+	"github.com/tyrenix/qbr/internal/uuid"
+)
+
+// ScanAll scans all rows into a slice of T and closes the rows.
+//
+// T must be a struct type. The columns of the rows are mapped to the struct
+// fields by their "db" annotations (or the column overriding it for read
+// operations), including the fields of embedded structs and of nested structs
+// whose columns are prefixed with the tag of the struct, such as `db:"address_"`.
+// Nil pointers to embedded and nested structs are allocated. A column is scanned into the
+// least nested field of the column. Columns without a matching field are discarded, fields of types
+// with a registered scan function are scanned by it (see RegisterScanner), fields
+// annotated with "json" are unmarshalled from JSON, other slice fields than byte slices
+// are scanned from arrays in the PostgreSQL text format, and UUIDs stored in 16
+// byte arrays are scanned from the text or the binary format.
+//
+// Returns the scanned slice, or an error if the rows could not be scanned.
+func ScanAll[T any](rows *sql.Rows) ([]T, error) {
+	return scanAll[T](rows, Options{TagName: string(domain.QueryDB)}, nil)
+}
+
+// scanAll scans all rows into a slice of T and closes the rows, see ScanAll.
+//
+// The columns are mapped to the struct fields by the struct tag of the given options, and
+// times are scanned in their time zone (see WithTimeZone). The columns in extra are scanned
+// into their destinations instead.
+func scanAll[T any](rows *sql.Rows, o Options, extra map[string]any) ([]T, error) {
+	// scanned values
+	var result []T
+
+	// scan rows
+	if err := scanSlice(rows, reflect.ValueOf(&result).Elem(), o, extra); err != nil {
+		return nil, err
+	}
+
+	// return result
+	return result, nil
+}
+
+// scanSlice scans all rows into the slice value dest and closes the rows, see scanAll. The
+// elements of the slice are structs or pointers to structs.
+func scanSlice(rows *sql.Rows, dest reflect.Value, o Options, extra map[string]any) error {
+	// close rows
+	defer rows.Close()
+
+	// struct type of elements
+	t := dest.Type().Elem()
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+
+	// map columns to struct fields
+	columns, targets, err := scanColumns(rows, t, o)
+	if err != nil {
+		return err
+	}
+
+	// scan rows
+	for rows.Next() {
+		// new struct value
+		ptr := reflect.New(t)
+
+		// scan row
+		if err := rows.Scan(scanDests(ptr.Elem(), columns, targets, o, extra)...); err != nil {
+			return err
+		}
+
+		// add value
+		if isPtr {
+			dest.Set(reflect.Append(dest, ptr))
+		} else {
+			dest.Set(reflect.Append(dest, ptr.Elem()))
+		}
+	}
+
+	// return rows error
+	return rows.Err()
+}
+
+// scanOne scans the first row into the struct value dest and closes the rows, see scanAll.
+//
+// Returns sql.ErrNoRows if there are no rows.
+func scanOne(rows *sql.Rows, dest reflect.Value, o Options) error {
+	// close rows
+	defer rows.Close()
+
+	// map columns to struct fields
+	columns, targets, err := scanColumns(rows, dest.Type(), o)
+	if err != nil {
+		return err
+	}
+
+	// check is row found
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+
+	// scan row
+	if err := rows.Scan(scanDests(dest, columns, targets, o, nil)...); err != nil {
+		return err
+	}
+
+	// close rows and return their error
+	return rows.Close()
+}
+
+// scanColumns maps the columns of the rows to the fields of the struct type t by the struct
+// tag of the given options, see scanAll.
+//
+// Returns the columns and the struct field of every column, which is nil if the column has no
+// field, or an error if t is not a struct type or the fields could not be extracted.
+func scanColumns(rows *sql.Rows, t reflect.Type, o Options) ([]string, []*structField, error) {
+	// check is struct
+	if t.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("unsupported scan type: %v", t)
+	}
+
+	// extract fields
+	fields, err := flattenFields(t, o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// get columns
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// struct field of every column, nil if not found
+	targets := make([]*structField, len(columns))
+	for i, column := range columns {
+		targets[i] = findField(fields, column)
+	}
+
+	// return mapping
+	return columns, targets, nil
+}
+
+// scanDests returns the scan destinations of the given columns in the struct value val, which
+// are the struct fields of the columns, see scanAll.
+func scanDests(val reflect.Value, columns []string, targets []*structField, o Options, extra map[string]any) []any {
+	// scan destinations
+	dest := make([]any, len(columns))
+	for i, target := range targets {
+		// scan extra columns
+		if d, ok := extra[columns[i]]; ok {
+			dest[i] = d
+			continue
+		}
+
+		// discard unknown columns
+		if target == nil {
+			dest[i] = new(any)
+			continue
+		}
+
+		// scan to struct field, with registered scanners, json fields from json and slices as arrays
+		fv := fieldByIndex(val, target.index)
+		dest[i] = fv.Addr().Interface()
+		switch scanner, ok := registeredScanner(fv.Addr()); {
+		case ok:
+			dest[i] = scanner
+		case target.field.JSON:
+			dest[i] = &jsonScanner{v: dest[i]}
+		case array.IsArray(fv.Interface()):
+			dest[i] = &array.Array{V: dest[i]}
+		case isUUIDField(fv):
+			dest[i] = &uuid.UUID{V: dest[i]}
+		case o.TimeZone != nil && isTimeField(fv):
+			dest[i] = &timeScanner{v: dest[i], loc: o.TimeZone}
+		}
+	}
+
+	// return destinations
+	return dest
+}
+
+// findField returns the least nested field which is read from the given column, or nil if
+// there is no such field.
+func findField(fields []structField, column string) *structField {
+	// find field
+	var found *structField
+	for i, field := range fields {
+		if field.field.Column(domain.OperationRead) == column && (found == nil || len(field.index) < len(found.index)) {
+			found = &fields[i]
+		}
+	}
+
+	// return field
+	return found
+}
+
+// isUUIDField checks if the struct field is a UUID or a pointer to a UUID stored in a 16 byte
+// array, which does not implement sql.Scanner.
+func isUUIDField(v reflect.Value) bool {
+	// uuid type
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// check is uuid without scanner
+	return uuid.IsUUID(t) && !reflect.PointerTo(t).Implements(reflect.TypeFor[sql.Scanner]())
+}
+
+// jsonScanner scans a JSON value into the value pointed to by v, NULL values set
+// the zero value.
+type jsonScanner struct {
+	v any
+}
+
+// Scan unmarshals the JSON source into the value.
+func (s *jsonScanner) Scan(src any) error {
+	// source data
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		reflect.ValueOf(s.v).Elem().SetZero()
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("unsupported json source: %T", src)
+	}
+
+	// unmarshal value
+	return json.Unmarshal(data, s.v)
+}
@@ -0,0 +1,84 @@
+package qbr
+
+import (
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// Scope is a named, reusable fragment of conditions, for example a business rule
+// which is used in many queries.
+//
+// Parameterized scopes are defined as functions returning a Scope:
+//
+//	func Visible(now time.Time) qbr.Scope {
+//		return qbr.DefineScope("visible",
+//			qbr.Eq(Status, "published"),
+//			qbr.Eq(DeletedAt, domain.ValueNull),
+//			qbr.LtOrEq(PublishAt, now),
+//		)
+//	}
+type Scope struct {
+	// Name is the name of the scope, used in error messages.
+	Name string
+
+	// Conditions are the conditions of the scope, combined with AND.
+	Conditions []domain.Condition
+}
+
+// DefineScope creates a new scope with the given name and conditions.
+//
+// Returns created scope.
+func DefineScope(name string, conds ...domain.Condition) Scope {
+	return Scope{
+		Name:       name,
+		Conditions: conds,
+	}
+}
+
+// Scope adds the conditions of the given scopes to the query, see Where.
+//
+// The conditions and their fields are deep-copied before they are added, so a
+// scope can be shared between queries without being mutated by any of them. Invalid
+// conditions of a scope are stored as an error of the query, prefixed with the scope
+// name. The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Scope(scopes ...Scope) *Query {
+	for _, scope := range scopes {
+		// check conditions fields
+		if err := checkConditionFields(scope.Conditions); err != nil {
+			qb.addError(fmt.Errorf("scope %q: %w", scope.Name, err))
+			continue
+		}
+
+		// add copied conditions
+		qb.Where(cloneScopeConditions(scope.Conditions)...)
+	}
+
+	// return query
+	return qb
+}
+
+// cloneScopeConditions returns a deep copy of the given conditions, including
+// their fields and the sub conditions of logical conditions.
+func cloneScopeConditions(conds []domain.Condition) []domain.Condition {
+	// copy conditions
+	result := cloneConditions(conds)
+
+	// copy fields
+	for i := range result {
+		// copy sub conditions fields
+		if sub, ok := result[i].Value.([]domain.Condition); ok {
+			result[i].Value = cloneScopeConditions(sub)
+			continue
+		}
+
+		// copy field
+		if result[i].Field != nil {
+			field := *result[i].Field
+			result[i].Field = &field
+		}
+	}
+
+	// return conditions
+	return result
+}
@@ -1,18 +1,31 @@
 package qbr
 
-import "github.com/tyrenix/qbr/domain"
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+)
 
 // Select sets the fields to be selected in the query. If no fields are
 // specified, all fields are selected. The fields parameter is a variable
 // argument list, so you can pass in any number of fields or an array/slice
-// of fields. The method returns the QueryBuilder instance to support method
-// chaining.
+// of fields. Nil fields are stored as an error of the query. The method
+// returns the QueryBuilder instance to support method chaining.
 func (qb *Query) Select(fields ...*domain.Field) *Query {
 	// set select to null
 	qb.selects = nil
 
 	// add fields to query
 	for _, field := range fields {
+		// check is field not nil
+		if field == nil {
+			qb.addError(errors.New("nil field in select"))
+			continue
+		}
+
+		// add field
 		qb.selects = append(qb.selects, *field)
 	}
 
@@ -20,13 +33,77 @@ func (qb *Query) Select(fields ...*domain.Field) *Query {
 	return qb
 }
 
+// SelectColumns adds the fields of the model of the query (see Model) with the given "db"
+// annotations to the fields to be selected in the query, so a subset of the struct fields
+// can be selected without defining another struct. Scanning the results into the full
+// struct leaves the fields which are not selected at their zero values.
+//
+// The fields are added to the fields already selected, so they can be combined with
+// aggregations; the default selection of all fields is replaced. Unknown names are stored
+// as an error of the query. The method returns the QueryBuilder instance to support method
+// chaining.
+func (qb *Query) SelectColumns(names ...string) *Query {
+	// model type
+	t := structTypeOf(qb.model)
+	if t == nil {
+		return qb.addError(errors.New("SelectColumns requires a struct model"))
+	}
+
+	// extract fields
+	fields, err := extractModelFields(t, qb.options)
+	if err != nil {
+		return qb.addError(err)
+	}
+
+	// replace default selection of all fields
+	if len(qb.selects) == 1 && qb.selects[0].DB == "*" && qb.selects[0].Aggregation == domain.AggregationNone {
+		qb.selects = nil
+	}
+
+	// unknown names
+	var unknown []string
+
+	// add fields
+	for _, name := range names {
+		// find field
+		i := slices.IndexFunc(fields, func(f *domain.Field) bool {
+			return f != nil && f.DB == name
+		})
+		if i < 0 {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		// add field
+		qb.selects = append(qb.selects, *fields[i])
+	}
+
+	// check is unknown names found
+	if len(unknown) > 0 {
+		qb.addError(fmt.Errorf("unknown columns %q for model %v", unknown, t))
+	}
+
+	// return query
+	return qb
+}
+
 // GetSelects returns the select fields set for the query builder, or an empty slice if no select fields have been set.
+//
+// Omitted fields (see Omit) are not returned, and the selection of all fields is expanded to the
+// fields of the model if any field is omitted.
 func (qb *Query) GetSelects() []domain.Field {
 	// conditions for returning
-	fields := make([]domain.Field, len(qb.selects))
+	fields := make([]domain.Field, 0, len(qb.selects))
 
-	// copy query  conditions
-	copy(fields, qb.selects)
+	// copy query conditions
+	for _, field := range qb.selects {
+		switch {
+		case len(qb.omits) > 0 && field.DB == "*" && field.Aggregation == domain.AggregationNone:
+			fields = append(fields, qb.expandAllFields()...)
+		case !qb.isOmitted(&field):
+			fields = append(fields, field)
+		}
+	}
 
 	// return copy conditions
 	return fields
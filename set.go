@@ -1,11 +1,17 @@
 package qbr
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
 	"github.com/tyrenix/qbr/domain"
 )
 
 // NewData creates a new instance of domain.Data with the specified field and value.
 // It takes a pointer to a domain.Field and a value of any type as arguments.
+// The value can be an expression (see Func), which is rendered in place of a placeholder.
 // Returns a pointer to the newly created domain.Data object containing the provided field and value.
 func NewData(field *domain.Field, value any) *domain.Data {
 	return &domain.Data{
@@ -15,14 +21,33 @@ func NewData(field *domain.Field, value any) *domain.Data {
 }
 
 // Set adds the specified Data objects to the QueryBuilder's data list. If a Data object's Value is
-// nil or zero, it is ignored and not added. Additionally, if the Data object's Field is ignored for
-// the current query type, it is also ignored and not added. Returns the modified QueryBuilder
+// nil or zero, it is ignored and not added (see ZeroValuePolicy), zero values of fields annotated
+// with "keep_zero" are added, values implementing driver.Valuer whose database value is NULL, such
+// as sql.NullString with Valid false, are set to NULL, values of Optional are added unless they are
+// unset. Additionally, if the Data object's Field is ignored for the current query type, it is also
+// ignored and not added. Nil Data objects or Data objects with a nil Field are stored as an error
+// of the query. Data for columns set by SetMap are ignored too. Returns the modified QueryBuilder
 // instance for method chaining.
 func (qb *Query) Set(data ...*domain.Data) *Query {
 	// add data to query
 	for _, d := range data {
-		// check is value is nil
-		if d.Field == nil || isZero(d.Value) {
+		// check is field not nil
+		if d == nil || d.Field == nil {
+			qb.addError(errors.New("nil field in data"))
+			continue
+		}
+
+		// resolve optional value, unset values are skipped and set values are kept even if zero
+		if value, state, ok := resolveOptional(d.Value); ok {
+			if state == optionalUnset {
+				continue
+			}
+			d = NewData(d.Field, value)
+		} else if isNullValuer(d.Value) {
+			// set driver values of NULL, such as invalid sql.NullString, to NULL
+			d = NewData(d.Field, domain.ValueNull)
+		} else if isSkipped(d.Value, fieldPolicy(d.Field, qb.options.ZeroValuePolicy)) {
+			// check is value is nil
 			continue
 		}
 
@@ -31,6 +56,11 @@ func (qb *Query) Set(data ...*domain.Data) *Query {
 			continue
 		}
 
+		// check is set by map, map entries win on conflict
+		if slices.Contains(qb.mapColumns, d.Field.DB) {
+			continue
+		}
+
 		// add data
 		qb.data = append(qb.data, *d)
 	}
@@ -39,24 +69,291 @@ func (qb *Query) Set(data ...*domain.Data) *Query {
 	return qb
 }
 
-// SetStruct adds the fields of the given struct to the QueryBuilder's data list, excluding any fields
-// with a nil value or that do not have a "db" annotation. The struct is first dereferenced if it is a
-// pointer. The method returns the modified QueryBuilder instance for method chaining.
+// SetStruct adds the fields of the given struct to the QueryBuilder's data list in the order of
+// their declaration, excluding any fields with a nil value or that do not have a "db" annotation.
+// The struct is first dereferenced if it is a pointer. The fields of embedded structs are added as
+// fields of the struct, and the fields of nested structs whose tag ends with "_", such as
+// `db:"address_"`, are added with the tag as prefix of their columns, for example "address_city";
+// the fields of nil pointers to such structs are not added. If the struct annotations could not be
+// parsed, the error is stored in the query and returned by ToSql. If no model has been set, the
+// struct is used as the model of the query. For create queries, zero values of fields with a
+// client-side default, such as "default=new_uuid", are set to a new default; the struct itself is
+// not modified. For update queries, pointer fields can be interpreted with three-valued semantics,
+// see WithPointerUpdateSemantics.
+//
+// For create queries, s may also be a slice of structs or of pointers to structs, which inserts a
+// row for every element with a single multi-row INSERT. The fields are extracted once for the
+// struct type, and all the fields which are not ignored for create operations are inserted for
+// every row, including zero values, so the rows share their columns; unset Optional values are
+// inserted as NULL. Columns of the primary key and with a client-side default which are zero or
+// NULL in every row are not inserted, as with a single struct.
+// The method returns the modified QueryBuilder instance for method chaining.
 func (qb *Query) SetStruct(s any) *Query {
+	// set rows of slice
+	if v := reflect.ValueOf(s); v.Kind() == reflect.Slice {
+		return qb.setStructs(v)
+	}
+
+	// set model
+	if qb.model == nil {
+		qb.model = s
+	}
+
 	// extract data from struct
-	data := extractDataFromStruct(s)
+	data, err := extractDataFromStruct(s, qb.options)
+	if err != nil {
+		return qb.addError(err)
+	}
+
+	// set client-side defaults
+	data, err = qb.setDefaults(data)
+	if err != nil {
+		return qb.addError(err)
+	}
 
 	// set data to query
-	return qb.Set(data...)
+	return qb.Set(qb.setVersion(qb.setPointers(data))...)
+}
+
+// SetMap adds the column values of the map to the QueryBuilder's data list.
+//
+// The columns are validated against the "db" annotations of the model of the query (see Model),
+// columns which are unknown or ignored for the current query type are stored as an error of the
+// query. The values are added as they are, without skipping zero values, and nil values are set
+// to NULL. The columns are added in sorted order, so the generated SQL is deterministic.
+//
+// Map entries win on conflict with data set by Set or SetStruct, regardless of the call order.
+// Returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) SetMap(values map[string]any) *Query {
+	// model type
+	t := structTypeOf(qb.model)
+	if t == nil {
+		return qb.addError(errors.New("SetMap requires a struct model"))
+	}
+
+	// extract fields
+	fields, err := modelFields(t, qb.options)
+	if err != nil {
+		return qb.addError(err)
+	}
+
+	// add values in sorted order
+	for _, column := range sortedKeys(values) {
+		// find field
+		i := slices.IndexFunc(fields, func(f structField) bool {
+			return f.field.DB == column
+		})
+		if i < 0 {
+			qb.addError(fmt.Errorf("unknown column %q for model %v", column, t))
+			continue
+		}
+
+		// check is ignored
+		if isFieldIgnored(fields[i].field, qb.operation) {
+			qb.addError(fmt.Errorf("column %q is ignored on %v", column, qb.operation))
+			continue
+		}
+
+		// value, nil, unset optionals and driver values of NULL are set to NULL
+		value, _, _ := resolveOptional(values[column])
+		if isNil(value) || isNullValuer(value) {
+			value = domain.ValueNull
+		}
+
+		// data
+		d := domain.Data{Field: fields[i].field, Value: value}
+
+		// replace existing data of column
+		if j := slices.IndexFunc(qb.data, func(d domain.Data) bool { return d.Field.DB == column }); j >= 0 {
+			qb.data[j] = d
+		} else {
+			qb.data = append(qb.data, d)
+		}
+
+		// mark column as set by map
+		if !slices.Contains(qb.mapColumns, column) {
+			qb.mapColumns = append(qb.mapColumns, column)
+		}
+	}
+
+	// return query
+	return qb
+}
+
+// setStructs sets the rows of the multi-row insert to the elements of the slice of structs or
+// pointers to structs, see SetStruct. The first row is the data of the query, the other rows
+// are stored in the rows of the query.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) setStructs(v reflect.Value) *Query {
+	// check is create
+	if qb.operation != domain.OperationCreate {
+		return qb.addError(fmt.Errorf("SetStruct with a slice on %v query", qb.operation))
+	}
+
+	// check is not empty
+	if v.Len() == 0 {
+		return qb.addError(ErrEmptyInsert)
+	}
+
+	// struct type of elements
+	t := structTypeOf(reflect.Zero(v.Type().Elem()).Interface())
+	if t == nil {
+		return qb.addError(fmt.Errorf("unsupported SetStruct slice type: %v", v.Type()))
+	}
+
+	// check is data not set
+	if len(qb.data) > 0 || len(qb.mapColumns) > 0 {
+		return qb.addError(errors.New("SetStruct with a slice on query with data"))
+	}
+
+	// set model
+	if qb.model == nil {
+		qb.model = v.Index(0).Interface()
+	}
+
+	// extract fields once
+	fields, err := modelFields(t, qb.options)
+	if err != nil {
+		return qb.addError(err)
+	}
+
+	// create rows
+	rows := make([][]domain.Data, v.Len())
+	for i := range rows {
+		// struct value
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				return qb.addError(fmt.Errorf("nil element %d in SetStruct slice", i))
+			}
+			elem = elem.Elem()
+		}
+
+		// row data of inserted fields, unset optionals and fields of nil nested structs are
+		// inserted as NULL
+		var data []*domain.Data
+		for _, field := range fields {
+			if isFieldIgnored(field.field, qb.operation) {
+				continue
+			}
+			var value any
+			if fv, ok := valueByIndex(elem, field.index); ok {
+				value, _, _ = resolveOptional(fv.Interface())
+			}
+			if value == nil {
+				value = domain.ValueNull
+			}
+			data = append(data, NewData(field.field, value))
+		}
+
+		// set client-side defaults
+		data, err = qb.setDefaults(data)
+		if err != nil {
+			return qb.addError(fmt.Errorf("row %d: %w", i, err))
+		}
+
+		// set row
+		for _, d := range data {
+			rows[i] = append(rows[i], *d)
+		}
+	}
+
+	// omit keys which are not set in any row
+	rows = omitUnsetKeys(rows)
+
+	// check columns
+	if len(rows[0]) == 0 {
+		return qb.addError(ErrEmptyInsert)
+	}
+
+	// set first row as data and other rows
+	qb.data = rows[0]
+	qb.rows = rows[1:]
+
+	// return query
+	return qb
+}
+
+// omitUnsetKeys returns the rows of a multi-row insert without the columns of the primary key
+// and with a client-side default whose values are zero or NULL in every row, which are left to
+// the database. Zero values of fields annotated with "keep_zero" are kept.
+func omitUnsetKeys(rows [][]domain.Data) [][]domain.Data {
+	// check is column unset in every row
+	unset := func(j int) bool {
+		for _, row := range rows {
+			if row[j].Value != domain.ValueNull && !isZero(row[j].Value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// omitted columns
+	var omitted []int
+	for j, d := range rows[0] {
+		if (d.Field.PK || d.Field.Default != "") && !d.Field.KeepZero && unset(j) {
+			omitted = append(omitted, j)
+		}
+	}
+
+	// check is column omitted
+	if len(omitted) == 0 {
+		return rows
+	}
+
+	// remove omitted columns, starting with the last one
+	for i := range rows {
+		for _, j := range slices.Backward(omitted) {
+			rows[i] = slices.Delete(rows[i], j, j+1)
+		}
+	}
+
+	// return rows
+	return rows
+}
+
+// GetRows returns the data of all the rows of a multi-row insert, starting with the data of
+// the query (see GetData), or nil if the query inserts a single row, see SetStruct. Data of
+// omitted fields (see Omit) is not returned.
+func (qb *Query) GetRows() [][]domain.Data {
+	// check is multi-row
+	if len(qb.rows) == 0 {
+		return nil
+	}
+
+	// rows without omitted fields
+	rows := make([][]domain.Data, 0, len(qb.rows)+1)
+	rows = append(rows, qb.GetData())
+	for _, row := range qb.rows {
+		data := make([]domain.Data, 0, len(row))
+		for _, d := range row {
+			if !qb.isOmitted(d.Field) {
+				data = append(data, d)
+			}
+		}
+		rows = append(rows, data)
+	}
+
+	// return rows
+	return rows
 }
 
 // GetData returns the data set for the query, or an empty slice if no data has been set.
+// Data of omitted fields (see Omit) is not returned.
 func (qb *Query) GetData() []domain.Data {
 	// init new data slice
-	data := make([]domain.Data, len(qb.data))
+	data := make([]domain.Data, 0, len(qb.data))
+
+	// copy slice without omitted fields
+	for _, d := range qb.data {
+		if !qb.isOmitted(d.Field) {
+			data = append(data, d)
+		}
+	}
 
-	// copy slice
-	copy(data, qb.data)
+	// add version column
+	data = append(data, qb.versionData()...)
 
 	// return data
 	return data
@@ -0,0 +1,67 @@
+package qbr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestSetMap(t *testing.T) {
+	// update of profile 1 from a map
+	update := func() qbrtest.Builder {
+		return qbr.NewUpdate().Model(profile{}).SetMap(map[string]any{
+			"zip": "0154", "age": 41, "city": "Oslo", "verified": true, "email": "ann@example.com",
+			"score": 97, "street": nil, "login": "ann", "country": "NO", "phone": "+4712345678",
+		}).Where(qbr.Eq(qbr.FieldOf[profile]("id"), 1))
+	}
+
+	t.Run("sorted columns", func(t *testing.T) {
+		qbrtest.Golden(t, update())
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		qbrtest.Deterministic(t, update)
+	})
+}
+
+func TestSetStructs(t *testing.T) {
+	// item with a primary key generated by the database
+	type item struct {
+		ID   int64  `db:"id" qbr:"pk"`
+		Name string `db:"name"`
+	}
+
+	t.Run("unset primary key", func(t *testing.T) {
+		// the primary key is not inserted, as with a single struct
+		single, _, err := qbr.NewCreate().Table("items").SetStruct(item{Name: "a"}).ToSQL()
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		query, params, err := qbr.NewCreate().Table("items").SetStruct([]item{{Name: "a"}, {Name: "b"}}).ToSQL()
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if want := strings.Replace(single, "($1)", "($1), ($2)", 1); query != want {
+			t.Fatalf("got %q, want %q", query, want)
+		}
+		if len(params) != 2 || params[0] != "a" || params[1] != "b" {
+			t.Fatalf("got params %v, want [a b]", params)
+		}
+	})
+
+	t.Run("primary key set in a row", func(t *testing.T) {
+		// the primary key is inserted for every row, since the rows share their columns
+		query, params, err := qbr.NewCreate().Table("items").SetStruct([]item{{ID: 1, Name: "a"}, {Name: "b"}}).ToSQL()
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if want := "INSERT INTO items (id, name) VALUES ($1, $2), ($3, $4) RETURNING *"; query != want {
+			t.Fatalf("got %q, want %q", query, want)
+		}
+		if len(params) != 4 || params[0] != int64(1) || params[2] != int64(0) {
+			t.Fatalf("got params %v, want [1 a 0 b]", params)
+		}
+	})
+}
@@ -1,6 +1,11 @@
 package qbr
 
-import "github.com/tyrenix/qbr/domain"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+)
 
 // NewSortDesc creates a new instance of domain.Sort with descending sort type.
 //
@@ -25,10 +30,100 @@ func NewSortAsc(field *domain.Field) *domain.Sort {
 	}
 }
 
-// Sort add sort.
+// Sort directions of OrderBy.
+const (
+	Asc  = domain.SortAsc
+	Desc = domain.SortDesc
+)
+
+// Orders of NULL values of OrderBy.
+const (
+	NullsFirst = domain.NullsFirst
+	NullsLast  = domain.NullsLast
+)
+
+// OrderBy adds a sort by the field or expression in the given direction to the query, with the
+// order of NULL values, if given, for example:
+//
+//	qb.OrderBy(FieldOf[User]("last_login"), Desc, NullsLast)
+//	qb.OrderBy(Case().When(Eq(status, "pinned"), 0).Else(1), Asc)
+//
+// The sort is a field, an expression or a CASE expression. The order of NULL values is rendered
+// as NULLS FIRST or NULLS LAST, or by sorting by a CASE expression checking for NULL values first
+// in dialects without NULLS FIRST and NULLS LAST, such as MySQL and SQL Server. Unsupported
+// sorts are stored as an error of the query.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) OrderBy(by any, t domain.SortType, nulls ...domain.NullsOrder) *Query {
+	// check direction and order of null values
+	if t != domain.SortAsc && t != domain.SortDesc {
+		return qb.addError(fmt.Errorf("unsupported sort direction: %q", t))
+	}
+	for _, n := range nulls {
+		if n != domain.NullsDefault && n != domain.NullsFirst && n != domain.NullsLast {
+			return qb.addError(fmt.Errorf("unsupported order of null values: %q", n))
+		}
+	}
+
+	// sort field
+	field, err := sortField(by)
+	if err != nil {
+		return qb.addError(err)
+	}
+
+	// add sort
+	return qb.Sort(newSort(field, t, nulls))
+}
+
+// sortField returns the field of the sort of OrderBy, which is a field, or a computed field of
+// an expression or a CASE expression.
+//
+// Returns the field, or an error if the sort is not supported.
+func sortField(by any) (*domain.Field, error) {
+	switch v := by.(type) {
+	case *domain.Field:
+		return v, nil
+	case *domain.Expression:
+		if v == nil {
+			return nil, errors.New("nil expression in sort")
+		}
+		return v.As(""), nil
+	case *CaseExpression:
+		if v == nil {
+			return nil, errors.New("nil expression in sort")
+		}
+		return v.As(""), nil
+	default:
+		return nil, fmt.Errorf("unsupported sort type: %T", by)
+	}
+}
+
+// newSort returns the sort of the field in the given direction, with the first order of NULL
+// values, if any, see OrderBy.
+func newSort(field *domain.Field, t domain.SortType, nulls []domain.NullsOrder) *domain.Sort {
+	// sort
+	sort := &domain.Sort{Field: field, Type: t}
+
+	// set order of null values
+	if len(nulls) > 0 {
+		sort.Nulls = nulls[0]
+	}
+
+	// return sort
+	return sort
+}
+
+// Sort add sort. Nil sorts or sorts with nil fields are stored as an error of the query.
 func (qb *Query) Sort(sorts ...*domain.Sort) *Query {
 	// add sorts to query
 	for _, sort := range sorts {
+		// check is sort field not nil
+		if sort == nil || sort.Field == nil {
+			qb.addError(errors.New("nil field in sort"))
+			continue
+		}
+
+		// add sort
 		qb.sort = append(qb.sort, *sort)
 	}
 
@@ -1,6 +1,8 @@
 package qbr
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/tyrenix/qbr/domain"
@@ -11,23 +13,214 @@ import (
 const (
 	SqlDollar   domain.SqlPlaceholder = "$"
 	SqlQuestion domain.SqlPlaceholder = "?"
+	SqlAtP      domain.SqlPlaceholder = "@p"
+	SqlColon    domain.SqlPlaceholder = ":"
 )
 
+// Statement is a built SQL query.
+type Statement struct {
+	SQL         string                // Query string.
+	Params      []any                 // Query parameters.
+	Placeholder domain.SqlPlaceholder // Placeholder used in the query string.
+
+	// TenantUnscoped is true if the tenant scoping of the query was disabled,
+	// see Query.WithoutTenantScope.
+	TenantUnscoped bool
+
+	// Fingerprint is the hash of the structure of the query, see Query.Fingerprint.
+	Fingerprint string
+}
+
+// Placeholder sets the placeholder used by Build and ToSQL, the default is the placeholder of the
+// dialect, see WithDialect.
+//
+// It overrides the placeholder set by WithPlaceholder.
+func (qb *Query) Placeholder(placeholder domain.SqlPlaceholder) *Query {
+	// set placeholder
+	qb.options.Placeholder = placeholder
+
+	// return query
+	return qb
+}
+
+// GetPlaceholder returns the placeholder used by Build and ToSQL.
+func (qb *Query) GetPlaceholder() domain.SqlPlaceholder {
+	return qb.options.Placeholder
+}
+
+// Build validates the query (see Validate) and builds SQL statement for the table and with the
+// placeholder of the query.
+//
+// Returns the built statement, or the joined errors of the query if it is invalid or could not
+// be built.
+func (qb *Query) Build() (*Statement, error) {
+	return qb.BuildContext(context.Background())
+}
+
+// BuildContext builds SQL statement for the given context, see Build. Tenant scoped queries
+// are scoped to the tenant of the context, see TenantProvider. The build is wrapped in the
+// middlewares of the query, which get a copy of the query, see Use.
+func (qb *Query) BuildContext(ctx context.Context) (*Statement, error) {
+	// check is build wrapped
+	build, wrapped := qb.buildChain()
+	if !wrapped {
+		return build(ctx, qb)
+	}
+
+	// build copy of query, which can be mutated by middlewares
+	return build(ctx, qb.Clone())
+}
+
+// buildStatement validates the query and builds its SQL statement for the given context, see
+// BuildContext.
+func buildStatement(ctx context.Context, qb *Query) (*Statement, error) {
+	// validate query
+	if err := qb.Validate(); err != nil {
+		return nil, err
+	}
+
+	// build query
+	query, params, err := qb.toSql(ctx, "", qb.options.Placeholder)
+	if err != nil {
+		return nil, err
+	}
+
+	// return statement
+	return &Statement{
+		SQL:            query,
+		Params:         params,
+		Placeholder:    qb.options.Placeholder,
+		TenantUnscoped: qb.isTenantUnscoped(),
+		Fingerprint:    qb.Fingerprint(),
+	}, nil
+}
+
+// ToSQL builds SQL query for the table and with the placeholder of the query, see Build.
+func (qb *Query) ToSQL() (string, []any, error) {
+	return qb.ToSql("", qb.options.Placeholder)
+}
+
 // ToSql builds SQL query from the query builder data and returns it as a string, along with the query parameters and an error if the query could not be built.
 //
-// It supports the following query types: SELECT, INSERT, UPDATE, DELETE.
+// It supports the following query types: SELECT, INSERT, UPDATE, DELETE. If any errors occurred
+// while the query was built, they are returned joined instead of the query. If table is empty,
+// the table of the query is used (see GetTable and TableFunc). The query is not validated (see
+// Validate), except that UPDATE queries without data to set and UPDATE and DELETE queries
+// without conditions are rejected with ErrEmptySet and ErrMissingWhere.
 func (qb *Query) ToSql(table string, placeholder domain.SqlPlaceholder) (string, []any, error) {
+	return qb.toSql(context.Background(), table, placeholder)
+}
+
+// toSql builds SQL query for the given context, see ToSql. Tenant scoped queries are scoped
+// to the tenant of the context, see TenantProvider.
+func (qb *Query) toSql(ctx context.Context, table string, placeholder domain.SqlPlaceholder) (string, []any, error) {
+	// check data and conditions of writes, which are not validated by ToSql
+	if err := qb.checkWrite(); err != nil {
+		return "", nil, errors.Join(qb.err, err)
+	}
+
+	// build query in dialect with placeholder
+	query, params, err := qb.buildSql(ctx, table, qb.options.sqlDialect(placeholder), nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return query with hints and params for binding
+	return qb.withHints(query), bindParams(params, qb.options), nil
+}
+
+// buildSql builds SQL query for the given context in the dialect, see toSql, with its params
+// appended to the given params of an enclosing statement, see BuildSubquery. Hints are not
+// added and the params are not bound.
+func (qb *Query) buildSql(ctx context.Context, table string, d domain.Dialect, params []any) (string, []any, error) {
+	// query errors
+	err := errors.Join(qb.err, qb.checkOmits(), qb.checkFieldErrors())
+
+	// resolve table, which is not used with a FROM source
+	if table == "" && qb.from == nil {
+		resolved, tableErr := qb.resolveTable(ctx)
+		if tableErr != nil {
+			return "", nil, errors.Join(err, tableErr)
+		}
+		table = resolved
+	}
+
+	// check is table not empty and safe
+	switch {
+	case qb.from != nil:
+		// table of FROM source is checked by From
+	case table == "":
+		err = errors.Join(err, fmt.Errorf("missing table for %v query", qb.operation))
+	default:
+		err = errors.Join(err, qb.checkTable(table))
+	}
+
+	// add table prefix and quote table
+	table = qb.prefixTable(table)
+	if !qb.isTableUnsafe() {
+		table = d.QuoteIdent(table)
+	}
+
+	// check is query has errors
+	if err != nil {
+		return "", nil, err
+	}
+
+	// scope query to tenant
+	scoped, err := qb.withTenant(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// add default conditions
+	defaults, err := qb.defaultConditions()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(defaults) > 0 {
+		if scoped == qb {
+			scoped = qb.Clone()
+		}
+		scoped.conditions = append(scoped.conditions, defaults...)
+	}
+
+	// set inserted columns of upserts
+	scoped, err = scoped.withInsertedUpdates(scoped == qb)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// add keyset pagination condition
+	scoped = scoped.withKeyset(d, scoped == qb)
+
+	// add table alias
+	if qb.alias != "" {
+		table += " AS " + d.QuoteIdent(qb.alias)
+	}
+
+	// build subqueries for context
+	d = withContext(d, ctx)
+
 	// select need method for build
+	var query string
 	switch qb.operation {
 	case domain.OperationRead:
-		return sqlbuilder.CreateSelectSql(qb, table, placeholder)
+		query, params, err = sqlbuilder.CreateSelectSql(scoped, table, d, params)
 	case domain.OperationCreate:
-		return sqlbuilder.CreateInsertSql(qb, table, placeholder)
+		query, params, err = sqlbuilder.CreateInsertSql(scoped, table, d)
 	case domain.OperationUpdate:
-		return sqlbuilder.CreateUpdateSql(qb, table, placeholder)
+		query, params, err = sqlbuilder.CreateUpdateSql(scoped, table, d, params)
 	case domain.OperationDelete:
-		return sqlbuilder.CreateDeleteSql(qb, table, placeholder)
+		query, params, err = sqlbuilder.CreateDeleteSql(scoped, table, d, params)
 	default:
 		return "", nil, fmt.Errorf("unsupported query type: %v", qb.operation)
 	}
+
+	// check is query failed
+	if err != nil {
+		return "", nil, err
+	}
+
+	// return query and params
+	return query, params, nil
 }
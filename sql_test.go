@@ -0,0 +1,92 @@
+package qbr_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+// profile is a model of the tests with more fields than a map would keep in order.
+type profile struct {
+	ID       int64  `db:"id" qbr:"pk"`
+	Login    string `db:"login"`
+	Email    string `db:"email"`
+	Phone    string `db:"phone"`
+	Country  string `db:"country"`
+	City     string `db:"city"`
+	Street   string `db:"street"`
+	Zip      string `db:"zip"`
+	Age      int    `db:"age"`
+	Score    int    `db:"score"`
+	Verified bool   `db:"verified"`
+}
+
+// TableName returns the table of the model.
+func (profile) TableName() string {
+	return "profiles"
+}
+
+// sampleProfile returns a profile with all fields set.
+func sampleProfile() profile {
+	return profile{
+		ID: 1, Login: "ann", Email: "ann@example.com", Phone: "+4712345678", Country: "NO", City: "Oslo",
+		Street: "Karl Johans gate 1", Zip: "0154", Age: 41, Score: 97, Verified: true,
+	}
+}
+
+func TestBuildDeterministic(t *testing.T) {
+	// queries of every operation
+	age, country := qbr.FieldOf[profile]("age"), qbr.FieldOf[profile]("country")
+	queries := map[string]func() *qbr.Query{
+		"insert": func() *qbr.Query {
+			return qbr.NewCreate().Table("profiles").SetStruct(sampleProfile())
+		},
+		"update": func() *qbr.Query {
+			return qbr.NewUpdate().Table("profiles").SetStruct(sampleProfile()).Where(qbr.Eq(qbr.FieldOf[profile]("id"), 1))
+		},
+		"read": func() *qbr.Query {
+			return qbr.NewRead().Model(profile{}).Select(country, qbr.Count(qbr.NewAllField()).As("total")).
+				Where(qbr.Or(qbr.Gt(age, 18), qbr.In(country, "NO", "SE", "DK")), qbr.Like(qbr.FieldOf[profile]("email"), "%@example.com")).
+				GroupBy(country).Having(qbr.Gt(qbr.Count(qbr.NewAllField()).As("total"), 10)).
+				Sort(qbr.NewSortDesc(country)).Limit(20).Offset(40)
+		},
+	}
+
+	for _, name := range []string{"insert", "update", "read"} {
+		t.Run(name, func(t *testing.T) {
+			// first build
+			first, err := queries[name]().Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// byte-identical statements of repeated builds
+			for i := range 1000 {
+				stmt, err := queries[name]().Build()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stmt.SQL != first.SQL || !slices.Equal(stmt.Params, first.Params) || stmt.Fingerprint != first.Fingerprint {
+					t.Fatalf("build %d differs from the first build:\n%s %v\n%s %v", i+2, first.SQL, first.Params, stmt.SQL, stmt.Params)
+				}
+			}
+
+			// columns in the order of the struct fields
+			qbrtest.Golden(t, queries[name]())
+			qbrtest.Deterministic(t, func() qbrtest.Builder { return queries[name]() })
+		})
+	}
+}
+
+func TestBuildDialectsDeterministic(t *testing.T) {
+	for _, d := range []domain.Dialect{qbr.Postgres, qbr.MySQL, qbr.SQLite, qbr.SQLServer, qbr.Oracle, qbr.DB2} {
+		t.Run(d.Name(), func(t *testing.T) {
+			qbrtest.Deterministic(t, func() qbrtest.Builder {
+				return qbr.NewCreate(qbr.WithDialect(d)).Table("profiles").SetStruct(sampleProfile())
+			})
+		})
+	}
+}
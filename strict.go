@@ -0,0 +1,19 @@
+package qbr
+
+import "sync/atomic"
+
+// strictAnnotations reports whether unknown qbr annotations are rejected.
+var strictAnnotations atomic.Bool
+
+// SetStrictAnnotations enables or disables strict parsing of qbr annotations.
+//
+// In strict mode extracting fields from a struct returns an error listing every
+// unrecognized annotation together with its struct and field name, instead of
+// silently skipping it. Lenient parsing is the default.
+//
+// Returns the previous mode, so it can be restored in tests:
+//
+//	defer qbr.SetStrictAnnotations(qbr.SetStrictAnnotations(true))
+func SetStrictAnnotations(strict bool) bool {
+	return strictAnnotations.Swap(strict)
+}
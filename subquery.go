@@ -0,0 +1,59 @@
+package qbr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// BuildSubquery builds the read query as a subquery of a statement in the dialect of the
+// statement, with its params appended to the params of the statement, see domain.Subquery.
+//
+// The query is validated and built as by Build, but without its hints and middlewares. When a
+// statement is built by BuildContext, its subqueries are built for the same context, so they
+// are scoped to the tenant of the context, otherwise the background context is used.
+func (qb *Query) BuildSubquery(d domain.Dialect, params []any) (string, []any, error) {
+	// context of statement
+	ctx := context.Background()
+	if cd, ok := d.(contextDialect); ok {
+		ctx, d = cd.ctx, cd.Dialect
+	}
+
+	// check is read
+	if qb.operation != domain.OperationRead {
+		return "", nil, fmt.Errorf("subquery of %v query", qb.operation)
+	}
+
+	// validate query
+	if err := qb.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	// build query
+	return qb.buildSql(ctx, "", d, params)
+}
+
+// contextDialect is the dialect of a statement built for a context, which is passed to the
+// subqueries of the statement, so they are built for the same context, see BuildSubquery.
+type contextDialect struct {
+	domain.Dialect
+	ctx context.Context
+}
+
+// Unwrap returns the dialect of the statement without its context.
+func (d contextDialect) Unwrap() domain.Dialect {
+	return d.Dialect
+}
+
+// withContext returns the dialect for building a statement for the given context, see
+// contextDialect.
+func withContext(d domain.Dialect, ctx context.Context) domain.Dialect {
+	// unwrap dialect of enclosing statement
+	if cd, ok := d.(contextDialect); ok {
+		d = cd.Dialect
+	}
+
+	// return dialect with context
+	return contextDialect{Dialect: d, ctx: ctx}
+}
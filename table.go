@@ -0,0 +1,115 @@
+package qbr
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tableNamePattern matches the table names allowed in queries, optionally qualified by
+// a schema.
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// TableFunc resolves the table of a query when it is built, for example the partition
+// or the shard of the model.
+type TableFunc func(ctx context.Context, model any) (string, error)
+
+// TableNamer is implemented by models which define the name of their table.
+type TableNamer interface {
+	TableName() string
+}
+
+// Table sets the table of the query.
+//
+// The table set explicitly takes precedence over the table resolved from the
+// model of the query. It must be a valid identifier, optionally qualified by a
+// schema, or the query cannot be built, see UnsafeTable.
+func (qb *Query) Table(table string) *Query {
+	// set table
+	qb.table = table
+	qb.unsafeTable = false
+
+	// return query
+	return qb
+}
+
+// TableFunc sets the function resolving the table of the query when it is built, with the
+// context passed to BuildContext or the execution helpers and the model of the query.
+//
+// The resolved table takes precedence over the table set by Table and the table of the model.
+// It must be a valid identifier, optionally qualified by a schema, and errors of the function
+// are returned by Build. The method returns the QueryBuilder instance to support method chaining.
+func (qb *Query) TableFunc(fn TableFunc) *Query {
+	// set table function
+	qb.tableFunc = fn
+
+	// return query
+	return qb
+}
+
+// resolveTable returns the table of the query for the given context, which is resolved by the
+// table function of the query if set (see TableFunc), or by GetTable otherwise.
+func (qb *Query) resolveTable(ctx context.Context) (string, error) {
+	// check is table function set
+	if qb.tableFunc == nil {
+		return qb.GetTable(), nil
+	}
+
+	// resolve table
+	table, err := qb.tableFunc(ctx, qb.model)
+	if err != nil {
+		return "", fmt.Errorf("resolve table: %w", err)
+	}
+
+	// check table name
+	if !tableNamePattern.MatchString(table) {
+		return "", fmt.Errorf("invalid table name %q", table)
+	}
+
+	// return table
+	return table, nil
+}
+
+// prefixTable adds the table prefix of the query (see WithTablePrefix) to the table segment
+// of the given table name.
+func (qb *Query) prefixTable(table string) string {
+	// check is prefix set
+	if qb.options.TablePrefix == "" || table == "" {
+		return table
+	}
+
+	// add prefix to table segment
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		return table[:i+1] + qb.options.TablePrefix + table[i+1:]
+	}
+	return qb.options.TablePrefix + table
+}
+
+// Model sets the model of the query.
+//
+// The model is a struct or a pointer to a struct used to resolve the table of
+// the query when no table has been set explicitly.
+func (qb *Query) Model(model any) *Query {
+	// set model
+	qb.model = model
+
+	// return query
+	return qb
+}
+
+// GetTable returns the table of the query, or an empty string if no table can be resolved.
+//
+// The table is resolved in the following order: the table set by Table, the
+// result of the TableName method of the model, the "table" annotation of the
+// model, and the snake_case name of the model type. The function set by TableFunc is not
+// evaluated, and the table prefix (see WithTablePrefix) is not added.
+func (qb *Query) GetTable() string {
+	// check is table set explicitly
+	if qb.table != "" {
+		return qb.table
+	}
+
+	// resolve table from model
+	return extractTableFromStruct(qb.model)
+}
@@ -0,0 +1,118 @@
+package qbr
+
+import (
+	"context"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// TenantProvider returns the tenant of the given context, or nil if there is no tenant.
+//
+// If a provider is set by the WithTenant option, queries on models with a "tenant"
+// annotated column are scoped to the tenant: SELECT, UPDATE and DELETE queries only
+// affect rows of the tenant, INSERT queries set the column to the tenant, and UPDATE
+// queries never set the column. The tenant is read from the context passed to the
+// execution helpers (see Query.BuildContext), building a scoped query without a tenant
+// fails with ErrMissingTenant.
+type TenantProvider func(ctx context.Context) any
+
+// WithoutTenantScope disables the tenant scoping of the query, for example for admin jobs
+// which must access the rows of all tenants. Statements of such queries are marked with
+// TenantUnscoped, the logger of NewSlogLogger logs them on the warning level.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) WithoutTenantScope() *Query {
+	// disable tenant scope
+	qb.tenantUnscoped = true
+
+	// return query
+	return qb
+}
+
+// tenantField returns the tenant column of the model of the query, or nil if tenant
+// scoping is disabled or the model has no tenant column.
+func (qb *Query) tenantField() *domain.Field {
+	// check is tenant provider set
+	if qb.options.Tenant == nil {
+		return nil
+	}
+
+	// model type
+	t := structTypeOf(qb.model)
+	if t == nil {
+		return nil
+	}
+
+	// extract fields
+	fields, err := extractModelFields(t, qb.options)
+	if err != nil {
+		return nil
+	}
+
+	// find tenant field
+	i := slices.IndexFunc(fields, func(f *domain.Field) bool {
+		return f != nil && f.Tenant
+	})
+	if i < 0 {
+		return nil
+	}
+
+	// return tenant field
+	return fields[i]
+}
+
+// withTenant returns the query scoped to the tenant of the given context, or the query
+// itself if it is not tenant scoped.
+//
+// Returns ErrMissingTenant if the query is tenant scoped and the context has no tenant.
+func (qb *Query) withTenant(ctx context.Context) (*Query, error) {
+	// tenant field
+	field := qb.tenantField()
+	if field == nil || qb.tenantUnscoped {
+		return qb, nil
+	}
+
+	// get tenant
+	tenant := qb.options.Tenant(ctx)
+	if tenant == nil {
+		return nil, ErrMissingTenant
+	}
+
+	// scoped query
+	scoped := qb.Clone()
+
+	// data without tenant column
+	isTenant := func(d domain.Data) bool {
+		return d.Field.DB == field.DB
+	}
+	scoped.data = slices.DeleteFunc(scoped.data, isTenant)
+	for i, row := range scoped.rows {
+		scoped.rows[i] = slices.DeleteFunc(row, isTenant)
+	}
+
+	// scope query
+	switch qb.operation {
+	case domain.OperationCreate:
+		scoped.data = append(scoped.data, *NewData(field, tenant))
+		for i, row := range scoped.rows {
+			scoped.rows[i] = append(row, *NewData(field, tenant))
+		}
+	case domain.OperationUpdate:
+		// check is data left
+		if len(scoped.GetData()) == 0 {
+			return nil, ErrEmptySet
+		}
+		scoped.conditions = append(scoped.conditions, Eq(field, tenant))
+	default:
+		scoped.conditions = append(scoped.conditions, Eq(field, tenant))
+	}
+
+	// return scoped query
+	return scoped, nil
+}
+
+// isTenantUnscoped checks if the tenant scoping of a tenant scoped query is disabled.
+func (qb *Query) isTenantUnscoped() bool {
+	return qb.tenantUnscoped && qb.tenantField() != nil
+}
@@ -0,0 +1,113 @@
+package qbr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// Timeout sets the timeout of the execution of the query by the execution helpers, such as
+// Exec and TypedQuery.Find, which derive a context with the timeout as deadline. With the
+// WithStatementTimeout option, the timeout is also set as the statement timeout of the
+// database, so the database cancels the statement too, see WithStatementTimeout.
+//
+// If the timeout is exceeded, the execution helpers return an error wrapping
+// context.DeadlineExceeded with the statement, see Statement.DebugSQL.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) Timeout(d time.Duration) *Query {
+	// check is positive
+	if d <= 0 {
+		return qb.addError(fmt.Errorf("invalid timeout %v", d))
+	}
+
+	// set timeout
+	qb.timeout = d
+
+	// return query
+	return qb
+}
+
+// WithStatementTimeout makes the timeout of queries (see Query.Timeout) the statement timeout of
+// the database:
+//   - in PostgreSQL, the execution helpers run SET LOCAL statement_timeout before statements
+//     executed in a *sql.Tx, which applies until the end of the transaction;
+//   - in MySQL, SELECT statements are built with a MAX_EXECUTION_TIME optimizer hint, for
+//     example "SELECT /*+ MAX_EXECUTION_TIME(500) */ ...", which applies to the statement only.
+//
+// The other dialects have no statement timeouts, their queries are only canceled by the
+// deadline of the context.
+func WithStatementTimeout() Option {
+	return func(o *Options) error {
+		// set statement timeout
+		o.StatementTimeout = true
+		return nil
+	}
+}
+
+// withTimeout returns the context with the deadline of the timeout of the query, see Timeout.
+//
+// Returns the context and its cancel function, which must be called after the results of
+// the query are read.
+func (qb *Query) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	// check is timeout set
+	if qb.timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	// return context with timeout
+	return context.WithTimeout(ctx, qb.timeout)
+}
+
+// GetStatementTimeout returns the timeout of the query if it is set as the statement timeout of
+// the database (see WithStatementTimeout), or zero otherwise.
+func (qb *Query) GetStatementTimeout() time.Duration {
+	// check is statement timeout
+	if !qb.options.StatementTimeout {
+		return 0
+	}
+
+	// return timeout
+	return qb.timeout
+}
+
+// setStatementTimeout sets the timeout of the query as the statement timeout of the transaction,
+// if the executor is a transaction, the query has the WithStatementTimeout option and the
+// dialect sets statement timeouts of transactions, which is the case for PostgreSQL.
+func (qb *Query) setStatementTimeout(ctx context.Context, exec Executor) error {
+	// check is statement timeout of transaction
+	if _, ok := exec.(*sql.Tx); !ok {
+		return nil
+	}
+	query := sqlbuilder.StatementTimeoutSql(qb.options.Dialect, qb.GetStatementTimeout())
+	if query == "" {
+		return nil
+	}
+
+	// set statement timeout
+	if _, err := exec.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("set statement timeout: %w", err)
+	}
+	return nil
+}
+
+// timeoutError returns the error of the execution of the statement wrapped with the statement
+// and context.DeadlineExceeded, if the timeout of the query is exceeded, or the error otherwise.
+func (qb *Query) timeoutError(ctx context.Context, stmt *Statement, err error) error {
+	// check is timeout exceeded
+	if err == nil || qb.timeout <= 0 || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	// check is deadline error
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("query timed out after %v: %s: %w", qb.timeout, stmt.DebugSQL(), err)
+	}
+
+	// wrap deadline error
+	return fmt.Errorf("query timed out after %v: %s: %w: %w", qb.timeout, stmt.DebugSQL(), context.DeadlineExceeded, err)
+}
@@ -0,0 +1,69 @@
+package qbr_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestStatementTimeout(t *testing.T) {
+	// read of users with statement timeout in dialect
+	read := func(d domain.Dialect) *qbr.Query {
+		return qbr.NewRead(qbr.WithDialect(d), qbr.WithStatementTimeout()).Model(user{}).Timeout(1500 * time.Millisecond)
+	}
+
+	// statements of the read in a transaction
+	statements := func(t *testing.T, d domain.Dialect) []string {
+		db, drv := openRecording(t)
+		err := qbr.Transact(context.Background(), db, func(ctx context.Context, tx *sql.Tx) error {
+			rows, err := read(d).QueryContext(ctx, tx)
+			if err != nil {
+				return err
+			}
+			return rows.Close()
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return drv.statements()
+	}
+
+	t.Run("postgres", func(t *testing.T) {
+		got := statements(t, qbr.Postgres)
+		if len(got) != 4 || got[1] != "SET LOCAL statement_timeout = 1500" || strings.Contains(got[2], "MAX_EXECUTION_TIME") {
+			t.Fatalf("got statements %q, want SET LOCAL statement_timeout before the SELECT", got)
+		}
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		qbrtest.Golden(t, read(qbr.MySQL))
+
+		// no SET LOCAL in MySQL
+		got := statements(t, qbr.MySQL)
+		if len(got) != 3 || !strings.HasPrefix(got[1], "SELECT /*+ MAX_EXECUTION_TIME(1500) */ ") {
+			t.Fatalf("got statements %q, want a single SELECT with MAX_EXECUTION_TIME", got)
+		}
+	})
+
+	t.Run("other dialects", func(t *testing.T) {
+		for _, d := range []domain.Dialect{qbr.SQLite, qbr.SQLServer, qbr.Oracle, qbr.DB2} {
+			got := statements(t, d)
+			if len(got) != 3 || strings.Contains(got[1], "MAX_EXECUTION_TIME") {
+				t.Fatalf("got statements %q in the %s dialect, want the SELECT only", got, d.Name())
+			}
+		}
+	})
+
+	t.Run("without option", func(t *testing.T) {
+		qb := qbr.NewRead(qbr.WithDialect(qbr.MySQL)).Model(user{}).Timeout(time.Second)
+		if got := qb.GetStatementTimeout(); got != 0 {
+			t.Fatalf("got statement timeout %v, want 0", got)
+		}
+	})
+}
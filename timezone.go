@@ -0,0 +1,101 @@
+package qbr
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// WithTimeZone sets the time zone of the database timestamps, for example time.UTC for columns
+// of the type "timestamp without time zone" which are assumed to be UTC.
+//
+// The time.Time params, including non-nil pointers to them and the results of converters (see
+// RegisterConverter), are converted to the time zone before they are bound. Scanned times
+// are read as wall clock times of the time zone and converted back to the local time zone,
+// since drivers return timestamps without time zone as UTC wall clock times. The structs
+// of the caller are not modified.
+func WithTimeZone(loc *time.Location) Option {
+	return func(o *Options) error {
+		// check is nil
+		if loc == nil {
+			return fmt.Errorf("nil time zone")
+		}
+
+		// check is conflicting
+		if o.TimeZone != nil && o.TimeZone.String() != loc.String() {
+			return fmt.Errorf("conflicting time zone options: %q and %q", o.TimeZone, loc)
+		}
+
+		// set time zone
+		o.TimeZone = loc
+		return nil
+	}
+}
+
+// inTimeZone converts the value to the given time zone if it is a time.Time or a non-nil
+// pointer to a time.Time, and the time zone is not nil.
+//
+// Returns the converted time, or the value itself.
+func inTimeZone(value any, loc *time.Location) any {
+	// check is time zone set
+	if loc == nil {
+		return value
+	}
+
+	// convert time
+	switch v := value.(type) {
+	case time.Time:
+		return v.In(loc)
+	case *time.Time:
+		if v != nil {
+			return v.In(loc)
+		}
+	}
+
+	// return value
+	return value
+}
+
+// isTimeField checks if the struct field is a time.Time or a pointer to a time.Time.
+func isTimeField(v reflect.Value) bool {
+	return v.Type() == reflect.TypeFor[time.Time]() || v.Type() == reflect.TypeFor[*time.Time]()
+}
+
+// timeScanner scans a time into the value pointed to by v, which is a time.Time or a pointer
+// to a time.Time, reading it as a wall clock time of a time zone, see WithTimeZone.
+type timeScanner struct {
+	v   any
+	loc *time.Location
+}
+
+// Scan scans the source and converts it to the local time zone, NULL values set the zero value.
+func (s *timeScanner) Scan(src any) error {
+	// scan time
+	var t sql.NullTime
+	if err := t.Scan(src); err != nil {
+		return err
+	}
+
+	// destination value
+	dest := reflect.ValueOf(s.v).Elem()
+
+	// check is null
+	if !t.Valid {
+		dest.SetZero()
+		return nil
+	}
+
+	// read wall clock time in time zone
+	year, month, day := t.Time.Date()
+	hour, minute, sec := t.Time.Clock()
+	local := time.Date(year, month, day, hour, minute, sec, t.Time.Nanosecond(), s.loc).Local()
+
+	// set time
+	if dest.Kind() == reflect.Ptr {
+		dest.Set(reflect.ValueOf(&local))
+	} else {
+		dest.Set(reflect.ValueOf(local))
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package qbr
+
+import (
+	"slices"
+)
+
+// Tracked is a struct of the type T which tracks the columns touched by its changes, for update
+// queries which set only the touched columns, see Track.
+type Tracked[T any] struct {
+	original T        // Struct at the start of the tracking.
+	value    T        // Current struct.
+	touched  []string // Touched columns in the order of their touch.
+	opts     []Option // Options of the update query.
+	err      error    // Error of the tracking.
+}
+
+// Track returns a tracked copy of the struct value, T must be a struct type. The given options
+// are used to compare the changes (see Diff) and to build the update query, see BuildUpdate.
+func Track[T any](value T, opts ...Option) *Tracked[T] {
+	return &Tracked[T]{original: value, value: value, opts: opts}
+}
+
+// Get returns the current struct.
+func (t *Tracked[T]) Get() T {
+	return t.value
+}
+
+// Set changes the struct with the given function, and touches the columns of the fields which
+// changed, see Diff for the comparison of the fields. Assigning a field its current value does
+// not touch it, use Mark for that.
+//
+// The method returns the tracked struct for method chaining.
+func (t *Tracked[T]) Set(fn func(*T)) *Tracked[T] {
+	// change struct
+	before := t.value
+	fn(&t.value)
+
+	// changed fields
+	changed, _, err := Diff(before, t.value, t.opts...)
+	if err != nil {
+		t.err = err
+		return t
+	}
+
+	// touch changed columns
+	for _, field := range changed {
+		t.Mark(field.DB)
+	}
+
+	// return tracked struct
+	return t
+}
+
+// Mark marks the given columns, by their "db" annotation, as touched, so they are set by
+// the update query even if their value did not change.
+//
+// The method returns the tracked struct for method chaining.
+func (t *Tracked[T]) Mark(columns ...string) *Tracked[T] {
+	// touch columns
+	for _, column := range columns {
+		if !slices.Contains(t.touched, column) {
+			t.touched = append(t.touched, column)
+		}
+	}
+
+	// return tracked struct
+	return t
+}
+
+// Touched returns the touched columns in the order of their touch.
+func (t *Tracked[T]) Touched() []string {
+	return slices.Clone(t.touched)
+}
+
+// BuildUpdate returns an update query which sets the touched columns to their current values,
+// including zero values and nil values, which are set to NULL. The values are set as by SetMap,
+// so touched columns which are unknown or ignored for updates are errors of the query, and the
+// current struct is the model of the query. If T has a "version" annotated column, the query is
+// locked with the version at the start of the tracking, see SkipVersionCheck.
+//
+// Errors are stored in the query and returned by ToSql, which is ErrNoChanges if no columns
+// are touched. The conditions of the updated rows are added with Where.
+func (t *Tracked[T]) BuildUpdate() *Query {
+	// update query of the model
+	qb := NewUpdate(t.opts...).Model(t.value)
+
+	// check errors
+	if qb.err != nil {
+		return qb
+	}
+	if t.err != nil {
+		return qb.addError(t.err)
+	}
+
+	// lock version of original struct
+	data, err := extractDataFromStruct(t.original, qb.options)
+	if err != nil {
+		return qb.addError(err)
+	}
+	qb.setVersion(data)
+
+	// current values of columns
+	data, err = extractDataFromStruct(t.value, qb.options)
+	if err != nil {
+		return qb.addError(err)
+	}
+	values := make(map[string]any)
+	var versions []string
+	for _, d := range data {
+		if d.Field.Version {
+			versions = append(versions, d.Field.DB)
+		}
+		values[d.Field.DB] = d.Value
+	}
+
+	// touched values, the version is set by the lock and unknown columns are rejected by SetMap
+	touched := make(map[string]any, len(t.touched))
+	for _, column := range t.touched {
+		if slices.Contains(versions, column) {
+			continue
+		}
+		if value, ok := values[column]; ok {
+			touched[column] = value
+		} else {
+			touched[column] = nil
+		}
+	}
+
+	// check is touched
+	if len(touched) == 0 {
+		return qb.addError(ErrNoChanges)
+	}
+
+	// set touched values
+	return qb.SetMap(touched)
+}
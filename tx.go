@@ -0,0 +1,138 @@
+package qbr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// TxBeginner starts transactions.
+//
+// It is implemented by *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// txContextKey is the context key of the transaction of Transact.
+type txContextKey struct{}
+
+// txScope is the transaction of Transact with the dialect of its savepoints.
+type txScope struct {
+	tx      *sql.Tx        // Transaction.
+	dialect domain.Dialect // Dialect of the savepoints of nested transactions.
+}
+
+// savepointID is the sequence of the savepoint names of nested transactions.
+var savepointID atomic.Uint64
+
+// Transact calls fn in a transaction, which is committed if fn returns nil and rolled back
+// if fn returns an error or panics.
+//
+// The context passed to fn carries the transaction, so Transact calls nested in fn with this
+// context do not begin a new transaction: they run in a SAVEPOINT of the outer transaction,
+// which is released if the nested fn returns nil and rolled back to otherwise. So a failing
+// inner scope only rolls back its own work, and the outer fn decides whether to continue or
+// to return the error. The options apply to the outer transaction only.
+//
+// The savepoints use the syntax of PostgreSQL, which is shared by MySQL and SQLite, use
+// TransactDialect for the other dialects.
+//
+// Returns the error of fn, or an error if the transaction or the savepoint could not be
+// begun, committed or rolled back.
+func Transact(ctx context.Context, db TxBeginner, fn func(ctx context.Context, tx *sql.Tx) error, opts ...*sql.TxOptions) error {
+	return TransactDialect(ctx, Postgres, db, fn, opts...)
+}
+
+// TransactDialect calls fn in a transaction with the savepoints of nested transactions in the
+// syntax of the dialect, see Transact: SQL Server uses SAVE TRANSACTION and ROLLBACK
+// TRANSACTION, and Oracle and SQL Server do not release savepoints. Nested transactions use
+// the dialect of the outer transaction.
+//
+// Returns the error of fn, or an error if the transaction or the savepoint could not be
+// begun, committed or rolled back.
+func TransactDialect(ctx context.Context, d domain.Dialect, db TxBeginner, fn func(ctx context.Context, tx *sql.Tx) error, opts ...*sql.TxOptions) (err error) {
+	// check is nested transaction
+	if scope, ok := ctx.Value(txContextKey{}).(txScope); ok {
+		return transactSavepoint(ctx, scope, fn)
+	}
+
+	// transaction options
+	var txOpts *sql.TxOptions
+	if len(opts) > 0 {
+		txOpts = opts[0]
+	}
+
+	// begin transaction
+	tx, err := db.BeginTx(ctx, txOpts)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	// rollback on error or panic
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = errors.Join(err, fmt.Errorf("rollback transaction: %w", rbErr))
+			}
+		}
+	}()
+
+	// call function
+	if err = fn(context.WithValue(ctx, txContextKey{}, txScope{tx: tx, dialect: d}), tx); err != nil {
+		return err
+	}
+
+	// commit transaction
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// transactSavepoint calls fn in a savepoint of the transaction of the scope, see Transact.
+func transactSavepoint(ctx context.Context, scope txScope, fn func(ctx context.Context, tx *sql.Tx) error) (err error) {
+	// savepoint statements
+	tx := scope.tx
+	create, rollback, release := sqlbuilder.SavepointSql(scope.dialect, fmt.Sprintf("qbr_sp_%d", savepointID.Add(1)))
+
+	// create savepoint
+	if _, err := tx.ExecContext(ctx, create); err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+
+	// rollback to savepoint on error or panic
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = tx.ExecContext(ctx, rollback)
+			panic(p)
+		}
+		if err != nil {
+			if _, rbErr := tx.ExecContext(ctx, rollback); rbErr != nil {
+				err = errors.Join(err, fmt.Errorf("rollback to savepoint: %w", rbErr))
+			}
+		}
+	}()
+
+	// call function
+	if err = fn(ctx, tx); err != nil {
+		return err
+	}
+
+	// release savepoint, if the dialect releases savepoints
+	if release == "" {
+		return nil
+	}
+	if _, err = tx.ExecContext(ctx, release); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+	return nil
+}
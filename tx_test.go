@@ -0,0 +1,77 @@
+package qbr_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+)
+
+// savepointName matches the generated savepoint names of nested transactions.
+var savepointName = regexp.MustCompile(`qbr_sp_\d+`)
+
+func TestTransactSavepoints(t *testing.T) {
+	// errInner is the error of the failing nested transaction
+	errInner := errors.New("inner failed")
+
+	// statements of a transaction with a released and a rolled back savepoint in dialect
+	statements := func(t *testing.T, d domain.Dialect) []string {
+		db, drv := openRecording(t)
+		err := qbr.TransactDialect(context.Background(), d, db, func(ctx context.Context, tx *sql.Tx) error {
+			// nested transaction which succeeds
+			if err := qbr.Transact(ctx, db, func(context.Context, *sql.Tx) error { return nil }); err != nil {
+				return err
+			}
+
+			// nested transaction which fails
+			if err := qbr.Transact(ctx, db, func(context.Context, *sql.Tx) error { return errInner }); !errors.Is(err, errInner) {
+				t.Errorf("got error %v, want %v", err, errInner)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// statements with normalized savepoint names
+		got := drv.statements()
+		for i, query := range got {
+			got[i] = savepointName.ReplaceAllString(query, "sp")
+		}
+		return got
+	}
+
+	for _, tc := range []struct {
+		dialect domain.Dialect
+		want    []string
+	}{
+		{qbr.Postgres, []string{"BEGIN", "SAVEPOINT sp", "RELEASE SAVEPOINT sp", "SAVEPOINT sp", "ROLLBACK TO SAVEPOINT sp", "COMMIT"}},
+		{qbr.MySQL, []string{"BEGIN", "SAVEPOINT sp", "RELEASE SAVEPOINT sp", "SAVEPOINT sp", "ROLLBACK TO SAVEPOINT sp", "COMMIT"}},
+		{qbr.SQLServer, []string{"BEGIN", "SAVE TRANSACTION sp", "SAVE TRANSACTION sp", "ROLLBACK TRANSACTION sp", "COMMIT"}},
+		{qbr.Oracle, []string{"BEGIN", "SAVEPOINT sp", "SAVEPOINT sp", "ROLLBACK TO SAVEPOINT sp", "COMMIT"}},
+		{qbr.DB2, []string{"BEGIN", "SAVEPOINT sp ON ROLLBACK RETAIN CURSORS", "RELEASE SAVEPOINT sp", "SAVEPOINT sp ON ROLLBACK RETAIN CURSORS", "ROLLBACK TO SAVEPOINT sp", "COMMIT"}},
+	} {
+		t.Run(tc.dialect.Name(), func(t *testing.T) {
+			if got := statements(t, tc.dialect); !slices.Equal(got, tc.want) {
+				t.Fatalf("got statements %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("rollback", func(t *testing.T) {
+		// failing outer transaction is rolled back
+		db, drv := openRecording(t)
+		err := qbr.Transact(context.Background(), db, func(context.Context, *sql.Tx) error { return errInner })
+		if !errors.Is(err, errInner) {
+			t.Fatalf("got error %v, want %v", err, errInner)
+		}
+		if got, want := drv.statements(), []string{"BEGIN", "ROLLBACK"}; !slices.Equal(got, want) {
+			t.Fatalf("got statements %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,894 @@
+package qbr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// TypedQuery is a query builder bound to the model type T.
+//
+// Fields used in the query are validated against the fields of T, and the
+// results of the query are scanned into values of T.
+type TypedQuery[T any] struct {
+	query  *Query
+	fields []*domain.Field
+}
+
+// NewQuery creates a new query builder with given query type and options for the
+// model type T.
+//
+// T must be a struct type, its table is used as the table of the query (see
+// Query.GetTable).
+//
+// Returns created query builder.
+func NewQuery[T any](t domain.OperationType, opts ...Option) *TypedQuery[T] {
+	// create query builder
+	q := &TypedQuery[T]{
+		query: New(t, opts...).Model(*new(T)),
+	}
+
+	// struct type
+	st := reflect.TypeFor[T]()
+
+	// check is struct
+	if st.Kind() != reflect.Struct {
+		q.query.addError(fmt.Errorf("unsupported model type: %v", st))
+		return q
+	}
+
+	// extract fields
+	fields, err := extractModelFields(st, q.query.options)
+	if err != nil {
+		q.query.addError(err)
+		return q
+	}
+
+	// set fields
+	q.fields = fields
+
+	// return query builder
+	return q
+}
+
+// FieldOf returns the Field model of the struct field of T with the given "db" annotation.
+//
+// It is intended for creating field references once, for example in package variables:
+//
+//	var UserEmail = qbr.FieldOf[User]("email")
+//
+// The fields of T are extracted with the given options, such as WithSnakeCaseColumns, so
+// the options should match the options of the queries using the field. The table of T is
+// set as the table of the field, so conditions on fields of models which are neither the
+// model of the query nor joined are rejected by Validate with ErrForeignField.
+//
+// If T is not a struct, or it has no field with the given annotation, the returned field
+// carries the error, for example `unknown field "x" for model User`, which is returned by
+// the queries using the field, see Validate.
+func FieldOf[T any](db string, opts ...Option) *domain.Field {
+	// struct type
+	st := reflect.TypeFor[T]()
+
+	// check is struct
+	if st.Kind() != reflect.Struct {
+		return &domain.Field{DB: db, Err: fmt.Errorf("unsupported model type: %v", st)}
+	}
+
+	// create options
+	options, err := newOptions(opts...)
+	if err != nil {
+		return &domain.Field{DB: db, Err: err}
+	}
+
+	// extract fields
+	fields, err := extractModelFields(st, options)
+	if err != nil {
+		return &domain.Field{DB: db, Err: err}
+	}
+
+	// find field
+	for _, field := range fields {
+		if field != nil && field.DB == db {
+			// copy field with table of model
+			f := *field
+			f.Table = extractTableFromStruct(*new(T))
+			return &f
+		}
+	}
+
+	// not found
+	return &domain.Field{DB: db, Err: fmt.Errorf("unknown field %q for model %v", db, st)}
+}
+
+// Select sets the fields to be selected in the query, see Query.Select.
+//
+// Fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) Select(fields ...*domain.Field) *TypedQuery[T] {
+	// valid fields
+	valid := make([]*domain.Field, 0, len(fields))
+
+	// validate fields
+	for _, field := range fields {
+		if q.validateField(field) {
+			valid = append(valid, field)
+		}
+	}
+
+	// set select
+	q.query.Select(valid...)
+
+	// return query
+	return q
+}
+
+// SelectColumns adds the fields of T with the given "db" annotations to the fields to be
+// selected in the query, see Query.SelectColumns.
+func (q *TypedQuery[T]) SelectColumns(names ...string) *TypedQuery[T] {
+	// add fields
+	q.query.SelectColumns(names...)
+
+	// return query
+	return q
+}
+
+// Omit excludes the specified fields from the query, see Query.Omit.
+func (q *TypedQuery[T]) Omit(fields ...*domain.Field) *TypedQuery[T] {
+	// omit fields
+	q.query.Omit(fields...)
+
+	// return query
+	return q
+}
+
+// Where adds the specified conditions to the query, see Query.Where.
+//
+// Conditions on fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) Where(conds ...domain.Condition) *TypedQuery[T] {
+	// validate conditions
+	q.validateConditions(conds)
+
+	// add conditions
+	q.query.Where(conds...)
+
+	// return query
+	return q
+}
+
+// WhereIf adds the specified conditions to the query only if cond is true, see Where.
+func (q *TypedQuery[T]) WhereIf(cond bool, conds ...domain.Condition) *TypedQuery[T] {
+	// check is condition true
+	if !cond {
+		return q
+	}
+
+	// add conditions
+	return q.Where(conds...)
+}
+
+// WhereNotZero adds the equality condition of the field and the value to the query only if
+// the value is not nil or zero, see Query.WhereNotZero.
+func (q *TypedQuery[T]) WhereNotZero(field *domain.Field, value any) *TypedQuery[T] {
+	// check is value zero
+	if isZero(value) {
+		return q
+	}
+
+	// add condition
+	return q.Where(Eq(field, value))
+}
+
+// ApplyIf applies fn to the query only if cond is true, see Query.ApplyIf.
+func (q *TypedQuery[T]) ApplyIf(cond bool, fn func(*TypedQuery[T]) *TypedQuery[T]) *TypedQuery[T] {
+	// check is condition true
+	if !cond {
+		return q
+	}
+
+	// apply function
+	return fn(q)
+}
+
+// Scope adds the conditions of the given scopes to the query, see Query.Scope.
+//
+// Conditions on fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) Scope(scopes ...Scope) *TypedQuery[T] {
+	// validate conditions
+	for _, scope := range scopes {
+		q.validateConditions(scope.Conditions)
+	}
+
+	// add scopes
+	q.query.Scope(scopes...)
+
+	// return query
+	return q
+}
+
+// Merge merges the conditions of the other query into the query, see Query.Merge.
+//
+// Conditions on fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) Merge(other *Query) *TypedQuery[T] {
+	// validate conditions
+	if other != nil {
+		q.validateConditions(other.conditions)
+	}
+
+	// merge query
+	q.query.Merge(other)
+
+	// return query
+	return q
+}
+
+// Paginate sorts the query by the sort keys and limits it to a page, see Query.Paginate.
+//
+// Sorts by fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) Paginate(limit uint64, sorts ...*domain.Sort) *TypedQuery[T] {
+	return q.Sort(sorts...).Limit(limit)
+}
+
+// After restricts the query to the rows following the values of the sort keys, see Query.After.
+func (q *TypedQuery[T]) After(values ...any) *TypedQuery[T] {
+	// set values
+	q.query.After(values...)
+
+	// return query
+	return q
+}
+
+// GroupBy adds the fields to the GROUP BY clause, see Query.GroupBy.
+//
+// Fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) GroupBy(fields ...*domain.Field) *TypedQuery[T] {
+	// validate fields
+	for _, field := range fields {
+		q.validateField(field)
+	}
+
+	// add groups
+	q.query.GroupBy(fields...)
+
+	// return query
+	return q
+}
+
+// Distinct removes duplicate rows from the result, see Query.Distinct.
+func (q *TypedQuery[T]) Distinct() *TypedQuery[T] {
+	// set distinct
+	q.query.Distinct()
+
+	// return query
+	return q
+}
+
+// DistinctOn keeps the first row of every group of rows with equal values of the fields, see
+// Query.DistinctOn.
+//
+// Fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) DistinctOn(fields ...*domain.Field) *TypedQuery[T] {
+	// validate fields
+	for _, field := range fields {
+		q.validateField(field)
+	}
+
+	// add fields
+	q.query.DistinctOn(fields...)
+
+	// return query
+	return q
+}
+
+// Union combines the rows of the query with the rows of the queries, removing duplicates, see
+// Query.Union.
+func (q *TypedQuery[T]) Union(queries ...domain.Subquery) *TypedQuery[T] {
+	// add set operations
+	q.query.Union(queries...)
+
+	// return query
+	return q
+}
+
+// UnionAll combines the rows of the query with the rows of the queries, keeping duplicates, see
+// Query.UnionAll.
+func (q *TypedQuery[T]) UnionAll(queries ...domain.Subquery) *TypedQuery[T] {
+	// add set operations
+	q.query.UnionAll(queries...)
+
+	// return query
+	return q
+}
+
+// Intersect keeps the rows of the query which are returned by the queries too, see
+// Query.Intersect.
+func (q *TypedQuery[T]) Intersect(queries ...domain.Subquery) *TypedQuery[T] {
+	// add set operations
+	q.query.Intersect(queries...)
+
+	// return query
+	return q
+}
+
+// Except removes the rows returned by the queries from the rows of the query, see
+// Query.Except.
+func (q *TypedQuery[T]) Except(queries ...domain.Subquery) *TypedQuery[T] {
+	// add set operations
+	q.query.Except(queries...)
+
+	// return query
+	return q
+}
+
+// Having adds the conditions to the HAVING clause, see Query.Having.
+//
+// Conditions on fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) Having(conds ...domain.Condition) *TypedQuery[T] {
+	// validate conditions
+	q.validateConditions(conds)
+
+	// add conditions
+	q.query.Having(conds...)
+
+	// return query
+	return q
+}
+
+// OrderBy adds a sort by the field or expression to the query, see Query.OrderBy.
+//
+// Sorts by fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) OrderBy(by any, t domain.SortType, nulls ...domain.NullsOrder) *TypedQuery[T] {
+	// check sort field
+	if field, err := sortField(by); err == nil && field != nil && !q.validateField(field) {
+		return q
+	}
+
+	// add sort
+	q.query.OrderBy(by, t, nulls...)
+
+	// return query
+	return q
+}
+
+// Sort adds the sort parameters to the query, see Query.Sort.
+//
+// Sorts on fields which are not fields of T are stored as an error of the query.
+func (q *TypedQuery[T]) Sort(sorts ...*domain.Sort) *TypedQuery[T] {
+	// valid sorts
+	valid := make([]*domain.Sort, 0, len(sorts))
+
+	// validate sort fields
+	for _, sort := range sorts {
+		if sort != nil && q.validateField(sort.Field) {
+			valid = append(valid, sort)
+		}
+	}
+
+	// add sorts
+	q.query.Sort(valid...)
+
+	// return query
+	return q
+}
+
+// Set adds the fields of the given model to the query data, see Query.SetStruct.
+func (q *TypedQuery[T]) Set(model T) *TypedQuery[T] {
+	// set data
+	q.query.SetStruct(model)
+
+	// return query
+	return q
+}
+
+// SetAll adds the rows of the given models to the data of an insert query, which inserts them
+// with a single multi-row INSERT, see Query.SetStruct.
+func (q *TypedQuery[T]) SetAll(models []T) *TypedQuery[T] {
+	// set rows
+	q.query.SetStruct(models)
+
+	// return query
+	return q
+}
+
+// Limit sets the limit of the query.
+func (q *TypedQuery[T]) Limit(limit uint64) *TypedQuery[T] {
+	// set limit
+	q.query.Limit(limit)
+
+	// return query
+	return q
+}
+
+// Offset sets the offset of the query.
+func (q *TypedQuery[T]) Offset(offset uint64) *TypedQuery[T] {
+	// set offset
+	q.query.Offset(offset)
+
+	// return query
+	return q
+}
+
+// ForUpdate adds a FOR UPDATE locking clause to the query, see Query.ForUpdate.
+func (q *TypedQuery[T]) ForUpdate(of ...string) *TypedQuery[T] {
+	// set locking clause
+	q.query.ForUpdate(of...)
+
+	// return query
+	return q
+}
+
+// ForShare adds a FOR SHARE locking clause to the query, see Query.ForShare.
+func (q *TypedQuery[T]) ForShare(of ...string) *TypedQuery[T] {
+	// set locking clause
+	q.query.ForShare(of...)
+
+	// return query
+	return q
+}
+
+// NoWait makes the locking clause of the query fail immediately on locked rows, see Query.NoWait.
+func (q *TypedQuery[T]) NoWait() *TypedQuery[T] {
+	// set wait policy
+	q.query.NoWait()
+
+	// return query
+	return q
+}
+
+// SkipLocked makes the locking clause of the query skip locked rows, see Query.SkipLocked.
+func (q *TypedQuery[T]) SkipLocked() *TypedQuery[T] {
+	// set wait policy
+	q.query.SkipLocked()
+
+	// return query
+	return q
+}
+
+// Returning sets the fields returned by the write query, see Query.Returning.
+func (q *TypedQuery[T]) Returning(fields ...*domain.Field) *TypedQuery[T] {
+	// set returned fields
+	q.query.Returning(fields...)
+
+	// return query
+	return q
+}
+
+// Alias sets the alias of the table of T, see Query.Alias.
+func (q *TypedQuery[T]) Alias(alias string) *TypedQuery[T] {
+	// set alias
+	q.query.Alias(alias)
+
+	// return query
+	return q
+}
+
+// Using adds the sources joined into the update or delete query of T, see Query.Using.
+func (q *TypedQuery[T]) Using(sources ...any) *TypedQuery[T] {
+	// add sources
+	q.query.Using(sources...)
+
+	// return query
+	return q
+}
+
+// With adds the common table expression of the subquery, see Query.With.
+func (q *TypedQuery[T]) With(name string, sub domain.Subquery) *TypedQuery[T] {
+	// add common table expression
+	q.query.With(name, sub)
+
+	// return query
+	return q
+}
+
+// WithRecursive adds the recursive common table expression of the subquery, see
+// Query.WithRecursive.
+func (q *TypedQuery[T]) WithRecursive(name string, sub domain.Subquery) *TypedQuery[T] {
+	// add common table expression
+	q.query.WithRecursive(name, sub)
+
+	// return query
+	return q
+}
+
+// Join adds an INNER JOIN of the source, see Query.Join. The conditions are not validated
+// against the fields of T, since they reference the joined source.
+func (q *TypedQuery[T]) Join(source any, on ...domain.Condition) *TypedQuery[T] {
+	// add join
+	q.query.Join(source, on...)
+
+	// return query
+	return q
+}
+
+// LeftJoin adds a LEFT JOIN of the source, see Query.LeftJoin.
+func (q *TypedQuery[T]) LeftJoin(source any, on ...domain.Condition) *TypedQuery[T] {
+	// add join
+	q.query.LeftJoin(source, on...)
+
+	// return query
+	return q
+}
+
+// RightJoin adds a RIGHT JOIN of the source, see Query.RightJoin.
+func (q *TypedQuery[T]) RightJoin(source any, on ...domain.Condition) *TypedQuery[T] {
+	// add join
+	q.query.RightJoin(source, on...)
+
+	// return query
+	return q
+}
+
+// FullJoin adds a FULL JOIN of the source, see Query.FullJoin.
+func (q *TypedQuery[T]) FullJoin(source any, on ...domain.Condition) *TypedQuery[T] {
+	// add join
+	q.query.FullJoin(source, on...)
+
+	// return query
+	return q
+}
+
+// CrossJoin adds a CROSS JOIN of the source, see Query.CrossJoin.
+func (q *TypedQuery[T]) CrossJoin(source any) *TypedQuery[T] {
+	// add join
+	q.query.CrossJoin(source)
+
+	// return query
+	return q
+}
+
+// OnConflict sets the conflict target of the insert query, see Query.OnConflict.
+func (q *TypedQuery[T]) OnConflict(target ...*domain.Field) *TypedQuery[T] {
+	// set conflict target
+	q.query.OnConflict(target...)
+
+	// return query
+	return q
+}
+
+// OnConflictConstraint sets the conflict target to a named constraint, see Query.OnConflictConstraint.
+func (q *TypedQuery[T]) OnConflictConstraint(name string) *TypedQuery[T] {
+	// set conflict constraint
+	q.query.OnConflictConstraint(name)
+
+	// return query
+	return q
+}
+
+// OnConflictWhere adds the predicate of a partial unique index, see Query.OnConflictWhere.
+func (q *TypedQuery[T]) OnConflictWhere(conds ...domain.Condition) *TypedQuery[T] {
+	// add conflict target predicate
+	q.query.OnConflictWhere(conds...)
+
+	// return query
+	return q
+}
+
+// DoUpdateSet sets the conflict action to DO UPDATE with the given data, see Query.DoUpdateSet.
+func (q *TypedQuery[T]) DoUpdateSet(data ...*domain.Data) *TypedQuery[T] {
+	// set conflict action
+	q.query.DoUpdateSet(data...)
+
+	// return query
+	return q
+}
+
+// DoUpdate sets the conflict action to DO UPDATE of the given fields, see Query.DoUpdate.
+func (q *TypedQuery[T]) DoUpdate(fields ...*domain.Field) *TypedQuery[T] {
+	// set conflict action
+	q.query.DoUpdate(fields...)
+
+	// return query
+	return q
+}
+
+// DoNothing sets the conflict action to DO NOTHING, see Query.DoNothing.
+func (q *TypedQuery[T]) DoNothing() *TypedQuery[T] {
+	// set conflict action
+	q.query.DoNothing()
+
+	// return query
+	return q
+}
+
+// Ignore makes the insert skip the rows which cannot be inserted, see Query.Ignore.
+func (q *TypedQuery[T]) Ignore() *TypedQuery[T] {
+	// set ignore
+	q.query.Ignore()
+
+	// return query
+	return q
+}
+
+// Use adds middlewares wrapping the build of the query, see Query.Use.
+func (q *TypedQuery[T]) Use(mw ...Middleware) *TypedQuery[T] {
+	// add middlewares
+	q.query.Use(mw...)
+
+	// return query
+	return q
+}
+
+// UsePrimary forces the query to the writer of a Router, see Query.UsePrimary.
+func (q *TypedQuery[T]) UsePrimary() *TypedQuery[T] {
+	// use primary
+	q.query.UsePrimary()
+
+	// return query
+	return q
+}
+
+// Clone returns a deep copy of the query builder, see Query.Clone.
+func (q *TypedQuery[T]) Clone() *TypedQuery[T] {
+	return &TypedQuery[T]{
+		query:  q.query.Clone(),
+		fields: q.fields,
+	}
+}
+
+// Query returns the underlying untyped query builder.
+func (q *TypedQuery[T]) Query() *Query {
+	return q.query
+}
+
+// Cached enables caching of the results of the query, see Query.Cached.
+func (q *TypedQuery[T]) Cached(ttl time.Duration) *TypedQuery[T] {
+	// set time to live
+	q.query.Cached(ttl)
+
+	// return query
+	return q
+}
+
+// Placeholder sets the placeholder of the query, see Query.Placeholder.
+func (q *TypedQuery[T]) Placeholder(placeholder domain.SqlPlaceholder) *TypedQuery[T] {
+	// set placeholder
+	q.query.Placeholder(placeholder)
+
+	// return query
+	return q
+}
+
+// WithDialect sets the SQL dialect of the query, see Query.WithDialect.
+func (q *TypedQuery[T]) WithDialect(d domain.Dialect) *TypedQuery[T] {
+	// set dialect
+	q.query.WithDialect(d)
+
+	// return query
+	return q
+}
+
+// Build builds SQL statement for the table of T, see Query.Build.
+func (q *TypedQuery[T]) Build() (*Statement, error) {
+	return q.query.Build()
+}
+
+// BuildContext builds SQL statement for the table of T and the given context, see Query.BuildContext.
+func (q *TypedQuery[T]) BuildContext(ctx context.Context) (*Statement, error) {
+	return q.query.BuildContext(ctx)
+}
+
+// Fingerprint returns a stable hash of the structure of the query, see Query.Fingerprint.
+func (q *TypedQuery[T]) Fingerprint() string {
+	return q.query.Fingerprint()
+}
+
+// BuildSubquery builds the read query as a subquery of a statement, see Query.BuildSubquery.
+func (q *TypedQuery[T]) BuildSubquery(d domain.Dialect, params []any) (string, []any, error) {
+	return q.query.BuildSubquery(d, params)
+}
+
+// IncludeZeroValues keeps the zero values of the data and conditions added afterwards, see
+// Query.IncludeZeroValues.
+func (q *TypedQuery[T]) IncludeZeroValues() *TypedQuery[T] {
+	// keep zero values
+	q.query.IncludeZeroValues()
+
+	// return query
+	return q
+}
+
+// WithoutTenantScope disables the tenant scoping of the query, see Query.WithoutTenantScope.
+func (q *TypedQuery[T]) WithoutTenantScope() *TypedQuery[T] {
+	// disable tenant scope
+	q.query.WithoutTenantScope()
+
+	// return query
+	return q
+}
+
+// TableFunc sets the function resolving the table of the query, see Query.TableFunc.
+func (q *TypedQuery[T]) TableFunc(fn TableFunc) *TypedQuery[T] {
+	// set table function
+	q.query.TableFunc(fn)
+
+	// return query
+	return q
+}
+
+// Unscoped disables the default conditions of T, see Query.Unscoped.
+func (q *TypedQuery[T]) Unscoped() *TypedQuery[T] {
+	// disable default scopes
+	q.query.Unscoped()
+
+	// return query
+	return q
+}
+
+// Hint adds an optimizer hint to the query, see Query.Hint.
+func (q *TypedQuery[T]) Hint(text string) *TypedQuery[T] {
+	// add hint
+	q.query.Hint(text)
+
+	// return query
+	return q
+}
+
+// UseIndex adds a USE INDEX hint to the table of the read query of T, see Query.UseIndex.
+func (q *TypedQuery[T]) UseIndex(indexes ...string) *TypedQuery[T] {
+	// add index hint
+	q.query.UseIndex(indexes...)
+
+	// return query
+	return q
+}
+
+// ForceIndex adds a FORCE INDEX hint to the table of the read query of T, see Query.ForceIndex.
+func (q *TypedQuery[T]) ForceIndex(indexes ...string) *TypedQuery[T] {
+	// add index hint
+	q.query.ForceIndex(indexes...)
+
+	// return query
+	return q
+}
+
+// IgnoreIndex adds an IGNORE INDEX hint to the table of the read query of T, see
+// Query.IgnoreIndex.
+func (q *TypedQuery[T]) IgnoreIndex(indexes ...string) *TypedQuery[T] {
+	// add index hint
+	q.query.IgnoreIndex(indexes...)
+
+	// return query
+	return q
+}
+
+// Timeout sets the timeout of the execution of the query, see Query.Timeout.
+func (q *TypedQuery[T]) Timeout(d time.Duration) *TypedQuery[T] {
+	// set timeout
+	q.query.Timeout(d)
+
+	// return query
+	return q
+}
+
+// ToSQL builds SQL query for the table of T, see Query.ToSQL.
+func (q *TypedQuery[T]) ToSQL() (string, []any, error) {
+	return q.query.ToSQL()
+}
+
+// Find builds the query, executes it using the given executor and scans the
+// resulting rows into a slice of T.
+//
+// Returns the scanned slice, or an error if the query could not be built,
+// executed or scanned. The query is not executed if it has any errors. The
+// results of cached queries are read from the cache if stored, see Query.Cached.
+func (q *TypedQuery[T]) Find(ctx context.Context, exec Executor) ([]T, error) {
+	// derive context with timeout
+	ctx, cancel := q.query.withTimeout(ctx)
+	defer cancel()
+
+	// build query
+	stmt, err := q.query.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// cached results
+	var items []T
+	if q.query.isCached() && q.query.cacheGet(ctx, "find", stmt, &items) {
+		return items, nil
+	}
+
+	// execute query
+	rows, err := q.query.queryContext(ctx, exec, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	// scan rows
+	items, err = scanAll[T](rows, q.query.options, nil)
+	if err != nil {
+		return nil, q.query.timeoutError(ctx, stmt, err)
+	}
+
+	// cache results
+	if q.query.isCached() {
+		q.query.cacheSet(ctx, "find", stmt, items)
+	}
+
+	// return results
+	return items, nil
+}
+
+// Exec builds the query and executes it using the given executor, see Query.Exec.
+func (q *TypedQuery[T]) Exec(ctx context.Context, exec Executor) (*Result, error) {
+	return q.query.Exec(ctx, exec)
+}
+
+// ExecInsert executes the insert query and reports if its row was skipped, see Query.ExecInsert.
+func (q *TypedQuery[T]) ExecInsert(ctx context.Context, exec Executor) (InsertResult, error) {
+	return q.query.ExecInsert(ctx, exec)
+}
+
+// ExplainJSON builds the query and returns its execution plan, see Query.ExplainJSON.
+func (q *TypedQuery[T]) ExplainJSON(ctx context.Context, exec Executor) (*ExplainPlan, error) {
+	return q.query.ExplainJSON(ctx, exec)
+}
+
+// SkipVersionCheck disables optimistic locking of the query, see Query.SkipVersionCheck.
+func (q *TypedQuery[T]) SkipVersionCheck() *TypedQuery[T] {
+	// skip version check
+	q.query.SkipVersionCheck()
+
+	// return query
+	return q
+}
+
+// validateConditions validates the fields of the given conditions and their sub conditions.
+func (q *TypedQuery[T]) validateConditions(conds []domain.Condition) {
+	for _, cond := range conds {
+		// validate sub conditions
+		if sub, ok := cond.Value.([]domain.Condition); ok {
+			q.validateConditions(sub)
+			continue
+		}
+
+		// validate field
+		q.validateField(cond.Field)
+	}
+}
+
+// validateField checks if the given field is a field of T, the all field, or a computed field
+// of fields of T, and stores an error in the query otherwise. The errors of fields with an
+// error (see FieldOf) are returned by the query.
+//
+// Returns true if the field is valid.
+func (q *TypedQuery[T]) validateField(field *domain.Field) bool {
+	// check is field nil
+	if field == nil {
+		q.query.addError(fmt.Errorf("nil field for model %v", reflect.TypeFor[T]()))
+		return false
+	}
+
+	// check is field with error, which is returned by the query
+	if field.Err != nil {
+		return true
+	}
+
+	// check is all field
+	if field.DB == "*" {
+		return true
+	}
+
+	// validate fields of computed field
+	if field.Expression != nil {
+		valid := true
+		for _, f := range field.Expression.Fields() {
+			valid = q.validateField(f) && valid
+		}
+		return valid
+	}
+
+	// find field
+	for _, f := range q.fields {
+		if f != nil && f.DB == field.DB {
+			return true
+		}
+	}
+
+	// add unknown field error
+	q.query.addError(fmt.Errorf("unknown field %q for model %v", field.DB, reflect.TypeFor[T]()))
+
+	// invalid field
+	return false
+}
@@ -0,0 +1,287 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// URL filter reserved parameters.
+const (
+	urlSortParam  = "sort"
+	urlLimitParam = "limit"
+)
+
+// URLFilter is a filter parsed from URL query parameters.
+type URLFilter struct {
+	Conditions []domain.Condition // Conditions of the filter.
+	Sorts      []*domain.Sort     // Sort parameters of the filter.
+	Limit      uint64             // Limit of the filter, 0 if not set.
+}
+
+// ParseURLFilter parses the URL query parameters to a filter on the fields of the model.
+//
+// The parameters follow a constrained grammar:
+//
+//	?status=active&age[gte]=18&sort=-created_at,id&limit=20
+//
+// A parameter "<column>=<value>" or "<column>[<operator>]=<value>" creates a condition
+// on the field of the model with the given "db" annotation, only fields which are not
+// ignored for read operations are allowed. The accepted operators are eq, ne, lt, gt,
+// lte, gte, like, in and not_in, for example "name[like]=jo%" or
+// "status[in]=active,invited". Values are converted to the Go type of the field, the
+// values of in and not_in are separated by commas, and like patterns are accepted only
+// for string fields.
+//
+// The "sort" parameter contains a comma separated list of columns, prefixed with "-"
+// for descending order. The "limit" parameter sets the limit of the filter; if maxLimit
+// is not 0, the limit is capped at maxLimit, and maxLimit is used if no limit is given.
+//
+// Returns the filter, or the joined errors of all invalid parameters.
+func ParseURLFilter(values url.Values, model any, maxLimit uint64) (*URLFilter, error) {
+	// model type
+	t := structTypeOf(model)
+	if t == nil {
+		return nil, fmt.Errorf("unsupported filter model type: %T", model)
+	}
+
+	// extract fields
+	fields, err := extractFieldsFromType(t, Options{TagName: string(domain.QueryDB)})
+	if err != nil {
+		return nil, err
+	}
+
+	// filter
+	filter := &URLFilter{Limit: maxLimit}
+	// parameters errors
+	var errs []error
+
+	// parse parameters in sorted order, so the filter is deterministic
+	for _, key := range sortedKeys(values) {
+		for _, value := range values[key] {
+			if err := filter.parseParam(t, fields, key, value, maxLimit); err != nil {
+				errs = append(errs, fmt.Errorf("parameter %q: %w", key, err))
+			}
+		}
+	}
+
+	// check is parameters errors found
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// return filter
+	return filter, nil
+}
+
+// Apply adds the conditions, sort parameters and limit of the filter to the query.
+// The method returns the modified QueryBuilder instance for method chaining.
+func (f *URLFilter) Apply(qb *Query) *Query {
+	// add conditions and sorts
+	qb.Where(f.Conditions...).Sort(f.Sorts...)
+
+	// set limit
+	if f.Limit > 0 {
+		qb.Limit(f.Limit)
+	}
+
+	// return query
+	return qb
+}
+
+// parseParam parses the URL query parameter with the given key and value to the filter.
+func (f *URLFilter) parseParam(t reflect.Type, fields []*domain.Field, key, value string, maxLimit uint64) error {
+	switch key {
+	case urlSortParam:
+		return f.parseSort(fields, value)
+	case urlLimitParam:
+		return f.parseLimit(value, maxLimit)
+	}
+
+	// split column and operator
+	column, op := key, "eq"
+	if i := strings.IndexByte(key, '['); i >= 0 && strings.HasSuffix(key, "]") {
+		column, op = key[:i], key[i+1:len(key)-1]
+	}
+
+	// get operator
+	operator, ok := filterOperators[op]
+	if !ok {
+		return fmt.Errorf("unsupported operator %q", op)
+	}
+
+	// find field
+	index := findFilterField(fields, column)
+	if index < 0 {
+		return fmt.Errorf("unknown column %q", column)
+	}
+
+	// check operator for field type
+	typ := t.Field(index).Type
+	if err := checkFilterOperator(operator, typ); err != nil {
+		return err
+	}
+
+	// convert values of in and not_in, which are separated by commas
+	if operator == domain.OperatorIn || operator == domain.OperatorNotIn {
+		list := []any{}
+		for _, item := range strings.Split(value, ",") {
+			v, err := convertURLValue(item, typ)
+			if err != nil {
+				return err
+			}
+			list = append(list, v)
+		}
+
+		// add condition
+		f.Conditions = append(f.Conditions, domain.Condition{
+			Field:    fields[index],
+			Operator: operator,
+			Value:    list,
+		})
+		return nil
+	}
+
+	// convert value
+	v, err := convertURLValue(value, typ)
+	if err != nil {
+		return err
+	}
+
+	// add condition
+	f.Conditions = append(f.Conditions, domain.Condition{
+		Field:    fields[index],
+		Operator: operator,
+		Value:    v,
+	})
+	return nil
+}
+
+// parseSort parses the comma separated sort columns to the filter.
+func (f *URLFilter) parseSort(fields []*domain.Field, value string) error {
+	for _, column := range strings.Split(value, ",") {
+		// sort direction
+		desc := strings.HasPrefix(column, "-")
+		column = strings.TrimPrefix(column, "-")
+
+		// find field
+		index := findFilterField(fields, column)
+		if index < 0 {
+			return fmt.Errorf("unknown column %q", column)
+		}
+
+		// add sort
+		if desc {
+			f.Sorts = append(f.Sorts, NewSortDesc(fields[index]))
+		} else {
+			f.Sorts = append(f.Sorts, NewSortAsc(fields[index]))
+		}
+	}
+
+	// sort parsed
+	return nil
+}
+
+// parseLimit parses the limit to the filter, capped at maxLimit if it is not 0.
+func (f *URLFilter) parseLimit(value string, maxLimit uint64) error {
+	// parse limit
+	limit, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid limit %q", value)
+	}
+
+	// cap limit
+	if maxLimit > 0 && (limit == 0 || limit > maxLimit) {
+		limit = maxLimit
+	}
+
+	// set limit
+	f.Limit = limit
+	return nil
+}
+
+// findFilterField returns the index of the field with the given DB field name which is
+// not ignored for read operations, or -1 if there is no such field.
+func findFilterField(fields []*domain.Field, column string) int {
+	// find field
+	for i, field := range fields {
+		if field != nil && field.DB == column && !isFieldIgnored(field, domain.OperationRead) {
+			return i
+		}
+	}
+
+	// not found
+	return -1
+}
+
+// convertURLValue converts the string value of a URL parameter to the given type.
+//
+// Strings, booleans, numbers, time.Time in RFC3339 format and pointers to them are supported.
+func convertURLValue(value string, t reflect.Type) (any, error) {
+	// pointer type
+	if t.Kind() == reflect.Ptr {
+		// convert to element type
+		v, err := convertURLValue(value, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		// create pointer
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(reflect.ValueOf(v))
+		return ptr.Interface(), nil
+	}
+
+	// time type
+	if t == reflect.TypeOf(time.Time{}) {
+		v, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q", value)
+		}
+		return v, nil
+	}
+
+	// new value of type
+	v := reflect.New(t).Elem()
+
+	// convert by kind
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("invalid unsigned integer %q", value)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", value)
+		}
+		v.SetFloat(n)
+	default:
+		return nil, fmt.Errorf("unsupported field type %v", t)
+	}
+
+	// return value
+	return v.Interface(), nil
+}
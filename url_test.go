@@ -0,0 +1,49 @@
+package qbr_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestParseURLFilter(t *testing.T) {
+	// read of profiles filtered by the URL query parameters
+	read := func() qbrtest.Builder {
+		values, err := url.ParseQuery("zip=0154&age[gte]=18&city=Oslo&verified=true&country=NO&score[lt]=100&sort=-age,id&limit=20")
+		if err != nil {
+			t.Fatal(err)
+		}
+		filter, err := qbr.ParseURLFilter(values, profile{}, 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return filter.Apply(qbr.NewRead().Model(profile{}))
+	}
+
+	t.Run("sorted conditions", func(t *testing.T) {
+		qbrtest.Golden(t, read())
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		qbrtest.Deterministic(t, read)
+	})
+
+	t.Run("like and in", func(t *testing.T) {
+		values, err := url.ParseQuery("name[like]=jo&status[in]=active,invited")
+		if err != nil {
+			t.Fatal(err)
+		}
+		filter, err := qbr.ParseURLFilter(values, user{}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		qbrtest.Golden(t, filter.Apply(qbr.NewRead().Model(user{})))
+	})
+
+	t.Run("like on non-string field", func(t *testing.T) {
+		_, err := qbr.ParseURLFilter(url.Values{"age[like]": {"1"}}, user{}, 0)
+		wantError(t, err, `operator "like" requires a string field`)
+	})
+}
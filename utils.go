@@ -1,9 +1,16 @@
 package qbr
 
 import (
+	"cmp"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"maps"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
-	"time"
+	"sync"
 
 	"github.com/tyrenix/qbr/domain"
 )
@@ -11,20 +18,37 @@ import (
 // isZero check on zero value
 func isZero(value any) bool {
 	// check is nil
-	if value == nil {
+	if isNil(value) {
 		return true
 	}
 
+	// value types, such as ValueNull, are never zero
+	if _, ok := value.(domain.ValueType); ok {
+		return false
+	}
+
+	// driver values of NULL, such as sql.NullString with Valid false, are never zero, since
+	// they are set to NULL, see isNullValuer
+	if isNullValuer(value) {
+		return false
+	}
+
 	// get value by reflect
 	v := reflect.ValueOf(value)
 
-	// for pointer types
+	// for pointer types, slices including byte slices are zero only if nil, so empty
+	// binary values are kept
 	if v.Kind() == reflect.Ptr ||
 		v.Kind() == reflect.Slice ||
 		v.Kind() == reflect.Map ||
 		v.Kind() == reflect.Chan ||
 		v.Kind() == reflect.Interface {
-		return v.IsNil()
+		return false
+	}
+
+	// for types with own zero check, such as time.Time or decimals
+	if z, ok := value.(interface{ IsZero() bool }); ok {
+		return z.IsZero()
 	}
 
 	// is string check on empty
@@ -32,56 +56,174 @@ func isZero(value any) bool {
 		return v.String() == ""
 	}
 
-	// is number check on zero value
+	// is number, including time.Duration, check on zero value
 	if (v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64) ||
+		(v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uint64) ||
 		(v.Kind() >= reflect.Float32 && v.Kind() <= reflect.Float64) {
 		return v.IsZero()
 	}
 
-	// for Time types
-	if v.Kind() == reflect.Struct && v.Type() == reflect.TypeOf(time.Time{}) {
-		return v.Interface().(time.Time).IsZero()
+	// for arrays, such as the nil UUID
+	if v.Kind() == reflect.Array {
+		return v.IsZero()
 	}
 
 	// for other types return false
 	return false
 }
 
+// isNil check on nil value, including nil pointers, slices, maps, channels and interfaces
+func isNil(value any) bool {
+	// check is nil
+	if value == nil {
+		return true
+	}
+
+	// get value by reflect
+	v := reflect.ValueOf(value)
+
+	// for pointer types
+	if v.Kind() == reflect.Ptr ||
+		v.Kind() == reflect.Slice ||
+		v.Kind() == reflect.Map ||
+		v.Kind() == reflect.Chan ||
+		v.Kind() == reflect.Interface {
+		return v.IsNil()
+	}
+
+	// not nil
+	return false
+}
+
+// isNullValuer checks if the value implements driver.Valuer and its database value is NULL, for
+// example sql.NullString or sql.NullInt64 with Valid false. Such values are resolved to
+// domain.ValueNull, so they are set to NULL and compared with IS NULL instead of being bound.
+func isNullValuer(value any) bool {
+	// check is valuer
+	v, ok := value.(driver.Valuer)
+	if !ok || isNil(value) {
+		return false
+	}
+
+	// check is null, values which could not be converted are bound to report their error
+	dv, err := v.Value()
+	return err == nil && dv == nil
+}
+
+// isSkipped checks if the value is skipped by the given zero value policy: nil values are always
+// skipped, and zero values are skipped unless the policy is ZeroValueKeep.
+func isSkipped(value any, policy ZeroValuePolicy) bool {
+	// keep zero values
+	if policy == ZeroValueKeep {
+		return isNil(value)
+	}
+
+	// skip zero values
+	return isZero(value)
+}
+
+// fieldPolicy returns the zero value policy of the values of the field, which is ZeroValueKeep
+// for fields annotated with "keep_zero" and the given policy otherwise.
+func fieldPolicy(field *domain.Field, policy ZeroValuePolicy) ZeroValuePolicy {
+	// check is zero values kept
+	if field != nil && field.KeepZero {
+		return ZeroValueKeep
+	}
+
+	// return policy
+	return policy
+}
+
+// operationTypes contains all operation types supported by the query builder.
+var operationTypes = []domain.OperationType{
+	domain.OperationCreate,
+	domain.OperationRead,
+	domain.OperationUpdate,
+	domain.OperationDelete,
+}
+
+// operationAliases contains the aliases accepted in operation annotations together with the
+// operations they are expanded to.
+var operationAliases = map[string][]domain.OperationType{
+	"all":    operationTypes,
+	"write":  {domain.OperationCreate, domain.OperationUpdate},
+	"select": {domain.OperationRead},
+}
+
+// Limits of the struct tags parsed by extractFieldFromStruct, which defend against malformed
+// tags of generated code.
+const (
+	maxTagLength   = 1024 // Maximum length of the DB field name and the "qbr" tag, in bytes.
+	maxAnnotations = 64   // Maximum count of the annotations of a "qbr" tag.
+)
+
+// annotationListSeparator matches a comma separating annotation values together with the
+// surrounding whitespaces.
+var annotationListSeparator = regexp.MustCompile(`\s*,\s*`)
+
+// isOperationType checks if the given operation type is supported by the query builder.
+func isOperationType(op domain.OperationType) bool {
+	return isOperationIn(operationTypes, op)
+}
+
 // isFieldIgnored checks if a field is ignored for a given query type.
 //
-// The function checks if the query type is in the field's list of ignored operations.
-// If it is, the function returns true, indicating that the field is ignored. Otherwise,
-// it returns false.
+// The function checks if the query type is in the field's list of ignored operations,
+// or if the field has a list of allowed operations that does not contain the query type.
+// If so, the function returns true, indicating that the field is ignored. Otherwise,
+// it returns false. Fields annotated with "generated" are ignored for create queries too.
 func isFieldIgnored(field *domain.Field, queryType domain.OperationType) bool {
-	// check is ignored
-	for _, ignoreOp := range field.IgnoreOn {
-		if ignoreOp == queryType {
-			return true
-		}
+	// check is ignored, generated columns are not inserted
+	if isOperationIn(field.IgnoreOn, queryType) || (field.Generated && queryType == domain.OperationCreate) {
+		return true
 	}
 
-	// not ignored
-	return false
+	// check is not allowed
+	return len(field.OnlyOn) > 0 && !isOperationIn(field.OnlyOn, queryType)
 }
 
 // extractFieldFromStruct extracts a Field object from a given struct field.
 //
-// The function retrieves the "db" tag from the field annotation and uses it to
+// The function retrieves the "db" (or 'tag') tag from the field annotation and uses it to
 // initialize a Field object. If the "db" tag is empty, the function returns nil.
 // Additionally, the function checks for a "qbr" tag and parses any annotations
-// it contains. If the "qbr" tag includes an "ignore_on" annotation, the function
-// extracts the ignored operations and adds them to the Field's IgnoredOperations
-// slice.
+// it contains. If the "qbr" tag includes an "ignore_on" or "only_on" annotation,
+// the function extracts the operations and adds them to the Field's IgnoreOn or
+// OnlyOn slice. Using both annotations on the same field is an error. The
+// "col_on_<operation>" annotations set the field's DB field name per operation.
 //
 // The resulting Field object is returned, representing a database field with
-// optional ignored operations based on the struct field's annotations.
-func extractFieldFromStruct(ft reflect.StructField) *domain.Field {
+// optional ignored operations based on the struct field's annotations. An error
+// is returned if the annotations could not be parsed, if the tags are longer than
+// maxTagLength or contain more than maxAnnotations annotations, or, in strict mode,
+// if the "qbr" tag contains unknown annotations, or if the struct type or the
+// type of the struct field is nil. The parser never panics on malformed tags,
+// see FuzzStructTag. The struct type 'st' is used to name
+// the field in errors, and the options name the struct tag with the DB
+// field name, usually "db". Untagged fields are named after the struct field
+// in the snake_case column mode, see WithSnakeCaseColumns.
+func extractFieldFromStruct(st reflect.Type, ft reflect.StructField, o Options) (*domain.Field, error) {
+	// check are types set, the types of struct fields are used for the annotations
+	if st == nil || ft.Type == nil {
+		return nil, fmt.Errorf("invalid struct field %q without type", ft.Name)
+	}
+
 	// get tags from field annotation
-	db := ft.Tag.Get(string(domain.QueryDB))
+	db, tagged := ft.Tag.Lookup(o.TagName)
 
-	// check is not empty
-	if db == "" {
-		return nil
+	// derive column of untagged fields
+	if !tagged && o.SnakeCaseColumns && ft.IsExported() && !ft.Anonymous {
+		db = SnakeCase(ft.Name)
+	}
+
+	// check is not empty or skipped
+	if db == "" || db == "-" {
+		return nil, nil
+	}
+
+	// check is db tag not too long
+	if len(db) > maxTagLength {
+		return nil, fmt.Errorf("%s.%s: %s tag longer than %d bytes", st.Name(), ft.Name, o.TagName, maxTagLength)
 	}
 
 	// create field
@@ -94,9 +236,25 @@ func extractFieldFromStruct(ft reflect.StructField) *domain.Field {
 
 	// check is not empty
 	if qbr == "" {
-		return field
+		return field, nil
+	}
+
+	// check is query builder tag not too long
+	if len(qbr) > maxTagLength {
+		return nil, fmt.Errorf("%s.%s: %s tag longer than %d bytes", st.Name(), ft.Name, domain.QueryQbr, maxTagLength)
 	}
 
+	// remove spaces around list separators, so "ignore_on=create, update" is a single block
+	qbr = annotationListSeparator.ReplaceAllString(qbr, ",")
+
+	// check annotations count
+	if n := len(strings.Fields(qbr)); n > maxAnnotations {
+		return nil, fmt.Errorf("%s.%s: %d annotations, at most %d are allowed", st.Name(), ft.Name, n, maxAnnotations)
+	}
+
+	// unknown annotations errors
+	var errs []error
+
 	// get annotations from query builder annotation
 	for _, block := range strings.Split(qbr, " ") {
 		// check is not empty
@@ -107,17 +265,119 @@ func extractFieldFromStruct(ft reflect.StructField) *domain.Field {
 		// get annotation
 		switch {
 		case strings.HasPrefix(block, string(domain.QueryIgnoreOn)+"="):
-			field.IgnoreOn = append(
-				field.IgnoreOn,
-				extractIgnoredOperationOnAnnotations(block)...,
-			)
+			// extract ignored operations
+			ops, err := extractOperationsOnAnnotation(block, domain.QueryIgnoreOn)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", st.Name(), ft.Name, err)
+			}
+
+			// add ignored operations without duplicates
+			field.IgnoreOn = canonicalOperations(append(field.IgnoreOn, ops...))
+		case strings.HasPrefix(block, string(domain.QueryOnlyOn)+"="):
+			// extract allowed operations
+			ops, err := extractOperationsOnAnnotation(block, domain.QueryOnlyOn)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", st.Name(), ft.Name, err)
+			}
+
+			// add allowed operations without duplicates
+			field.OnlyOn = canonicalOperations(append(field.OnlyOn, ops...))
+		case strings.HasPrefix(block, string(domain.QueryColOn)):
+			// extract column for operations
+			ops, column, err := extractColumnOnAnnotation(block)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", st.Name(), ft.Name, err)
+			}
+
+			// init columns
+			if field.Columns == nil {
+				field.Columns = make(map[domain.OperationType]string, len(ops))
+			}
+
+			// set column for operations
+			for _, op := range ops {
+				field.Columns[op] = column
+			}
+		case block == string(domain.QueryVersion):
+			// check is integer field
+			if !isIntegerKind(ft.Type.Kind()) {
+				return nil, fmt.Errorf("%s.%s: %s annotation requires an integer field", st.Name(), ft.Name, domain.QueryVersion)
+			}
+
+			// set version column
+			field.Version = true
+		case block == string(domain.QueryTenant):
+			// set tenant column
+			field.Tenant = true
+		case block == string(domain.QueryJSON):
+			// set json column
+			field.JSON = true
+		case block == string(domain.QueryPK):
+			// set primary key column
+			field.PK = true
+		case block == string(domain.QueryGen):
+			// set generated column
+			field.Generated = true
+		case block == string(domain.QueryDup):
+			// allow duplicate column
+			field.Duplicate = true
+		case block == string(domain.QueryKeepZero):
+			// keep zero values
+			field.KeepZero = true
+		case strings.HasPrefix(block, string(domain.QueryDefault)+"="):
+			// check is supported default
+			value := strings.TrimPrefix(block, string(domain.QueryDefault)+"=")
+			if value != domain.DefaultNewUUID {
+				return nil, fmt.Errorf("%s.%s: unsupported %s %q", st.Name(), ft.Name, domain.QueryDefault, value)
+			}
+
+			// check is uuid field
+			if !isUUIDType(ft.Type) {
+				return nil, fmt.Errorf("%s.%s: %s=%s requires a uuid or string field", st.Name(), ft.Name, domain.QueryDefault, value)
+			}
+
+			// set default
+			field.Default = value
 		default:
-			continue
+			// unknown annotations are skipped in lenient mode
+			if !strictAnnotations.Load() {
+				continue
+			}
+
+			// annotation name
+			name, _, _ := strings.Cut(block, "=")
+
+			// add unknown annotation error
+			errs = append(errs, fmt.Errorf("%s.%s: unknown annotation %q", st.Name(), ft.Name, name))
 		}
 	}
 
+	// check is unknown annotations found
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// check is ignored and allowed operations are not mixed
+	if len(field.IgnoreOn) > 0 && len(field.OnlyOn) > 0 {
+		return nil, fmt.Errorf(
+			"%w: %s.%s: %s and %s annotations cannot be used together",
+			ErrConflictingAnnotations, st.Name(), ft.Name, domain.QueryIgnoreOn, domain.QueryOnlyOn,
+		)
+	}
+
 	// return fields
-	return field
+	return field, nil
+}
+
+// isIntegerKind checks if the kind is a signed or unsigned integer kind.
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
 }
 
 // extractDataFromStruct extracts fields from a given struct and returns them as a slice of Data.
@@ -125,8 +385,11 @@ func extractFieldFromStruct(ft reflect.StructField) *domain.Field {
 // is a valid struct type and iterates through its fields. For each field, it retrieves the field's
 // value and annotation, and constructs a Data object. Fields with a nil value or that do not have
 // a "db" annotation are ignored. The resulting slice of Data objects is returned, representing the
-// struct's fields ready for inclusion in a query.
-func extractDataFromStruct(s any) []*domain.Data {
+// struct's fields ready for inclusion in a query. The fields of embedded and nested structs are
+// included, see modelFields, unless the pointer to their struct is nil. The DB field names are
+// taken from the struct tag with the given name. An error is returned if the annotations of any field could not be parsed,
+// containing the errors of all such fields.
+func extractDataFromStruct(s any, o Options) ([]*domain.Data, error) {
 	// struct value
 	val := reflect.ValueOf(s)
 	// struct type
@@ -136,7 +399,7 @@ func extractDataFromStruct(s any) []*domain.Data {
 	if val.Kind() == reflect.Ptr {
 		// check is nil
 		if val.IsNil() {
-			return nil
+			return nil, nil
 		}
 
 		// dereference pointer
@@ -146,72 +409,409 @@ func extractDataFromStruct(s any) []*domain.Data {
 
 	// check is struct
 	if val.Kind() != reflect.Struct {
-		return nil
+		return nil, nil
+	}
+
+	// extract fields, including the fields of embedded and nested structs
+	fields, err := modelFields(t, o)
+	if err != nil {
+		return nil, err
 	}
 
 	// create data slice
 	var data []*domain.Data
 
 	// we go through the fields of the structure
-	for i := 0; i < val.NumField(); i++ {
-		// struct field
-		field := val.Field(i)
-		// field type
-		ft := t.Field(i)
+	for _, f := range fields {
+		// field value, fields of nil embedded and nested structs are not set
+		fv, ok := valueByIndex(val, f.index)
+		if !ok {
+			continue
+		}
 
 		// add data
-		data = append(data, NewData(
-			extractFieldFromStruct(ft),
-			field.Interface(),
-		))
+		data = append(data, NewData(f.field, fv.Interface()))
 	}
 
 	// return query
-	return data
+	return data, nil
+}
+
+// structTypeOf returns the struct type of the given struct or pointer to a struct, or nil if the
+// value is not a struct.
+func structTypeOf(s any) reflect.Type {
+	// value type
+	t := reflect.TypeOf(s)
+
+	// dereference pointer
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// check is struct
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// return type
+	return t
+}
+
+// fieldsKey is the key of the fields of a struct type in fieldsCache, with the options and the
+// annotations mode used to parse them.
+type fieldsKey struct {
+	t         reflect.Type
+	tagName   string
+	snakeCase bool
+	strict    bool
+}
+
+// fieldsCache caches the fields of the struct types parsed by extractFieldsFromType, so the
+// struct tags of a type are parsed once instead of on every build.
+var fieldsCache sync.Map
+
+// extractFieldsFromType extracts Field objects from the fields of the given struct type.
+//
+// The returned slice is aligned with the fields of the struct: the element at index i
+// describes the i-th struct field, and is nil if the field is unexported or does not
+// have a "db" annotation, where "db" is the name of the struct tag of the options. An
+// error is returned if the annotations of any field could not be parsed, containing
+// the errors of all such fields, or if two fields map to the same column, see
+// checkDuplicateColumns.
+//
+// The fields are parsed once per struct type and options, see fieldsCache, and are shared by
+// the callers, which must not modify them.
+func extractFieldsFromType(t reflect.Type, o Options) ([]*domain.Field, error) {
+	// cache key
+	key := fieldsKey{t: t, tagName: o.TagName, snakeCase: o.SnakeCaseColumns, strict: strictAnnotations.Load()}
+
+	// check is fields cached
+	if fields, ok := fieldsCache.Load(key); ok {
+		return slices.Clone(fields.([]*domain.Field)), nil
+	}
+
+	// parse fields
+	fields, err := parseFieldsFromType(t, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// cache fields
+	fieldsCache.Store(key, fields)
+
+	// return fields
+	return slices.Clone(fields), nil
+}
+
+// parseFieldsFromType extracts Field objects from the fields of the given struct type without
+// the cache, see extractFieldsFromType.
+func parseFieldsFromType(t reflect.Type, o Options) ([]*domain.Field, error) {
+	// create fields slice
+	fields := make([]*domain.Field, t.NumField())
+	// fields errors
+	var errs []error
+
+	// we go through the fields of the structure
+	for i := range fields {
+		// field type
+		ft := t.Field(i)
+
+		// skip unexported fields, like the blank table annotation field
+		if !ft.IsExported() {
+			continue
+		}
+
+		// extract field
+		f, err := extractFieldFromStruct(t, ft, o)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// set field
+		fields[i] = f
+	}
+
+	// check is fields errors found
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// check duplicate columns
+	if err := checkDuplicateColumns(t, fields); err != nil {
+		return nil, err
+	}
+
+	// return fields
+	return fields, nil
+}
+
+// checkDuplicateColumns checks that no two fields of the struct type t map to the same column
+// for an operation, unless both are annotated with "duplicate", for example to map a column of
+// a view to several fields. Fields ignored for an operation are not checked for it, so a column
+// can be read into one field and written from another.
+//
+// Returns an error wrapping ErrDuplicateColumn naming both struct fields, or nil.
+func checkDuplicateColumns(t reflect.Type, fields []*domain.Field) error {
+	// check every operation
+	for _, op := range []domain.OperationType{
+		domain.OperationCreate, domain.OperationRead, domain.OperationUpdate, domain.OperationDelete,
+	} {
+		// struct field index of every column
+		seen := make(map[string]int, len(fields))
+		for i, field := range fields {
+			// skip fields without column for operation
+			if field == nil || isFieldIgnored(field, op) {
+				continue
+			}
+
+			// check is column seen
+			column := field.Column(op)
+			j, ok := seen[column]
+			if !ok {
+				seen[column] = i
+				continue
+			}
+
+			// check is duplicate allowed
+			if field.Duplicate && fields[j].Duplicate {
+				continue
+			}
+			return fmt.Errorf(
+				"%w: %s.%s and %s.%s map to column %q on %s",
+				ErrDuplicateColumn, t.Name(), t.Field(j).Name, t.Name(), t.Field(i).Name, column, op,
+			)
+		}
+	}
+
+	// no duplicates
+	return nil
+}
+
+// extractTableFromStruct resolves the table of the given struct.
+//
+// If the struct or a pointer to it implements TableNamer, the result of its TableName
+// method is returned. Otherwise the function looks for a "table" annotation in the "qbr"
+// tags of the struct fields, usually set on a blank field:
+//
+//	_ struct{} `qbr:"table=users"`
+//
+// If no annotation is found, the snake_case name of the struct type is returned. If the
+// input is not a struct, the function returns an empty string.
+func extractTableFromStruct(s any) string {
+	// check is table namer
+	if tn, ok := s.(TableNamer); ok {
+		return tn.TableName()
+	}
+
+	// struct value
+	val := reflect.ValueOf(s)
+
+	// check is pointer
+	if val.Kind() == reflect.Ptr {
+		// check is nil
+		if val.IsNil() {
+			return ""
+		}
+
+		// dereference pointer
+		val = val.Elem()
+	}
+
+	// check is struct
+	if val.Kind() != reflect.Struct {
+		return ""
+	}
+
+	// check is pointer to struct is table namer
+	ptr := reflect.New(val.Type())
+	ptr.Elem().Set(val)
+	if tn, ok := ptr.Interface().(TableNamer); ok {
+		return tn.TableName()
+	}
+
+	// find table annotation
+	for i := 0; i < val.NumField(); i++ {
+		// query builder tag
+		qbr := val.Type().Field(i).Tag.Get(string(domain.QueryQbr))
+
+		// get annotations from query builder annotation
+		for _, block := range strings.Split(qbr, " ") {
+			if table, ok := strings.CutPrefix(block, string(domain.QueryTable)+"="); ok && table != "" {
+				return table
+			}
+		}
+	}
+
+	// derive table from struct name
+	return SnakeCase(val.Type().Name())
 }
 
-// extractIgnoredOperationOnAnnotations extracts the ignored operations from the given block string.
+// extractOperationsOnAnnotation extracts the operations from the given block string of the
+// given annotation.
 //
-// The block string is expected to be in the format "ignore_on=<operation1>,<operation2>,...".
+// The block string is expected to be in the format "<annotation>=<operation1>,<operation2>,...",
+// for example "ignore_on=create,update". The operations are parsed by parseOperations.
 //
-// The function splits the block by comma, trims the resulting strings, and adds them to a slice of
-// ignored operations. The operation types are converted to lower case to ensure consistency.
+// The function returns the canonicalized slice of operations, or an error if the block contains
+// an unknown operation type.
+func extractOperationsOnAnnotation(block string, annotation domain.QueryAnnotationType) ([]domain.OperationType, error) {
+	// delete from block annotation type and parse operations
+	return parseOperations(strings.TrimPrefix(block, string(annotation)+"="), annotation)
+}
+
+// extractColumnOnAnnotation extracts the operations and the DB field name from the given block
+// string of the column annotation.
 //
-// The function returns the slice of ignored operations.
-func extractIgnoredOperationOnAnnotations(block string) []domain.OperationType {
-	// delete from block annotation type
-	block = strings.TrimPrefix(block, string(domain.QueryIgnoreOn)+"=")
+// The block string is expected to be in the format "col_on_<operation>=<column>", for example
+// "col_on_read=user_name". The operation may be an alias (see operationAliases).
+//
+// The function returns the canonicalized slice of operations and the column, or an error if the
+// operation is unknown or the column is empty.
+func extractColumnOnAnnotation(block string) ([]domain.OperationType, string, error) {
+	// split annotation to operation and column
+	op, column, _ := strings.Cut(strings.TrimPrefix(block, string(domain.QueryColOn)), "=")
+
+	// check is column not empty
+	if column == "" {
+		return nil, "", fmt.Errorf("empty column in %s%s annotation", domain.QueryColOn, op)
+	}
+
+	// parse operations
+	ops, err := parseOperations(op, domain.QueryColOn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// check is operation not empty
+	if len(ops) == 0 {
+		return nil, "", fmt.Errorf("empty operation in %s annotation", domain.QueryColOn)
+	}
+
+	// return operations and column
+	return ops, column, nil
+}
 
+// parseOperations parses the comma separated list of operations of the given annotation.
+//
+// The function splits the list by comma, trims the resulting strings, and adds them to a slice of
+// operations. The operation types are converted to lower case to ensure consistency, and aliases
+// (see operationAliases) are expanded to the operations they stand for.
+//
+// The function returns the canonicalized slice of operations, or an error if the list contains
+// an unknown operation type.
+func parseOperations(list string, annotation domain.QueryAnnotationType) ([]domain.OperationType, error) {
 	// split by comma
-	ops := strings.Split(block, ",")
+	ops := strings.Split(list, ",")
 
-	// slice of ignored operations
-	ignOps := make([]domain.OperationType, 0, len(ops))
+	// slice of operations
+	result := make([]domain.OperationType, 0, len(ops))
 
-	// add ignored operations
+	// add operations
 	for _, op := range ops {
+		// normalize operation
+		op = strings.ToLower(strings.TrimSpace(op))
+
 		// check is not empty
 		if op == "" {
 			continue
 		}
 
+		// expand alias
+		if alias, ok := operationAliases[op]; ok {
+			result = append(result, alias...)
+			continue
+		}
+
+		// check is known operation
+		if !isOperationType(domain.OperationType(op)) {
+			return nil, fmt.Errorf("unknown operation %q in %s annotation", op, annotation)
+		}
+
 		// get operation type
-		ignOps = append(ignOps, domain.OperationType(strings.ToLower(op)))
+		result = append(result, domain.OperationType(op))
+	}
+
+	// return operations
+	return canonicalOperations(result), nil
+}
+
+// canonicalOperations returns the known operations contained in the given slice without
+// duplicates and in the order of operationTypes, so equal sets of operations are always
+// stored equally.
+func canonicalOperations(ops []domain.OperationType) []domain.OperationType {
+	// canonical operations
+	result := make([]domain.OperationType, 0, len(ops))
+
+	// add operations in the canonical order
+	for _, op := range operationTypes {
+		if isOperationIn(ops, op) {
+			result = append(result, op)
+		}
+	}
+
+	// return operations
+	return result
+}
+
+// isOperationIn checks if the operation is contained in the given slice of operations.
+func isOperationIn(ops []domain.OperationType, op domain.OperationType) bool {
+	for _, v := range ops {
+		if v == op {
+			return true
+		}
+	}
+
+	// not found
+	return false
+}
+
+// sortedKeys returns the keys of the map in sorted order, so maps are always processed
+// deterministically.
+func sortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// checkConditionFields checks that all the given conditions and their sub conditions,
+// except logical ones, have a Field.
+//
+// Returns an error for every condition with a nil Field, or nil if all fields are set.
+func checkConditionFields(conds []domain.Condition) error {
+	// conditions errors
+	var errs []error
+
+	// check conditions
+	for _, cond := range conds {
+		// check sub conditions
+		if sub, ok := cond.Value.([]domain.Condition); ok {
+			errs = append(errs, checkConditionFields(sub))
+			continue
+		}
+
+		// check is field not nil
+		if cond.Field == nil {
+			errs = append(errs, errors.New("nil field in condition"))
+		}
 	}
 
-	// return ignored operations
-	return ignOps
+	// return errors
+	return errors.Join(errs...)
 }
 
 // removeZeroCondition takes a variable number of conditions and returns a new slice
 // with the following changes:
-//  1. Conditions with a Value of nil or a zero value are removed.
+//  1. Conditions with a Value of nil or a zero value are removed, zero values are kept
+//     if the zero value policy is ZeroValueKeep or the field is annotated with "keep_zero".
 //  2. Conditions with a Field that is ignored for the current query type are removed.
 //  3. Conditions with a Value of domain.ValueNull are removed if the condition is not
 //     an aggregation or an equality/inequality check.
+//  4. Values of Optional are resolved: unset values are removed, NULL values are
+//     domain.ValueNull, and set values are kept even if they are zero.
+//  5. Values implementing driver.Valuer whose database value is NULL, such as
+//     sql.NullString with Valid false, are domain.ValueNull, see isNullValuer.
 //
 // The method returns the modified slice of conditions.
-func removeZeroCondition(conds ...domain.Condition) []domain.Condition {
+func removeZeroCondition(policy ZeroValuePolicy, conds ...domain.Condition) []domain.Condition {
 	// conditions for return
 	result := []domain.Condition{}
 
@@ -229,7 +829,7 @@ func removeZeroCondition(conds ...domain.Condition) []domain.Condition {
 		switch v := cond.Value.(type) {
 		case []domain.Condition:
 			// set new removed conditions
-			cond.Value = removeZeroCondition(v...)
+			cond.Value = removeZeroCondition(policy, v...)
 
 			// add formatted conditions
 			result = append(result, cond)
@@ -239,6 +839,18 @@ func removeZeroCondition(conds ...domain.Condition) []domain.Condition {
 				continue
 			}
 
+			// resolve optional value, unset values are removed and set values are kept even if zero
+			value, state, optional := resolveOptional(cond.Value)
+			if optional && state == optionalUnset {
+				continue
+			}
+			cond.Value = value
+
+			// resolve driver values of NULL
+			if isNullValuer(cond.Value) {
+				cond.Value = domain.ValueNull
+			}
+
 			// check if system conditional and handle value null case
 			switch t := cond.Value.(type) {
 			case domain.ValueType:
@@ -252,7 +864,7 @@ func removeZeroCondition(conds ...domain.Condition) []domain.Condition {
 			}
 
 			// check is not zero
-			if !isZero(v) {
+			if optional && state == optionalSet || !isSkipped(cond.Value, fieldPolicy(cond.Field, policy)) {
 				// add condition
 				result = append(result, cond)
 			}
@@ -0,0 +1,68 @@
+package qbr_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+)
+
+// fuzzStruct returns a value of a struct with a single int field F with the given tag.
+func fuzzStruct(tag reflect.StructTag) any {
+	t := reflect.StructOf([]reflect.StructField{{Name: "F", Type: reflect.TypeFor[int](), Tag: tag}})
+	return reflect.New(t).Elem().Interface()
+}
+
+func TestIgnoreOnAnnotation(t *testing.T) {
+	for _, tc := range []struct {
+		annotations string
+		want        []domain.OperationType
+	}{
+		{"ignore_on=create", []domain.OperationType{domain.OperationCreate}},
+		{"ignore_on=create, update", []domain.OperationType{domain.OperationCreate, domain.OperationUpdate}},
+		{"ignore_on=create, update,create", []domain.OperationType{domain.OperationCreate, domain.OperationUpdate}},
+		{"ignore_on=read,,read", []domain.OperationType{domain.OperationRead}},
+	} {
+		t.Run(tc.annotations, func(t *testing.T) {
+			field := qbr.NewFieldFromStruct(fuzzStruct(reflect.StructTag(`db:"f" qbr:"`+tc.annotations+`"`)), "F")
+			if field == nil || !slices.Equal(field.IgnoreOn, tc.want) {
+				t.Fatalf("got field %#v, want ignored on %v", field, tc.want)
+			}
+		})
+	}
+}
+
+func FuzzStructTag(f *testing.F) {
+	// seed corpus of tags
+	f.Add("id", "pk")
+	f.Add("name", "ignore_on=create, update only_on=read")
+	f.Add("version", "version keep_zero")
+	f.Add("email", "col_on_read=e col_on_update=")
+	f.Add("id", "default=uuid_v4")
+	f.Add("", "only_on=")
+	f.Add("x", "ignore_on=create,,, ,=")
+
+	f.Fuzz(func(t *testing.T, db, annotations string) {
+		// tag of the field, the parser must return errors instead of panicking
+		v := fuzzStruct(reflect.StructTag(`db:"` + db + `" qbr:"` + annotations + `"`))
+		_ = qbr.NewFieldFromStruct(v, "F")
+		_, _ = qbr.NewCreate().Table("t").SetStruct(v).Build()
+		_, _ = qbr.NewRead().Model(v).Build()
+	})
+}
+
+func FuzzRawStructTag(f *testing.F) {
+	// seed corpus of raw tags
+	f.Add(`db:"id" qbr:"pk"`)
+	f.Add(`db:"id`)
+	f.Add(`qbr:"only_on=read"`)
+	f.Add(`db:"\x00" qbr:"col_on_=x"`)
+
+	f.Fuzz(func(t *testing.T, tag string) {
+		v := fuzzStruct(reflect.StructTag(tag))
+		_ = qbr.NewFieldFromStruct(v, "F")
+		_, _ = qbr.NewUpdate().Table("t").SetStruct(v).AllowWithoutWhere().Build()
+	})
+}
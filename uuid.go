@@ -0,0 +1,90 @@
+package qbr
+
+import (
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/uuid"
+)
+
+// isUUIDType checks if the type can hold a generated UUID, which is the case for 16 byte
+// arrays, such as the UUID type of github.com/google/uuid, strings and pointers to them.
+func isUUIDType(t reflect.Type) bool {
+	// dereference pointer
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// check is uuid
+	return uuid.IsUUID(t) || t.Kind() == reflect.String
+}
+
+// needsDefault checks if the field has a client-side default (see domain.Field.Default) and
+// the given value is nil or zero.
+func needsDefault(field *domain.Field, value any) bool {
+	return field.Default == domain.DefaultNewUUID && isZero(value)
+}
+
+// newDefault returns a new client-side default of the type of the given value, which is a
+// random UUID of version 4.
+//
+// Returns the default, or an error if it could not be generated.
+func newDefault(value any) (any, error) {
+	// generate uuid
+	id, err := uuid.New()
+	if err != nil {
+		return nil, err
+	}
+
+	// value type
+	t := reflect.TypeOf(value)
+	ptr := t.Kind() == reflect.Ptr
+	if ptr {
+		t = t.Elem()
+	}
+
+	// create value of type
+	v := reflect.New(t)
+	if t.Kind() == reflect.String {
+		v.Elem().SetString(uuid.Format(id))
+	} else {
+		reflect.Copy(v.Elem(), reflect.ValueOf(id[:]))
+	}
+
+	// return value
+	if ptr {
+		return v.Interface(), nil
+	}
+	return v.Elem().Interface(), nil
+}
+
+// setDefaults sets the client-side defaults of the given data of a create query, see
+// domain.Field.Default.
+//
+// Returns the data with the defaults, or an error if a default could not be generated.
+func (qb *Query) setDefaults(data []*domain.Data) ([]*domain.Data, error) {
+	// check is create
+	if qb.operation != domain.OperationCreate {
+		return data, nil
+	}
+
+	// set defaults
+	for i, d := range data {
+		// check is default needed
+		if !needsDefault(d.Field, d.Value) {
+			continue
+		}
+
+		// create default
+		value, err := newDefault(d.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		// set data
+		data[i] = NewData(d.Field, value)
+	}
+
+	// return data
+	return data, nil
+}
@@ -0,0 +1,247 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tyrenix/qbr/domain"
+	"github.com/tyrenix/qbr/internal/sqlbuilder"
+)
+
+// AllowWithoutWhere allows UPDATE and DELETE queries without conditions, which
+// are rejected by Validate by default.
+func (qb *Query) AllowWithoutWhere() *Query {
+	// allow without where
+	qb.allowWithoutWhere = true
+
+	// return query
+	return qb
+}
+
+// Validate checks the query for structural mistakes before it is executed.
+//
+// It is run automatically by Build. Every problem is reported by a distinct
+// error, so callers can check them with errors.Is:
+//   - ErrEmptySet if an UPDATE query has no data to set;
+//   - ErrEmptyInsert if an INSERT query has no data and no selected rows, see
+//     InsertFromSelect;
+//   - ErrMissingWhere if an UPDATE or DELETE query has no conditions, unless
+//     allowed by AllowWithoutWhere;
+//   - ErrInvalidLock if a locking clause is set on other than a SELECT query,
+//     its wait policy is set without a lock strength, or the dialect of the
+//     query does not support the locking clause, its locked tables or its wait
+//     policy;
+//   - ErrInvalidConflict if a conflict clause is set on other than an INSERT
+//     query, has no action, or its conflict target is invalid;
+//   - ErrInvalidJoin if joins, a table alias or index hints are set on other
+//     than a SELECT query, or joined sources on other than an UPDATE or DELETE
+//     query;
+//   - ErrInvalidCompound if set operations are set on other than a SELECT query,
+//     or with a locking clause or keyset pagination;
+//   - the errors of fields created by FieldOf with an unknown annotation;
+//   - ErrForeignField if a condition is on a field of a model (see FieldOf) whose
+//     table is neither the table of the query nor a joined table;
+//   - ErrHavingWithoutGroupBy if HAVING conditions are set without GROUP BY;
+//   - ErrOffsetWithoutOrder if an offset is set without sort parameters in a
+//     dialect whose OFFSET clauses require an ORDER BY clause, such as SQL Server;
+//   - ErrUnsafeIdentifier if a column name is neither a valid identifier nor a
+//     column of the model of the query, see UnsafeIdent. Unsafe tables are
+//     reported by Build when the table is resolved, see UnsafeTable.
+//
+// The errors accumulated while building the query are returned too. Returns nil
+// if the query is valid.
+func (qb *Query) Validate() error {
+	// validation errors
+	errs := []error{qb.err}
+
+	// check data and conditions of writes
+	if err := qb.checkWrite(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check errors of fields
+	if err := qb.checkFieldErrors(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check fields of conditions
+	if err := qb.checkConditionTables(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check insert data
+	if qb.operation == domain.OperationCreate && len(qb.GetData()) == 0 && qb.insertSelect == nil {
+		errs = append(errs, ErrEmptyInsert)
+	}
+
+	// check offset
+	if err := qb.checkOffset(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check locking clause
+	if err := qb.checkLock(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check conflict clause
+	if err := qb.checkConflict(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check joins
+	if err := qb.checkJoins(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check keyset pagination
+	if err := qb.checkKeyset(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check groups
+	if err := qb.checkGroupBy(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check distinct
+	if err := qb.checkDistinct(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check common table expressions
+	if err := qb.checkCTEs(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check selected rows of insert
+	if err := qb.checkInsertSelect(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check set operations
+	if err := qb.checkCompounds(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// check identifiers
+	if err := qb.checkIdentifiers(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// return errors
+	return errors.Join(errs...)
+}
+
+// checkWrite checks the data to set of an UPDATE query and the conditions of an UPDATE or
+// DELETE query, which are checked by ToSql too, so the statements are never built without
+// them, including when their only condition was dropped because of a zero value or is an
+// empty logical condition, such as Or(Eq(name, "")).
+func (qb *Query) checkWrite() error {
+	// write errors
+	var errs []error
+
+	// check data
+	if qb.operation == domain.OperationUpdate && len(qb.GetData()) == 0 {
+		errs = append(errs, ErrEmptySet)
+	}
+
+	// check conditions
+	if (qb.operation == domain.OperationUpdate || qb.operation == domain.OperationDelete) &&
+		len(domain.PruneConditions(qb.conditions)) == 0 && !qb.allowWithoutWhere {
+		errs = append(errs, fmt.Errorf("%w: use AllowWithoutWhere to %v all rows", ErrMissingWhere, qb.operation))
+	}
+
+	// return errors
+	return errors.Join(errs...)
+}
+
+// checkFieldErrors returns the errors of the fields of the query, such as unknown fields of
+// FieldOf, which are checked by ToSql too.
+func (qb *Query) checkFieldErrors() error {
+	// fields errors
+	var errs []error
+
+	// check fields
+	for _, field := range qb.queryFields() {
+		if field != nil && field.Err != nil && !slices.ContainsFunc(errs, func(err error) bool {
+			return err.Error() == field.Err.Error()
+		}) {
+			errs = append(errs, field.Err)
+		}
+	}
+
+	// return errors
+	return errors.Join(errs...)
+}
+
+// checkConditionTables checks that the fields of the conditions of the query, which are bound
+// to the tables of their models by FieldOf, are fields of the table of the query or of a joined
+// table. Queries with a FROM source or joined subqueries are not checked, since the tables of
+// their columns are not known.
+func (qb *Query) checkConditionTables() error {
+	// tables of query
+	tables := map[string]bool{}
+	if table := qb.GetTable(); table != "" {
+		tables[table] = true
+	}
+	if qb.model != nil {
+		tables[extractTableFromStruct(qb.model)] = true
+	}
+
+	// check is query with known tables
+	if len(tables) == 0 || qb.from != nil {
+		return nil
+	}
+
+	// add joined tables
+	for _, join := range qb.joins {
+		if join.Source.Subquery != nil {
+			return nil
+		}
+		tables[join.Source.Table] = true
+	}
+
+	// fields errors
+	var errs []error
+
+	// check fields of conditions
+	var check func(conds []domain.Condition)
+	check = func(conds []domain.Condition) {
+		for _, cond := range conds {
+			// check sub conditions
+			if sub, ok := cond.Value.([]domain.Condition); ok {
+				check(sub)
+				continue
+			}
+
+			// check is field of table of query
+			if cond.Field != nil && cond.Field.Table != "" && !tables[cond.Field.Table] {
+				errs = append(errs, fmt.Errorf("%w: %s of table %s", ErrForeignField, cond.Field.DB, cond.Field.Table))
+			}
+		}
+	}
+	check(qb.conditions)
+
+	// return errors
+	return errors.Join(errs...)
+}
+
+// checkOffset checks the offset of the read query, which requires sort parameters in the
+// dialects whose OFFSET clauses require an ORDER BY clause, such as SQL Server. Limits of set
+// operations are rendered as OFFSET clauses too.
+func (qb *Query) checkOffset() error {
+	// check is offset rendered
+	if qb.operation != domain.OperationRead || (qb.offset == 0 && (qb.limit == 0 || len(qb.compounds) == 0)) {
+		return nil
+	}
+
+	// check is order required
+	if len(qb.sort) > 0 || !sqlbuilder.OffsetsOrdered(qb.options.Dialect) {
+		return nil
+	}
+
+	// return error
+	return fmt.Errorf("%w: sort the rows to skip them in the %s dialect", ErrOffsetWithoutOrder, qb.options.Dialect.Name())
+}
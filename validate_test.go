@@ -0,0 +1,112 @@
+package qbr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/qbrtest"
+)
+
+func TestValidate(t *testing.T) {
+	// fields of users
+	age, status := qbr.FieldOf[user]("age"), qbr.FieldOf[user]("status")
+
+	t.Run("having without group by", func(t *testing.T) {
+		qb := qbr.NewRead().Model(user{}).Having(qbr.Gt(age, 18))
+		if err := qb.Validate(); !errors.Is(err, qbr.ErrHavingWithoutGroupBy) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrHavingWithoutGroupBy)
+		}
+		if err := qb.GroupBy(status).Validate(); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+	})
+
+	t.Run("foreign field", func(t *testing.T) {
+		type order struct {
+			Total int `db:"total"`
+		}
+		total := qbr.FieldOf[order]("total")
+
+		qb := qbr.NewRead().Model(user{}).Where(qbr.Eq(total, 1))
+		if err := qb.Validate(); !errors.Is(err, qbr.ErrForeignField) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrForeignField)
+		}
+		if _, err := qb.Build(); !errors.Is(err, qbr.ErrForeignField) {
+			t.Fatalf("got error %v from Build, want %v", err, qbr.ErrForeignField)
+		}
+
+		// conditions on fields of joined models are valid
+		joined := qbr.NewRead().Model(user{}).Join("order", qbr.Eq(total, 1)).Where(qbr.Eq(total, 1))
+		if err := joined.Validate(); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+	})
+
+	t.Run("offset without order", func(t *testing.T) {
+		qb := qbr.NewRead(qbr.WithDialect(qbr.SQLServer)).Model(user{}).Limit(10).Offset(20)
+		if err := qb.Validate(); !errors.Is(err, qbr.ErrOffsetWithoutOrder) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrOffsetWithoutOrder)
+		}
+		if _, _, err := qb.ToSQL(); !errors.Is(err, qbr.ErrOffsetWithoutOrder) {
+			t.Fatalf("got error %v from ToSQL, want %v", err, qbr.ErrOffsetWithoutOrder)
+		}
+
+		// offsets without order are valid in other dialects
+		if err := qbr.NewRead().Model(user{}).Limit(10).Offset(20).Validate(); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+	})
+
+	t.Run("offset with order", func(t *testing.T) {
+		qbrtest.Golden(t, qbr.NewRead(qbr.WithDialect(qbr.SQLServer)).Model(user{}).Sort(qbr.NewSortAsc(qbr.FieldOf[user]("id"))).Limit(10).Offset(20))
+	})
+}
+
+func TestToSQLWrites(t *testing.T) {
+	// field of users
+	id := qbr.FieldOf[user]("id")
+
+	t.Run("update without data", func(t *testing.T) {
+		_, _, err := qbr.NewUpdate().Table("users").Where(qbr.Eq(id, 1)).ToSQL()
+		if !errors.Is(err, qbr.ErrEmptySet) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrEmptySet)
+		}
+	})
+
+	t.Run("delete without conditions", func(t *testing.T) {
+		_, _, err := qbr.NewDelete().Table("users").ToSql("", "")
+		if !errors.Is(err, qbr.ErrMissingWhere) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrMissingWhere)
+		}
+	})
+
+	t.Run("zero condition dropped", func(t *testing.T) {
+		// the only condition is dropped because of its zero value
+		_, _, err := qbr.NewUpdate().Table("users").Set(qbr.NewData(qbr.FieldOf[user]("name"), "ann")).Where(qbr.Eq(id, 0)).ToSQL()
+		if !errors.Is(err, qbr.ErrMissingWhere) {
+			t.Fatalf("got error %v, want %v", err, qbr.ErrMissingWhere)
+		}
+	})
+
+	t.Run("empty condition groups", func(t *testing.T) {
+		// the groups are empty because their only conditions are dropped
+		name := qbr.FieldOf[user]("name")
+		for _, qb := range []*qbr.Query{
+			qbr.NewDelete().Table("users").Where(qbr.Or(qbr.Eq(name, ""))),
+			qbr.NewDelete().Table("users").Where(qbr.And()),
+			qbr.NewUpdate().Table("users").Set(qbr.NewData(name, "ann")).Where(qbr.Not(qbr.Eq(name, ""))),
+		} {
+			if _, _, err := qb.ToSQL(); !errors.Is(err, qbr.ErrMissingWhere) {
+				t.Fatalf("got error %v, want %v", err, qbr.ErrMissingWhere)
+			}
+		}
+	})
+
+	t.Run("allowed without where", func(t *testing.T) {
+		query, _, err := qbr.NewDelete().Table("users").AllowWithoutWhere().ToSQL()
+		if err != nil || query != "DELETE FROM users" {
+			t.Fatalf("got %q, %v, want DELETE FROM users", query, err)
+		}
+	})
+}
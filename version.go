@@ -0,0 +1,68 @@
+package qbr
+
+import "github.com/tyrenix/qbr/domain"
+
+// SkipVersionCheck disables optimistic locking of the query: the version column of the
+// struct set by SetStruct is set as any other column, without checking its current value.
+//
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) SkipVersionCheck() *Query {
+	// skip version check
+	qb.skipVersion = true
+
+	// return query
+	return qb
+}
+
+// isVersionLocked checks if the query is an update with optimistic locking, which
+// is the case if the struct set by SetStruct has a "version" annotated column.
+func (qb *Query) isVersionLocked() bool {
+	return qb.version != nil && !qb.skipVersion && qb.operation == domain.OperationUpdate
+}
+
+// setVersion splits the data of the version column from the given data, the version is
+// used for optimistic locking of updates.
+//
+// Returns the data without the version column if the query is an update.
+func (qb *Query) setVersion(data []*domain.Data) []*domain.Data {
+	// check is update
+	if qb.operation != domain.OperationUpdate {
+		return data
+	}
+
+	// data without version column
+	result := make([]*domain.Data, 0, len(data))
+	for _, d := range data {
+		// set version
+		if d.Field.Version {
+			qb.version = d
+			continue
+		}
+
+		// add data
+		result = append(result, d)
+	}
+
+	// return data
+	return result
+}
+
+// versionData returns the data of the version column, which is incremented by one if
+// the query is locked, or set to the value of the struct otherwise.
+func (qb *Query) versionData() []domain.Data {
+	// check is version set
+	if qb.version == nil || isFieldIgnored(qb.version.Field, qb.operation) || qb.isOmitted(qb.version.Field) {
+		return nil
+	}
+
+	// check is locked
+	if !qb.isVersionLocked() {
+		if isSkipped(qb.version.Value, qb.options.ZeroValuePolicy) {
+			return nil
+		}
+		return []domain.Data{*qb.version}
+	}
+
+	// return incremented version
+	return []domain.Data{*NewData(qb.version.Field, Add(qb.version.Field, 1))}
+}
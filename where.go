@@ -1,6 +1,10 @@
 package qbr
 
-import "github.com/tyrenix/qbr/domain"
+import (
+	"reflect"
+
+	"github.com/tyrenix/qbr/domain"
+)
 
 // Or returns a condition that checks if any of the given conditions are true.
 //
@@ -22,6 +26,29 @@ func And(conds ...domain.Condition) domain.Condition {
 	}
 }
 
+// Not returns a condition that checks if the given conditions are not all true. The
+// conditions are joined by AND and can be nested with And and Or, for example:
+//
+//	Not(Or(Eq(status, "banned"), Lt(age, 18)))
+//
+// NOT (conds1 AND conds2 AND conds3 and so on)
+func Not(conds ...domain.Condition) domain.Condition {
+	return domain.Condition{
+		Operator: domain.OperatorNot,
+		Value:    conds,
+	}
+}
+
+// Cond returns a condition which is true if the given boolean expression is true, for
+// example Cond(Expr("tags && ?", tags)).
+func Cond(expr *domain.Expression) domain.Condition {
+	return domain.Condition{
+		Field:    expr.As(""),
+		Operator: domain.OperatorExpression,
+		Value:    true,
+	}
+}
+
 // Condition for equals.
 //
 // Eq returns a condition that checks if the value of the given field is equal to the given value.
@@ -44,6 +71,23 @@ func NoEq(field *domain.Field, val any) domain.Condition {
 	}
 }
 
+// IsNull returns a condition that checks if the value of the given field is NULL. The condition
+// is kept regardless of the zero value policy, unlike Eq with a nil value, and zero values
+// are compared with Some or IncludeZeroValues, for example Eq(balance, Some(0)).
+//
+// field IS NULL
+func IsNull(field *domain.Field) domain.Condition {
+	return Eq(field, domain.ValueNull)
+}
+
+// IsNotNull returns a condition that checks if the value of the given field is not NULL, see
+// IsNull.
+//
+// field IS NOT NULL
+func IsNotNull(field *domain.Field) domain.Condition {
+	return NoEq(field, domain.ValueNull)
+}
+
 // Lt returns a condition that checks if the value of the given field is less than the specified value.
 //
 // field < val
@@ -88,21 +132,225 @@ func GtOrEq(field *domain.Field, val any) domain.Condition {
 	}
 }
 
+// In returns a condition that checks if the value of the given field is one of the given
+// values, which are expanded to a placeholder per value, or in the rows of a subquery selecting a
+// single column, for example:
+//
+//	In(FieldOf[User]("id"), ids)
+//	In(FieldOf[User]("status"), "active", "invited")
+//	In(FieldOf[User]("id"), NewQuery[Order]("read").Select(FieldOf[Order]("user_id")))
+//
+// A single slice value is expanded to its elements, except byte slices, which are a single
+// value. A condition without values is kept regardless of the zero value policy and matches no
+// rows, since "1 = 0" is rendered in place of an empty list.
+//
+// field IN (val1, val2, ...) or field IN (SELECT ...)
+func In(field *domain.Field, values ...any) domain.Condition {
+	return domain.Condition{
+		Field:    field,
+		Operator: domain.OperatorIn,
+		Value:    inValues(values),
+	}
+}
+
+// NotIn returns a condition that checks if the value of the given field is not one of the given
+// values or not in the rows of a subquery, see In. A condition without values matches all rows.
+//
+// field NOT IN (val1, val2, ...) or field NOT IN (SELECT ...)
+func NotIn(field *domain.Field, values ...any) domain.Condition {
+	return domain.Condition{
+		Field:    field,
+		Operator: domain.OperatorNotIn,
+		Value:    inValues(values),
+	}
+}
+
+// inValues returns the value of an IN condition of the given values, which is the subquery of a
+// single subquery value, or the list of the values, with a single slice value expanded to its
+// elements, see In. The list of no values is not nil, so it is not removed as a zero condition.
+func inValues(values []any) any {
+	// check is single value
+	if len(values) != 1 {
+		return append([]any{}, values...)
+	}
+
+	// check is subquery
+	if sub, ok := values[0].(domain.Subquery); ok {
+		return sub
+	}
+
+	// check is slice, byte slices are a single value
+	v := reflect.ValueOf(values[0])
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		return append([]any{}, values...)
+	}
+
+	// expand slice
+	list := make([]any, v.Len())
+	for i := range list {
+		list[i] = v.Index(i).Interface()
+	}
+
+	// return list
+	return list
+}
+
+// Between returns a condition that checks if the value of the given field is between the lower
+// and upper bound, including the bounds. A condition whose bounds are both zero values is
+// kept regardless of the zero value policy. A nil bound, such as a nil pointer, leaves that
+// side open, so the condition is GtOrEq of the lower or LtOrEq of the upper bound, and a
+// condition whose bounds are both nil is dropped.
+//
+// field BETWEEN lo AND hi
+func Between(field *domain.Field, lo, hi any) domain.Condition {
+	// check is bound open
+	switch {
+	case isNil(hi):
+		return GtOrEq(field, lo)
+	case isNil(lo):
+		return LtOrEq(field, hi)
+	}
+
+	return domain.Condition{
+		Field:    field,
+		Operator: domain.OperatorBetween,
+		Value:    []any{lo, hi},
+	}
+}
+
+// Like returns a condition that checks if the value of the given field matches the pattern,
+// in which "%" matches any characters and "_" a single character, for example "john%".
+//
+// field LIKE pattern
+func Like(field *domain.Field, pattern string) domain.Condition {
+	return domain.Condition{
+		Field:    field,
+		Operator: domain.OperatorLike,
+		Value:    pattern,
+	}
+}
+
+// NotLike returns a condition that checks if the value of the given field does not match the
+// pattern, see Like.
+//
+// field NOT LIKE pattern
+func NotLike(field *domain.Field, pattern string) domain.Condition {
+	return domain.Condition{
+		Field:    field,
+		Operator: domain.OperatorNotLike,
+		Value:    pattern,
+	}
+}
+
+// ILike returns a condition that checks if the value of the given field matches the pattern
+// regardless of case, see Like. It is rendered as ILIKE in PostgreSQL, and by comparing the
+// lower case value and pattern in other dialects.
+//
+// field ILIKE pattern or LOWER(field) LIKE LOWER(pattern)
+func ILike(field *domain.Field, pattern string) domain.Condition {
+	return domain.Condition{
+		Field:    field,
+		Operator: domain.OperatorILike,
+		Value:    pattern,
+	}
+}
+
+// RegexMatch returns a condition that checks if the value of the given field matches the
+// regular expression, in the regular expression syntax of the database. It is rendered as "~"
+// in PostgreSQL and REGEXP in MySQL and SQLite, which requires a regexp function to be
+// registered by the driver, and is not supported in SQL Server.
+//
+// field ~ pattern or field REGEXP pattern
+func RegexMatch(field *domain.Field, pattern string) domain.Condition {
+	return domain.Condition{
+		Field:    field,
+		Operator: domain.OperatorRegexMatch,
+		Value:    pattern,
+	}
+}
+
+// Exists returns a condition that checks if the subquery returns any rows. The subquery can
+// reference the columns of the outer query, see Qualify.
+//
+// EXISTS (SELECT ...)
+func Exists(sub domain.Subquery) domain.Condition {
+	return Cond(&domain.Expression{Kind: domain.ExpressionSubquery, Name: "EXISTS", Subquery: sub})
+}
+
+// NotExists returns a condition that checks if the subquery returns no rows, see Exists.
+//
+// NOT EXISTS (SELECT ...)
+func NotExists(sub domain.Subquery) domain.Condition {
+	return Cond(&domain.Expression{Kind: domain.ExpressionSubquery, Name: "NOT EXISTS", Subquery: sub})
+}
+
 // Where adds the specified conditions to the QueryBuilder's conditions list.
-// If a condition's Value is nil or zero, it is ignored and not added.
+// If a condition's Value is nil or zero, it is ignored and not added (see ZeroValuePolicy), or
+// an error is stored in the query with the WithStrictZeroConditions option.
 // Additionally, if the condition's Field is ignored for the current query type, it is also ignored and not added.
+// If any condition has a nil Field, the conditions are not added and the error is stored in the query.
 // The method returns the modified QueryBuilder instance for method chaining.
 func (qb *Query) Where(conds ...domain.Condition) *Query {
+	// check conditions fields
+	if err := checkConditionFields(conds); err != nil {
+		return qb.addError(err)
+	}
+
+	// check zero conditions in strict mode
+	if qb.options.StrictZeroConditions {
+		if err := checkZeroConditions(qb.options.ZeroValuePolicy, conds); err != nil {
+			return qb.addError(err)
+		}
+	}
+
 	// add remove zero condition s
 	qb.conditions = append(
 		qb.conditions,
-		removeZeroCondition(conds...)...,
+		removeZeroCondition(qb.options.ZeroValuePolicy, conds...)...,
 	)
 
 	// return query
 	return qb
 }
 
+// WhereIf adds the specified conditions to the query, see Where, only if cond is true.
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) WhereIf(cond bool, conds ...domain.Condition) *Query {
+	// check is condition true
+	if !cond {
+		return qb
+	}
+
+	// add conditions
+	return qb.Where(conds...)
+}
+
+// WhereNotZero adds the equality condition of the field and the value to the query only if the
+// value is not nil or zero, regardless of the zero value policy of the query.
+// The method returns the modified QueryBuilder instance for method chaining.
+func (qb *Query) WhereNotZero(field *domain.Field, value any) *Query {
+	// check is value zero
+	if isZero(value) {
+		return qb
+	}
+
+	// add condition
+	return qb.Where(Eq(field, value))
+}
+
+// ApplyIf applies fn to the query only if cond is true, so optional parts of the query can
+// be added without breaking the method chain.
+// The method returns the query returned by fn, or the query itself if cond is false.
+func (qb *Query) ApplyIf(cond bool, fn func(*Query) *Query) *Query {
+	// check is condition true
+	if !cond {
+		return qb
+	}
+
+	// apply function
+	return fn(qb)
+}
+
 // GetConditions returns the conditions set for the query builder, or an empty slice if no conditions have been set.
 func (qb *Query) GetConditions() []domain.Condition {
 	// conditions for returning
@@ -111,6 +359,11 @@ func (qb *Query) GetConditions() []domain.Condition {
 	// copy query builder conditions
 	copy(conds, qb.conditions)
 
+	// add version check
+	if qb.isVersionLocked() {
+		conds = append(conds, Eq(qb.version.Field, qb.version.Value))
+	}
+
 	// return copy conditions
 	return conds
 }
@@ -0,0 +1,64 @@
+package qbr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/tyrenix/qbr"
+	"github.com/tyrenix/qbr/domain"
+)
+
+func TestEmptyConditionGroups(t *testing.T) {
+	// field of users
+	age := qbr.FieldOf[user]("age")
+
+	t.Run("string", func(t *testing.T) {
+		for _, tc := range []struct {
+			cond domain.Condition
+			want string
+		}{
+			{qbr.And(), "()"},
+			{qbr.Or(qbr.And(), qbr.Not()), "()"},
+			{qbr.Or(qbr.And(qbr.Or()), qbr.Gt(age, 18)), "(age > 18)"},
+			{qbr.Not(qbr.And(), qbr.Lt(age, 65)), "NOT (age < 65)"},
+		} {
+			if got := fmt.Sprintf("%v", tc.cond); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		}
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		query, params, err := qbr.NewRead().Model(user{}).Where(qbr.Or(qbr.And(qbr.Or()), qbr.Gt(age, 18)), qbr.And()).ToSQL()
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if want := "SELECT * FROM users WHERE (age > $1)"; query != want {
+			t.Fatalf("got %q, want %q", query, want)
+		}
+		if len(params) != 1 || params[0] != 18 {
+			t.Fatalf("got params %v, want [18]", params)
+		}
+	})
+}
+
+func TestBetween(t *testing.T) {
+	// field of users and open bounds
+	age := qbr.FieldOf[user]("age")
+	var none *int
+
+	for _, tc := range []struct {
+		cond domain.Condition
+		want string
+	}{
+		{qbr.Between(age, 18, 65), "SELECT * FROM users WHERE age BETWEEN $1 AND $2"},
+		{qbr.Between(age, 18, none), "SELECT * FROM users WHERE age >= $1"},
+		{qbr.Between(age, nil, 65), "SELECT * FROM users WHERE age <= $1"},
+		{qbr.Between(age, nil, none), "SELECT * FROM users"},
+	} {
+		query, _, err := qbr.NewRead().Model(user{}).Where(tc.cond).ToSQL()
+		if err != nil || query != tc.want {
+			t.Errorf("got %q, %v, want %q", query, err, tc.want)
+		}
+	}
+}
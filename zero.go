@@ -0,0 +1,73 @@
+package qbr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tyrenix/qbr/domain"
+)
+
+// WithStrictZeroConditions rejects conditions which would be dropped because of a nil or zero
+// value, so a filter such as "balance = 0" never silently becomes no filter with the default
+// zero value policy. Adding such a condition with Where stores an error wrapping
+// ErrZeroCondition in the query.
+//
+// Zero values are compared with the ZeroValueKeep policy (see IncludeZeroValues) or as set
+// optionals (see Some), and optional filters are added with WhereNotZero or WhereIf.
+func WithStrictZeroConditions() Option {
+	return func(o *Options) error {
+		// enable strict conditions
+		o.StrictZeroConditions = true
+		return nil
+	}
+}
+
+// IncludeZeroValues keeps the zero values of the data and conditions added to the query after
+// the call, so Where(Eq(balance, 0)) adds "balance = 0". Only nil values are skipped, as by the
+// ZeroValueKeep policy.
+//
+// It overrides the policy set by WithZeroValuePolicy. Use Some to keep the zero value of a
+// single condition instead:
+//
+//	qbr.NewRead().Where(qbr.Eq(balance, qbr.Some(0)))
+func (qb *Query) IncludeZeroValues() *Query {
+	// keep zero values
+	qb.options.ZeroValuePolicy = ZeroValueKeep
+
+	// return query
+	return qb
+}
+
+// checkZeroConditions checks that none of the given conditions and their sub conditions would
+// be dropped by the zero value policy because of a nil or zero value, see
+// WithStrictZeroConditions. Unset optionals and conditions on fields ignored for reads are
+// dropped explicitly and are not checked.
+//
+// Returns an error wrapping ErrZeroCondition for every such condition, or nil.
+func checkZeroConditions(policy ZeroValuePolicy, conds []domain.Condition) error {
+	// conditions errors
+	var errs []error
+
+	// check conditions
+	for _, cond := range conds {
+		// check sub conditions
+		if sub, ok := cond.Value.([]domain.Condition); ok {
+			errs = append(errs, checkZeroConditions(policy, sub))
+			continue
+		}
+
+		// check is dropped explicitly
+		value, _, optional := resolveOptional(cond.Value)
+		if optional || isFieldIgnored(cond.Field, domain.OperationRead) {
+			continue
+		}
+
+		// check is zero value
+		if isSkipped(value, fieldPolicy(cond.Field, policy)) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrZeroCondition, cond))
+		}
+	}
+
+	// return errors
+	return errors.Join(errs...)
+}